@@ -45,6 +45,8 @@ func DevCommand() *cobra.Command {
 	cmd.AddCommand(DevLintCommand())
 	cmd.AddCommand(DevValidateCommand())
 	cmd.AddCommand(DevGetResourcesCommand())
+	cmd.AddCommand(DevMutateCommand())
+	cmd.AddCommand(DevReplCommand())
 
 	return cmd
 }
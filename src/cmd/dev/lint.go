@@ -1,45 +1,64 @@
 package dev
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/defenseunicorns/go-oscal/src/pkg/files"
 	oscalValidation "github.com/defenseunicorns/go-oscal/src/pkg/validation"
 	pkgCommon "github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/common/lint"
 	"github.com/defenseunicorns/lula/src/pkg/common/network"
 	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
 var lintHelp = `
 To lint existing validation files:
 	lula dev lint -f <path1>,<path2>,<path3> [-r <result-file>]
+To write the findings of the lint rules to a json or yaml file:
+	lula dev lint -f <path> --findings-file findings.json
+To also fail on warnings of the lint rules:
+	lula dev lint -f <path> --fail-on warning
 `
 
 func DevLintCommand() *cobra.Command {
 
 	var (
-		inputFiles []string // -f --input-files
-		resultFile string   // -r --result-file
+		inputFiles   []string // -f --input-files
+		resultFile   string   // -r --result-file
+		findingsFile string   // --findings-file
+		failOn       string   // --fail-on
 	)
 
 	cmd := &cobra.Command{
-		Use:     "lint",
-		Short:   "Lint validation files against schema",
-		Long:    "Validate validation files are properly configured against the schema, file paths can be local or URLs (https://)",
+		Use:   "lint",
+		Short: "Lint validation files against schema and lint rules",
+		Long: "Validate validation files are properly configured against the schema, file paths can be local or URLs (https://). " +
+			"Validations are also checked by lint rules, e.g. for rego that fails to compile or output paths the policy does not define, each reporting findings with a rule ID and a severity. " +
+			"Findings at or above the --fail-on severity fail the lint.",
 		Example: lintHelp,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(inputFiles) == 0 {
 				return fmt.Errorf("no input files specified")
 			}
+			failOnSeverity, err := lint.ParseSeverity(failOn)
+			if err != nil {
+				return fmt.Errorf("invalid --fail-on: %v", err)
+			}
 
 			config, _ := cmd.Flags().GetStringSlice("set")
 			message.Debug("command line 'set' flags: %s", config)
 
-			validationResults := DevLint(inputFiles, config)
+			validationResults, findings := DevLint(cmd.Context(), inputFiles, config)
 
 			// If result file is specified, write the validation results to the file
-			var err error
 			if resultFile != "" {
 				// If there is only one validation result, write it to the file
 				if len(validationResults) == 1 {
@@ -52,6 +71,11 @@ func DevLintCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("error writing validation results: %v", err)
 			}
+			if findingsFile != "" {
+				if err := writeLintFindings(findings, findingsFile); err != nil {
+					return fmt.Errorf("error writing lint findings: %v", err)
+				}
+			}
 
 			// If there is at least one validation result that is not valid, exit with a fatal error
 			failedFiles := []string{}
@@ -60,6 +84,11 @@ func DevLintCommand() *cobra.Command {
 					failedFiles = append(failedFiles, result.Metadata.DocumentPath)
 				}
 			}
+			for _, finding := range findings {
+				if finding.Severity.AtLeast(failOnSeverity) && !slices.Contains(failedFiles, finding.DocumentPath) {
+					failedFiles = append(failedFiles, finding.DocumentPath)
+				}
+			}
 			if len(failedFiles) > 0 {
 				return fmt.Errorf("the following files failed linting: %s", strings.Join(failedFiles, ", "))
 			}
@@ -68,12 +97,17 @@ func DevLintCommand() *cobra.Command {
 	}
 	cmd.Flags().StringSliceVarP(&inputFiles, "input-files", "f", []string{}, "the paths to validation files (comma-separated)")
 	cmd.Flags().StringVarP(&resultFile, "result-file", "r", "", "the path to write the validation result")
+	cmd.Flags().StringVar(&findingsFile, "findings-file", "", "the path to write the findings of the lint rules, as json or yaml by its extension")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(lint.SeverityError), "the lowest severity of lint rule findings that fails the lint: error, warning or info")
 
 	return cmd
 }
 
-func DevLint(inputFiles []string, setOpts []string) []oscalValidation.ValidationResult {
+// DevLint lints the validations of each input file against the schema, and runs the lint rules
+// against them, returning the schema validation results and the findings of the rules
+func DevLint(ctx context.Context, inputFiles []string, setOpts []string) ([]oscalValidation.ValidationResult, []lint.Finding) {
 	var validationResults []oscalValidation.ValidationResult
+	findings := make([]lint.Finding, 0)
 
 	for _, inputFile := range inputFiles {
 		var result oscalValidation.ValidationResult
@@ -111,6 +145,9 @@ func DevLint(inputFiles []string, setOpts []string) []oscalValidation.Validation
 			break
 		}
 
+		// Files referenced by the validations are relative to the input file
+		lintCtx := context.WithValue(ctx, types.LulaValidationWorkDir, filepath.Dir(inputFile))
+
 		allValid := true
 		// Lint each validation in the file
 		for _, validation := range validations {
@@ -122,6 +159,16 @@ func DevLint(inputFiles []string, setOpts []string) []oscalValidation.Validation
 			if !result.Valid {
 				allValid = false
 			}
+
+			for _, finding := range lint.Lint(lintCtx, &validation) {
+				finding.DocumentPath = inputFile
+				findings = append(findings, finding)
+				if finding.Severity == lint.SeverityInfo {
+					message.Infof("%s: %s", inputFile, finding)
+				} else {
+					message.Warnf("%s: %s", inputFile, finding)
+				}
+			}
 		}
 
 		if allValid {
@@ -132,5 +179,23 @@ func DevLint(inputFiles []string, setOpts []string) []oscalValidation.Validation
 			spinner.Stop()
 		}
 	}
-	return validationResults
+	return validationResults, findings
+}
+
+func writeLintFindings(findings []lint.Finding, outputFile string) error {
+	var findingsBytes []byte
+	var err error
+
+	report := map[string][]lint.Finding{"findings": findings}
+	// Marshal to json if the output file is a json file
+	if strings.HasSuffix(outputFile, ".json") {
+		findingsBytes, err = json.MarshalIndent(report, "", "  ")
+	} else {
+		findingsBytes, err = yaml.Marshal(report)
+	}
+	if err != nil {
+		return err
+	}
+
+	return files.WriteOutput(findingsBytes, outputFile)
 }
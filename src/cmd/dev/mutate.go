@@ -0,0 +1,166 @@
+package dev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/go-oscal/src/pkg/files"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/internal/mutate"
+	pkgCommon "github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var mutateHelp = `
+To mutate the resources collected by a lula validation manifest:
+	lula dev mutate -f /path/to/validation.yaml
+To mutate a custom resources file:
+	lula dev mutate -f /path/to/validation.yaml -r /path/to/resources.json
+To write the mutation report, including each mutation as a test change, to a file:
+	lula dev mutate -f /path/to/validation.yaml -o /path/to/report.yaml
+To fail if the mutation score is below 80%:
+	lula dev mutate -f /path/to/validation.yaml --score-threshold 80
+`
+
+func DevMutateCommand() *cobra.Command {
+
+	var (
+		inputFile        string  // -f --input-file
+		outputFile       string  // -o --output-file
+		resourcesFile    string  // -r --resources-file
+		timeout          int     // -t --timeout
+		confirmExecution bool    // --confirm-execution
+		maxMutations     int     // --max-mutations
+		scoreThreshold   float64 // --score-threshold
+	)
+
+	cmd := &cobra.Command{
+		Use:   "mutate",
+		Short: "Run mutation testing on an individual Lula validation.",
+		Long: "Run mutation testing on an individual Lula validation, to find validations that pass regardless of their input. " +
+			"The resources of the validation are mutated by deleting fields, flipping booleans and emptying lists, and each mutation that leaves the result unchanged is reported as surviving. " +
+			"The mutation score is the percentage of mutations that change the result.",
+		Example: mutateHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spinnerMessage := fmt.Sprintf("Mutating %s", inputFile)
+			spinner := message.NewProgressSpinner("%s", spinnerMessage)
+			defer spinner.Stop()
+
+			if scoreThreshold < 0 || scoreThreshold > 100 {
+				return fmt.Errorf("score threshold must be between 0 and 100")
+			}
+
+			ctx := cmd.Context()
+
+			// Read the validation data from STDIN or provided file
+			validationBytes, err := ReadValidation(cmd, spinner, inputFile, timeout)
+			if err != nil {
+				return fmt.Errorf("error reading validation: %v", err)
+			}
+
+			// Reset the spinner message
+			spinner.Updatef("%s", spinnerMessage)
+
+			config, _ := cmd.Flags().GetStringSlice("set")
+			message.Debug("command line 'set' flags: %s", config)
+
+			output, err := DevTemplate(validationBytes, config)
+			if err != nil {
+				return fmt.Errorf("error templating validation: %v", err)
+			}
+
+			// If a resources file is provided, read the resources file
+			var resourcesBytes []byte
+			if resourcesFile != "" {
+				if !strings.HasSuffix(resourcesFile, ".json") {
+					return fmt.Errorf("resource file must be a json file")
+				}
+				resourcesBytes, err = pkgCommon.ReadFileToBytes(resourcesFile)
+				if err != nil {
+					return fmt.Errorf("error reading file: %v", err)
+				}
+			}
+
+			ctx = context.WithValue(ctx, types.LulaValidationWorkDir, filepath.Dir(inputFile))
+			report, err := DevMutate(ctx, output, resourcesBytes, confirmExecution, maxMutations, spinner)
+			if err != nil {
+				return fmt.Errorf("error running dev mutate: %v", err)
+			}
+
+			spinner.Success()
+			report.PrintReport()
+
+			if outputFile != "" {
+				if err := writeMutationReport(report, outputFile); err != nil {
+					return fmt.Errorf("error writing mutation report: %v", err)
+				}
+			}
+
+			if report.Score < scoreThreshold {
+				return fmt.Errorf("mutation score %.2f%% is below the threshold of %.2f%%", report.Score, scoreThreshold)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputFile, "input-file", "f", STDIN, "the path to a validation manifest file")
+	cmd.Flags().StringVarP(&resourcesFile, "resources-file", "r", "", "the path to an optional resources file, used instead of collecting the resources")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "the path to write the mutation report, as json or yaml")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", DEFAULT_TIMEOUT, "the timeout for stdin (in seconds, -1 for no timeout)")
+	cmd.Flags().BoolVar(&confirmExecution, "confirm-execution", false, "confirm execution scripts run as part of getting resources")
+	cmd.Flags().IntVar(&maxMutations, "max-mutations", 1000, "the maximum number of mutations to evaluate (0 for no limit)")
+	cmd.Flags().Float64Var(&scoreThreshold, "score-threshold", 0, "the minimum mutation score percentage, fails if not met")
+
+	return cmd
+}
+
+// DevMutate reads a validation manifest, collects its resources unless resourcesBytes is not empty,
+// and runs mutation testing of the validation against them
+func DevMutate(ctx context.Context, validationBytes []byte, resourcesBytes []byte, confirmExecution bool, maxMutations int, spinner *message.Spinner) (*mutate.MutationReport, error) {
+	var resources types.DomainResources
+	if len(resourcesBytes) > 0 {
+		if err := json.Unmarshal(resourcesBytes, &resources); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		resources, err = DevGetResources(ctx, validationBytes, confirmExecution, spinner)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var validation pkgCommon.Validation
+	if err := yaml.Unmarshal(validationBytes, &validation); err != nil {
+		return nil, err
+	}
+	lulaValidation, err := validation.ToLulaValidation("")
+	if err != nil {
+		return nil, err
+	}
+
+	return mutate.Run(ctx, &lulaValidation, resources, maxMutations)
+}
+
+func writeMutationReport(report *mutate.MutationReport, outputFile string) error {
+	var reportBytes []byte
+	var err error
+
+	// Marshal to json if the output file is a json file
+	if strings.HasSuffix(outputFile, ".json") {
+		reportBytes, err = json.MarshalIndent(report, "", "  ")
+	} else {
+		reportBytes, err = yaml.Marshal(report)
+	}
+	if err != nil {
+		return err
+	}
+
+	return files.WriteOutput(reportBytes, outputFile)
+}
@@ -0,0 +1,191 @@
+package dev
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/internal/repl"
+	pkgCommon "github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var replHelp = `
+To explore the resources collected by a lula validation manifest, starting from its rego:
+	lula dev repl -f /path/to/validation.yaml
+To explore a resources file, e.g. written by lula dev get-resources:
+	lula dev repl -r /path/to/resources.json
+To keep the history of the REPL between sessions:
+	lula dev repl -f /path/to/validation.yaml --history-file ~/.lula_repl_history
+`
+
+func DevReplCommand() *cobra.Command {
+
+	var (
+		inputFile        string // -f --input-file
+		resourcesFile    string // -r --resources-file
+		historyFile      string // --history-file
+		confirmExecution bool   // --confirm-execution
+	)
+
+	cmd := &cobra.Command{
+		Use:   "repl",
+		Short: "Interactively query the resources of a Lula validation.",
+		Long: "Start an interactive prompt to evaluate Rego, jq, JSONPath, CEL, JMESPath or CUE queries against the resources of a Lula validation, or a resources file, available as input. " +
+			"Rego rules entered at the prompt are added to a rule set, starting with the rego of the validation, which can be written to a validation file with the :dump command. " +
+			"Type :help at the prompt for the commands.",
+		Example: replHelp,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputFile == "" && resourcesFile == "" {
+				return fmt.Errorf("either --input-file or --resources-file is required")
+			}
+
+			spinnerMessage := "Loading the REPL"
+			spinner := message.NewProgressSpinner("%s", spinnerMessage)
+			defer spinner.Stop()
+
+			ctx := cmd.Context()
+
+			var validationBytes []byte
+			var err error
+			if inputFile != "" {
+				validationBytes, err = ReadValidation(cmd, spinner, inputFile, NO_TIMEOUT)
+				if err != nil {
+					return fmt.Errorf("error reading validation: %v", err)
+				}
+
+				config, _ := cmd.Flags().GetStringSlice("set")
+				message.Debug("command line 'set' flags: %s", config)
+
+				validationBytes, err = DevTemplate(validationBytes, config)
+				if err != nil {
+					return fmt.Errorf("error templating validation: %v", err)
+				}
+				ctx = context.WithValue(ctx, types.LulaValidationWorkDir, filepath.Dir(inputFile))
+			}
+
+			var resourcesBytes []byte
+			if resourcesFile != "" {
+				if !strings.HasSuffix(resourcesFile, ".json") {
+					return fmt.Errorf("resource file must be a json file")
+				}
+				resourcesBytes, err = pkgCommon.ReadFileToBytes(resourcesFile)
+				if err != nil {
+					return fmt.Errorf("error reading file: %v", err)
+				}
+			}
+
+			session, err := DevReplSession(ctx, validationBytes, resourcesBytes, confirmExecution, spinner)
+			if err != nil {
+				return fmt.Errorf("error loading the REPL: %v", err)
+			}
+			spinner.Success()
+
+			var history []string
+			var historyWriter io.Writer
+			if historyFile != "" {
+				history, err = readHistory(historyFile)
+				if err != nil {
+					return fmt.Errorf("error reading history: %v", err)
+				}
+				file, err := os.OpenFile(filepath.Clean(historyFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+				if err != nil {
+					return fmt.Errorf("error opening history: %v", err)
+				}
+				defer file.Close()
+				historyWriter = file
+			}
+
+			model := repl.NewModel(ctx, session, history, historyWriter)
+			p := tea.NewProgram(model, tea.WithContext(ctx), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.OutOrStdout()))
+			if _, err := p.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+				return fmt.Errorf("error running the REPL: %v", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputFile, "input-file", "f", "", "the path to a validation manifest file")
+	cmd.Flags().StringVarP(&resourcesFile, "resources-file", "r", "", "the path to a resources file, used instead of collecting the resources of the validation")
+	cmd.Flags().StringVar(&historyFile, "history-file", "", "the path to a file to read the history from and append new entries to")
+	cmd.Flags().BoolVar(&confirmExecution, "confirm-execution", false, "confirm execution scripts run as part of getting resources")
+
+	return cmd
+}
+
+// DevReplSession creates a REPL session over the resources of resourcesBytes if not empty, otherwise
+// the resources collected by the validation. The validation may be empty if resourcesBytes is not.
+func DevReplSession(ctx context.Context, validationBytes []byte, resourcesBytes []byte, confirmExecution bool, spinner *message.Spinner) (*repl.Session, error) {
+	var resources types.DomainResources
+	if len(resourcesBytes) > 0 {
+		if err := json.Unmarshal(resourcesBytes, &resources); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		resources, err = DevGetResources(ctx, validationBytes, confirmExecution, spinner)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(validationBytes) == 0 {
+		return repl.NewSession(resources, nil, nil)
+	}
+
+	var validation pkgCommon.Validation
+	if err := yaml.Unmarshal(validationBytes, &validation); err != nil {
+		return nil, err
+	}
+
+	// Load the modules of the OPA provider, relative to the validation
+	var modules map[string]string
+	if validation.Provider != nil && validation.Provider.OpaSpec != nil && len(validation.Provider.OpaSpec.Modules) > 0 {
+		workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+		if !ok {
+			workDir = "."
+		}
+		modules = make(map[string]string, len(validation.Provider.OpaSpec.Modules))
+		for name, src := range validation.Provider.OpaSpec.Modules {
+			content, err := network.Fetch(src, network.WithBaseDir(workDir))
+			if err != nil {
+				return nil, fmt.Errorf("error loading module %s: %v", name, err)
+			}
+			modules[name] = string(content)
+		}
+	}
+
+	return repl.NewSession(resources, &validation, modules)
+}
+
+// readHistory reads the lines of the history file, if it exists
+func readHistory(path string) ([]string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var history []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			history = append(history, line)
+		}
+	}
+	return history, scanner.Err()
+}
@@ -12,7 +12,9 @@ import (
 	"sigs.k8s.io/yaml"
 
 	pkgCommon "github.com/defenseunicorns/lula/src/pkg/common"
+	validationPkg "github.com/defenseunicorns/lula/src/pkg/common/validation"
 	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
 	"github.com/defenseunicorns/lula/src/types"
 )
 
@@ -29,19 +31,38 @@ To hang indefinitely for stdin:
 	lula dev validate -t -1
 To hang for timeout of 5 seconds:
 	lula dev validate -t 5
+To run tests and report the coverage of the Rego policy, failing if it is below 80%:
+	lula dev validate -f /path/to/validation.yaml --run-tests --coverage-threshold 80
+To run tests and rewrite the golden files of their observations:
+	lula dev validate -f /path/to/validation.yaml --run-tests --update-golden
+To run the tests, without collecting the domain resources if every test has a fixture or generators, e.g. in CI:
+	lula dev validate -f /path/to/validation.yaml --run-tests
+To run tests and write a JUnit XML test report to the validation directory:
+	lula dev validate -f /path/to/validation.yaml --run-tests --test-report-format junit
+To re-run the validation and its tests each time the validation, its modules, fixtures or resources file change:
+	lula dev validate -f /path/to/validation.yaml --run-tests --watch
+To log the trace of failing OPA expressions and attach it and the print() output to the result:
+	lula dev validate -f /path/to/validation.yaml --explain=fails --attach-provider-output
 `
 
 func DevValidateCommand() *cobra.Command {
 
 	var (
-		inputFile          string // -f --input-file
-		outputFile         string // -o --output-file
-		timeout            int    // -t --timeout
-		confirmExecution   bool   // --confirm-execution
-		expectedResult     bool   // -e --expected-result
-		resourcesFile      string // -r --resources-file
-		runTests           bool   // --run-tests
-		printTestResources bool   // --print-test-resources
+		inputFile          string  // -f --input-file
+		outputFile         string  // -o --output-file
+		timeout            int     // -t --timeout
+		confirmExecution   bool    // --confirm-execution
+		expectedResult     bool    // -e --expected-result
+		resourcesFile      string  // -r --resources-file
+		runTests           bool    // --run-tests
+		printTestResources bool    // --print-test-resources
+		explain            string  // --explain
+		attachOutput       bool    // --attach-provider-output
+		coverage           bool    // --coverage
+		coverageThreshold  float64 // --coverage-threshold
+		updateGolden       bool    // --update-golden
+		testReportFormat   string  // --test-report-format
+		watch              bool    // --watch
 	)
 
 	cmd := &cobra.Command{
@@ -50,97 +71,211 @@ func DevValidateCommand() *cobra.Command {
 		Long:    "Run an individual Lula validation for quick testing and debugging of a Lula Validation. This command is intended for development purposes only.",
 		Example: validateHelp,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			spinnerMessage := fmt.Sprintf("Validating %s", inputFile)
-			spinner := message.NewProgressSpinner("%s", spinnerMessage)
-			defer spinner.Stop()
-
-			ctx := cmd.Context()
-			var validationBytes []byte
-			var resourcesBytes []byte
-			var err error
-
-			// Read the validation data from STDIN or provided file
-			validationBytes, err = ReadValidation(cmd, spinner, inputFile, timeout)
-			if err != nil {
-				return fmt.Errorf("error reading validation: %v", err)
+			if err := opa.ValidateExplainMode(explain); err != nil {
+				return err
 			}
-
-			// Reset the spinner message
-			spinner.Updatef("%s", spinnerMessage)
-
-			// If a resources file is provided, read the resources file
-			if resourcesFile != "" {
-				if !strings.HasSuffix(resourcesFile, ".json") {
-					return fmt.Errorf("resource file must be a json file")
-				} else {
-					// Read the resources data
-					resourcesBytes, err = pkgCommon.ReadFileToBytes(resourcesFile)
-					if err != nil {
-						return fmt.Errorf("error reading file: %v", err)
-					}
+			if coverageThreshold < 0 || coverageThreshold > 100 {
+				return fmt.Errorf("coverage threshold must be between 0 and 100")
+			}
+			if (coverage || coverageThreshold > 0) && !runTests {
+				return fmt.Errorf("coverage requires --run-tests")
+			}
+			if updateGolden && !runTests {
+				return fmt.Errorf("--update-golden requires --run-tests")
+			}
+			if testReportFormat != "" {
+				if err := types.ValidateTestReportFormat(testReportFormat); err != nil {
+					return err
 				}
+				if !runTests {
+					return fmt.Errorf("--test-report-format requires --run-tests")
+				}
+			}
+			if watch && inputFile == STDIN {
+				return fmt.Errorf("--watch requires --input-file")
 			}
 
-			config, _ := cmd.Flags().GetStringSlice("set")
-			message.Debug("command line 'set' flags: %s", config)
+			ctx := cmd.Context()
+			ctx = context.WithValue(ctx, types.LulaValidationWorkDir, filepath.Dir(inputFile))
+			ctx = context.WithValue(ctx, types.LulaProviderExplain, explain)
+			ctx = context.WithValue(ctx, types.LulaAttachProviderOutput, attachOutput)
+			ctx = context.WithValue(ctx, types.LulaUpdateGolden, updateGolden)
 
-			output, err := DevTemplate(validationBytes, config)
-			if err != nil {
-				return fmt.Errorf("error templating validation: %v", err)
-			}
+			// run validates the validation and runs its tests once, reusing the domain resources of
+			// the cache if it is not nil
+			run := func(ctx context.Context, cache *resourcesCache) (*validateRun, error) {
+				spinnerMessage := fmt.Sprintf("Validating %s", inputFile)
+				spinner := message.NewProgressSpinner("%s", spinnerMessage)
+				defer spinner.Stop()
 
-			// add to debug logs accepting that this will print sensitive information?
-			message.Debug(string(output))
+				current := &validateRun{
+					Files: []string{inputFile},
+				}
+				var validationBytes []byte
+				var resourcesBytes []byte
+				var err error
 
-			ctx = context.WithValue(ctx, types.LulaValidationWorkDir, filepath.Dir(inputFile))
-			validation, err := DevValidate(ctx, output, resourcesBytes, confirmExecution, spinner)
-			if err != nil {
-				return fmt.Errorf("error running dev validate: %v", err)
-			}
+				// Read the validation data from STDIN or provided file
+				validationBytes, err = ReadValidation(cmd, spinner, inputFile, timeout)
+				if err != nil {
+					return current, fmt.Errorf("error reading validation: %v", err)
+				}
 
-			// Write the validation result to a file if an output file is provided
-			// Otherwise, print the result to the debug console
-			err = writeValidation(validation, outputFile)
-			if err != nil {
-				return fmt.Errorf("error writing result: %v", err)
-			}
+				// Reset the spinner message
+				spinner.Updatef("%s", spinnerMessage)
 
-			// Print observations if there are any
-			if len(validation.Result.Observations) > 0 {
-				message.Infof("Observations:")
-				for key, observation := range validation.Result.Observations {
-					message.Infof("--> %s: %s", key, observation)
+				// If a resources file is provided, read the resources file
+				if resourcesFile != "" {
+					current.Files = append(current.Files, resourcesFile)
+					if !strings.HasSuffix(resourcesFile, ".json") {
+						return current, fmt.Errorf("resource file must be a json file")
+					} else {
+						// Read the resources data
+						resourcesBytes, err = pkgCommon.ReadFileToBytes(resourcesFile)
+						if err != nil {
+							return current, fmt.Errorf("error reading file: %v", err)
+						}
+					}
 				}
-			}
 
-			result := validation.Result.Passing > 0 && validation.Result.Failing <= 0
-			// If the expected result is not equal to the actual result, return an error
-			if expectedResult != result {
-				return fmt.Errorf("expected result to be %t got %t", expectedResult, result)
-			}
-			// Print the number of passing and failing results
-			message.Infof("Validation completed with %d passing and %d failing results", validation.Result.Passing, validation.Result.Failing)
+				config, _ := cmd.Flags().GetStringSlice("set")
+				message.Debug("command line 'set' flags: %s", config)
 
-			// Run tests if requested
-			// Note - this runs tests strictly, e.g., returns an error if any test fails
-			if runTests {
-				testReport, err := validation.RunTests(ctx, printTestResources)
+				output, err := DevTemplate(validationBytes, config)
 				if err != nil {
-					return fmt.Errorf("error running tests")
+					return current, fmt.Errorf("error templating validation: %v", err)
 				}
-				if testReport == nil {
-					message.Debug("No tests defined for validation")
-					return nil
+
+				// add to debug logs accepting that this will print sensitive information?
+				message.Debug(string(output))
+
+				// Watch the files referenced by the validation, e.g. modules and fixtures
+				if cache != nil {
+					current.Files = append(current.Files, referencedFiles(ctx, output)...)
 				}
-				// Print the test report using messages
-				testReport.PrintReport()
 
-				// Return error if test failed
-				if testReport.TestFailed() {
-					return fmt.Errorf("some tests failed")
+				var validation types.LulaValidation
+				// Tests that all have a fixture or generators don't need the domain resources
+				if runTests && len(resourcesBytes) == 0 && testsHaveResources(ctx, output) {
+					message.Infof("Every test has a fixture or generators, running only the tests")
+					validation, err = DevTestsOnly(output)
+					if err != nil {
+						return current, fmt.Errorf("error reading validation: %v", err)
+					}
+				} else {
+					// Use the cached domain resources if the domain is unchanged
+					if cache != nil && len(resourcesBytes) == 0 {
+						resourcesBytes = cache.get(output)
+					}
+
+					validation, err = DevValidate(ctx, output, resourcesBytes, confirmExecution, spinner)
+					if cache != nil && len(resourcesBytes) == 0 {
+						cache.set(output, validation.DomainResources)
+					}
+					if err != nil {
+						return current, fmt.Errorf("error running dev validate: %v", err)
+					}
+					current.Result = validation.Result
+
+					// Write the validation result to a file if an output file is provided
+					// Otherwise, print the result to the debug console
+					err = writeValidation(validation, outputFile)
+					if err != nil {
+						return current, fmt.Errorf("error writing result: %v", err)
+					}
+
+					// Print observations if there are any
+					if len(validation.Result.Observations) > 0 {
+						message.Infof("Observations:")
+						for key, observation := range validation.Result.Observations {
+							message.Infof("--> %s: %s", key, observation)
+						}
+					}
+
+					// Print violations if there are any
+					if len(validation.Result.Violations) > 0 {
+						message.Infof("Violations:")
+						for _, violation := range validation.Result.Violations {
+							message.Infof("--> %s", violation)
+						}
+					}
+
+					result := validation.Result.Passing > 0 && validation.Result.Failing <= 0
+					// If the expected result is not equal to the actual result, return an error
+					if expectedResult != result {
+						return current, fmt.Errorf("expected result to be %t got %t", expectedResult, result)
+					}
+					// Print the number of passing and failing results
+					message.Infof("Validation completed with %d passing and %d failing results", validation.Result.Passing, validation.Result.Failing)
+				}
+
+				// Run tests if requested
+				// Note - this runs tests strictly, e.g., returns an error if any test fails
+				if runTests {
+					// Collect the Rego coverage across all tests if requested
+					testCtx := ctx
+					var regoCoverage *opa.Coverage
+					if coverage || coverageThreshold > 0 {
+						regoCoverage = opa.NewCoverage()
+						testCtx = context.WithValue(ctx, types.LulaProviderCoverage, regoCoverage)
+					}
+
+					testReport, err := validation.RunTests(testCtx, printTestResources)
+					if err != nil {
+						return current, fmt.Errorf("error running tests: %v", err)
+					}
+					if testReport == nil {
+						if coverageThreshold > 0 {
+							return current, fmt.Errorf("no Rego coverage collected for the threshold of %.2f%%: the validation has no tests", coverageThreshold)
+						}
+						message.Debug("No tests defined for validation")
+						return current, nil
+					}
+					current.TestResults = testReport.TestResults
+
+					// Print the test report using messages
+					testReport.PrintReport()
+
+					// Write the test report to the validation directory if a format is requested
+					if testReportFormat != "" {
+						testReportsMap := map[string]types.LulaValidationTestReport{
+							validation.Name: *testReport,
+						}
+						path, err := validationPkg.WriteTestReports(testReportsMap, types.TestReportFormat(testReportFormat), validation.Name, filepath.Dir(inputFile))
+						if err != nil {
+							return current, fmt.Errorf("error writing test report: %v", err)
+						}
+						message.Infof("Test report written to %s", path)
+					}
+
+					var coverageReport *opa.CoverageReport
+					if regoCoverage != nil {
+						coverageReport = regoCoverage.Report()
+						coverageReport.PrintReport()
+					}
+
+					// Return error if test failed
+					if testReport.TestFailed() {
+						return current, fmt.Errorf("some tests failed")
+					}
+
+					// Return error if the coverage is below the threshold, or was not collected as
+					// only the OPA provider reports coverage
+					if coverageThreshold > 0 && coverageReport == nil {
+						return current, fmt.Errorf("no Rego coverage collected for the threshold of %.2f%%: coverage requires the opa provider", coverageThreshold)
+					}
+					if coverageReport.BelowThreshold(coverageThreshold) {
+						return current, fmt.Errorf("rego coverage %.2f%% is below the threshold of %.2f%%", coverageReport.Coverage, coverageThreshold)
+					}
 				}
+				return current, nil
 			}
-			return nil
+
+			if watch {
+				return watchValidation(ctx, run)
+			}
+			_, err := run(ctx, nil)
+			return err
 		},
 	}
 
@@ -150,8 +285,15 @@ func DevValidateCommand() *cobra.Command {
 	cmd.Flags().IntVarP(&timeout, "timeout", "t", DEFAULT_TIMEOUT, "the timeout for stdin (in seconds, -1 for no timeout)")
 	cmd.Flags().BoolVarP(&expectedResult, "expected-result", "e", true, "the expected result of the validation (-e=false for failing result)")
 	cmd.Flags().BoolVar(&confirmExecution, "confirm-execution", false, "confirm execution scripts run as part of the validation")
-	cmd.Flags().BoolVar(&runTests, "run-tests", false, "run tests specified in the validation")
+	cmd.Flags().BoolVar(&runTests, "run-tests", false, "run tests specified in the validation; if every test has a fixture or generators, only the tests are run")
 	cmd.Flags().BoolVar(&printTestResources, "print-test-resources", false, "whether to print resources used for tests; prints <test-name>.json to the validation directory")
+	cmd.Flags().BoolVar(&coverage, "coverage", false, "report the coverage of the Rego policy across the tests; requires --run-tests")
+	cmd.Flags().Float64Var(&coverageThreshold, "coverage-threshold", 0, "the minimum Rego coverage percentage of the tests, fails if not met; requires --run-tests")
+	cmd.Flags().BoolVar(&updateGolden, "update-golden", false, "rewrite the golden files of the tests with their observations; requires --run-tests")
+	cmd.Flags().StringVar(&testReportFormat, "test-report-format", "", "write the test report to the validation directory in the format: junit, tap, yaml or json; requires --run-tests")
+	cmd.Flags().BoolVar(&watch, "watch", false, "re-run the validation and its tests when the validation file or the files it references change, printing the changes in the results")
+	cmd.Flags().StringVar(&explain, "explain", opa.ExplainOff, "trace the OPA evaluation to the debug logs: off, notes, fails or full")
+	cmd.Flags().BoolVar(&attachOutput, "attach-provider-output", false, "attach the provider debugging output, e.g. OPA print() output and traces, to the validation observations")
 
 	return cmd
 }
@@ -183,6 +325,46 @@ func DevValidate(ctx context.Context, validationBytes []byte, resourcesBytes []b
 	return lulaValidation, nil
 }
 
+// DevTestsOnly reads a validation manifest and converts it to a LulaValidation struct without
+// collecting the domain resources, so its tests can run against their fixtures
+func DevTestsOnly(validationBytes []byte) (lulaValidation types.LulaValidation, err error) {
+	var validation pkgCommon.Validation
+	if err = yaml.Unmarshal(validationBytes, &validation); err != nil {
+		return lulaValidation, err
+	}
+
+	return validation.ToLulaValidation("")
+}
+
+// testsHaveResources returns true if the validation has tests, including those of its test
+// suites, and every test has a fixture or generators to run against
+func testsHaveResources(ctx context.Context, validationBytes []byte) bool {
+	var validation pkgCommon.Validation
+	if err := yaml.Unmarshal(validationBytes, &validation); err != nil {
+		return false
+	}
+
+	var tests []types.LulaValidationTest
+	if validation.Tests != nil {
+		tests = append(tests, *validation.Tests...)
+	}
+	for _, suite := range validation.TestSuites {
+		// Errors are reported when the tests run
+		suiteTests, err := types.LoadTestSuite(ctx, suite)
+		if err != nil {
+			return false
+		}
+		tests = append(tests, suiteTests...)
+	}
+
+	for _, test := range tests {
+		if test.Fixture == "" && len(test.Generators) == 0 {
+			return false
+		}
+	}
+	return len(tests) > 0
+}
+
 func writeValidation(result types.LulaValidation, outputFile string) error {
 	var resultBytes []byte
 	var err error
@@ -3,9 +3,12 @@ package dev
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/types"
 )
 
 func FuzzRunSingleValidation(f *testing.F) {
@@ -34,3 +37,58 @@ func FuzzDevValidate(f *testing.F) {
 		DevValidate(context.Background(), a, b, false, nil)
 	})
 }
+
+func TestTestsHaveResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	suite := "tests:\n  - name: suite-test\n    expected-result: satisfied\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "suite.yaml"), []byte(suite), 0600))
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, tmpDir)
+
+	tests := []struct {
+		name       string
+		validation string
+		want       bool
+	}{
+		{
+			name:       "no tests",
+			validation: "provider:\n  type: opa\n",
+			want:       false,
+		},
+		{
+			name: "fixtures and generators",
+			validation: `tests:
+  - name: fixture
+    fixture: pods.yaml
+    expected-result: satisfied
+  - name: generators
+    generators:
+      - name: pods
+        count: 1
+        template: {}
+    expected-result: satisfied
+`,
+			want: true,
+		},
+		{
+			name:       "test without a fixture",
+			validation: "tests:\n  - name: fixture\n    fixture: pods.yaml\n    expected-result: satisfied\n  - name: domain\n    expected-result: satisfied\n",
+			want:       false,
+		},
+		{
+			name:       "test suite without a fixture",
+			validation: "tests:\n  - name: fixture\n    fixture: pods.yaml\n    expected-result: satisfied\ntest-suites:\n  - suite.yaml\n",
+			want:       false,
+		},
+		{
+			name:       "missing test suite",
+			validation: "tests:\n  - name: fixture\n    fixture: pods.yaml\n    expected-result: satisfied\ntest-suites:\n  - missing.yaml\n",
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, testsHaveResources(ctx, []byte(tt.validation)))
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package dev
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"sigs.k8s.io/yaml"
+
+	pkgCommon "github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/domains/sql"
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// watchDebounce is how long to wait for further changes before re-running, as editors often
+// write a file in several events
+const watchDebounce = 200 * time.Millisecond
+
+// validateRun is the outcome of one run of dev validate, and the files it depends on
+type validateRun struct {
+	Files       []string
+	Result      *types.Result
+	TestResults []*types.LulaValidationTestResult
+}
+
+// resourcesCache keeps the domain resources between runs of dev validate, keyed by the domain
+// specification they were collected with. Only the resources of remote domains are cached, as
+// the resources of local domains, e.g. files, plans and reports, change with their inputs.
+type resourcesCache struct {
+	key       string
+	resources []byte
+}
+
+// remoteDomains are the domain types whose resources are collected from a remote system
+var remoteDomains = map[string]bool{
+	"kubernetes": true,
+	"api":        true,
+	"sql":        true,
+	"metrics":    true,
+	"tls":        true,
+}
+
+// cacheable reports whether the resources of the domain can be cached. SQLite databases are
+// local files, so they are not cached.
+func cacheable(domain *pkgCommon.Domain) bool {
+	if domain == nil || !remoteDomains[domain.Type] {
+		return false
+	}
+	return domain.Type != "sql" || domain.SQLSpec == nil || domain.SQLSpec.Driver != sql.DriverSQLite
+}
+
+// domainKey returns the domain specification of the validation, or an empty string if the
+// validation cannot be read or its resources cannot be cached
+func domainKey(validationBytes []byte) string {
+	var validation pkgCommon.Validation
+	if err := yaml.Unmarshal(validationBytes, &validation); err != nil {
+		return ""
+	}
+	if validation.Domain != nil && !cacheable(validation.Domain) {
+		return ""
+	}
+	for _, domain := range validation.Domains {
+		if !cacheable(&domain.Domain) {
+			return ""
+		}
+	}
+	if validation.Domain == nil && len(validation.Domains) == 0 {
+		return ""
+	}
+	key, err := json.Marshal([]interface{}{validation.Domain, validation.Domains})
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
+
+// get returns the cached resources if they were collected with the domain of the validation
+func (c *resourcesCache) get(validationBytes []byte) []byte {
+	if key := domainKey(validationBytes); key != "" && key == c.key {
+		message.Debug("Using the cached domain resources")
+		return c.resources
+	}
+	return nil
+}
+
+// set caches the resources collected with the domain of the validation
+func (c *resourcesCache) set(validationBytes []byte, resources *types.DomainResources) {
+	if resources == nil || len(*resources) == 0 {
+		return
+	}
+	key := domainKey(validationBytes)
+	if key == "" {
+		return
+	}
+	resourcesBytes, err := json.Marshal(resources)
+	if err != nil {
+		return
+	}
+	c.key = key
+	c.resources = resourcesBytes
+}
+
+// referencedFiles returns the local files referenced by the validation: its OPA modules, and the
+// fixtures and test suites of its tests
+func referencedFiles(ctx context.Context, validationBytes []byte) []string {
+	var validation pkgCommon.Validation
+	if err := yaml.Unmarshal(validationBytes, &validation); err != nil {
+		return nil
+	}
+
+	var paths []string
+	if validation.Provider != nil && validation.Provider.OpaSpec != nil {
+		for _, module := range validation.Provider.OpaSpec.Modules {
+			paths = append(paths, module)
+		}
+	}
+	var tests []types.LulaValidationTest
+	if validation.Tests != nil {
+		tests = append(tests, *validation.Tests...)
+	}
+	for _, suite := range validation.TestSuites {
+		paths = append(paths, suite)
+		// Errors are reported when the tests run
+		suiteTests, err := types.LoadTestSuite(ctx, suite)
+		if err == nil {
+			tests = append(tests, suiteTests...)
+		}
+	}
+	for _, test := range tests {
+		if test.Fixture != "" {
+			paths = append(paths, test.Fixture)
+		}
+	}
+
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok {
+		workDir = "."
+	}
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		// Remote files are not watched
+		if strings.Contains(path, "://") {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workDir, path)
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// watchValidation runs the validation, then runs it again each time one of the files of the
+// previous run changes, printing the changes to the result, observations and tests, until the
+// context is done. Errors of a run are printed rather than returned.
+func watchValidation(ctx context.Context, run func(context.Context, *resourcesCache) (*validateRun, error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating file watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	cache := &resourcesCache{}
+	var previous *validateRun
+	watched := make(map[string]bool)
+	watchedDirs := make(map[string]bool)
+
+	runOnce := func() {
+		current, err := run(ctx, cache)
+		if err != nil {
+			message.WarnErrf(err, "%v", err)
+		}
+		if current == nil {
+			return
+		}
+		if previous != nil {
+			printValidateRunDiff(diffValidateRuns(previous, current))
+		}
+		// Only compare against runs that produced a result
+		if current.Result != nil || current.TestResults != nil {
+			previous = current
+		}
+
+		// Watch the directories of the files, as editors often replace a file rather than write it
+		clear(watched)
+		for _, file := range current.Files {
+			watched[filepath.Clean(file)] = true
+			dir := filepath.Dir(filepath.Clean(file))
+			if !watchedDirs[dir] {
+				if err := watcher.Add(dir); err != nil {
+					message.WarnErrf(err, "error watching %s: %v", dir, err)
+					continue
+				}
+				watchedDirs[dir] = true
+			}
+		}
+		message.Infof("Watching %d files for changes...", len(watched))
+	}
+
+	runOnce()
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if watched[filepath.Clean(event.Name)] && !event.Has(fsnotify.Chmod) {
+				message.Debugf("File changed: %s", event.Name)
+				debounce = time.After(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			message.WarnErr(err, "error watching files")
+		case <-debounce:
+			debounce = nil
+			runOnce()
+		}
+	}
+}
+
+// diffValidateRuns describes the changes to the result, observations and test results between
+// two runs, one change per line
+func diffValidateRuns(previous *validateRun, current *validateRun) []string {
+	var changes []string
+
+	if previous.Result != nil && current.Result != nil {
+		if before, after := resultState(previous.Result), resultState(current.Result); before != after {
+			changes = append(changes, fmt.Sprintf("result: %s -> %s", before, after))
+		}
+		if previous.Result.Passing != current.Result.Passing {
+			changes = append(changes, fmt.Sprintf("passing: %d -> %d", previous.Result.Passing, current.Result.Passing))
+		}
+		if previous.Result.Failing != current.Result.Failing {
+			changes = append(changes, fmt.Sprintf("failing: %d -> %d", previous.Result.Failing, current.Result.Failing))
+		}
+
+		keys := make([]string, 0, len(previous.Result.Observations)+len(current.Result.Observations))
+		for key := range previous.Result.Observations {
+			keys = append(keys, key)
+		}
+		for key := range current.Result.Observations {
+			if _, ok := previous.Result.Observations[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			before, hadBefore := previous.Result.Observations[key]
+			after, hasAfter := current.Result.Observations[key]
+			switch {
+			case !hadBefore:
+				changes = append(changes, fmt.Sprintf("+ observation %s: %s", key, after))
+			case !hasAfter:
+				changes = append(changes, fmt.Sprintf("- observation %s: %s", key, before))
+			case before != after:
+				changes = append(changes, fmt.Sprintf("~ observation %s: %s -> %s", key, before, after))
+			}
+		}
+	}
+
+	previousTests := make(map[string]*types.LulaValidationTestResult, len(previous.TestResults))
+	for _, result := range previous.TestResults {
+		previousTests[result.TestName] = result
+	}
+	currentTests := make(map[string]bool, len(current.TestResults))
+	for _, result := range current.TestResults {
+		currentTests[result.TestName] = true
+		before, ok := previousTests[result.TestName]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("+ test %s: %s", result.TestName, testState(result)))
+		case before.Pass != result.Pass || before.Result != result.Result:
+			changes = append(changes, fmt.Sprintf("~ test %s: %s -> %s", result.TestName, testState(before), testState(result)))
+		}
+	}
+	for _, result := range previous.TestResults {
+		if !currentTests[result.TestName] {
+			changes = append(changes, fmt.Sprintf("- test %s", result.TestName))
+		}
+	}
+
+	return changes
+}
+
+// printValidateRunDiff prints the changes since the previous run
+func printValidateRunDiff(changes []string) {
+	if len(changes) == 0 {
+		message.Infof("No changes since the last run")
+		return
+	}
+	message.Infof("Changes since the last run:")
+	for _, change := range changes {
+		message.Infof("--> %s", change)
+	}
+}
+
+func resultState(result *types.Result) string {
+	if result.Passing > 0 && result.Failing <= 0 {
+		return "satisfied"
+	}
+	return "not-satisfied"
+}
+
+func testState(result *types.LulaValidationTestResult) string {
+	status := "fail"
+	if result.Pass {
+		status = "pass"
+	}
+	return fmt.Sprintf("%s (%s)", status, result.Result)
+}
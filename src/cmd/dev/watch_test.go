@@ -0,0 +1,173 @@
+package dev
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestDiffValidateRuns(t *testing.T) {
+	previous := &validateRun{
+		Result: &types.Result{
+			Passing: 1,
+			Observations: map[string]string{
+				"removed":   "a",
+				"changed":   "b",
+				"unchanged": "c",
+			},
+		},
+		TestResults: []*types.LulaValidationTestResult{
+			{TestName: "still-passing", Pass: true, Result: "satisfied"},
+			{TestName: "now-failing", Pass: true, Result: "not-satisfied"},
+			{TestName: "removed-test", Pass: true, Result: "satisfied"},
+		},
+	}
+	current := &validateRun{
+		Result: &types.Result{
+			Failing: 1,
+			Observations: map[string]string{
+				"added":     "d",
+				"changed":   "e",
+				"unchanged": "c",
+			},
+		},
+		TestResults: []*types.LulaValidationTestResult{
+			{TestName: "still-passing", Pass: true, Result: "satisfied"},
+			{TestName: "now-failing", Pass: false, Result: "satisfied"},
+			{TestName: "added-test", Pass: true, Result: "satisfied"},
+		},
+	}
+
+	changes := diffValidateRuns(previous, current)
+	require.Equal(t, []string{
+		"result: satisfied -> not-satisfied",
+		"passing: 1 -> 0",
+		"failing: 0 -> 1",
+		"+ observation added: d",
+		"~ observation changed: b -> e",
+		"- observation removed: a",
+		"~ test now-failing: pass (not-satisfied) -> fail (satisfied)",
+		"+ test added-test: pass (satisfied)",
+		"- test removed-test",
+	}, changes)
+
+	require.Empty(t, diffValidateRuns(current, current))
+}
+
+func TestResourcesCache(t *testing.T) {
+	validation := []byte("domain:\n  type: api\n  api-spec:\n    requests:\n    - name: pod\n      url: https://example.com/pod\n")
+	changedDomain := []byte("domain:\n  type: api\n  api-spec:\n    requests:\n    - name: pod\n      url: https://example.com/other\n")
+	changedProvider := append([]byte("provider:\n  type: opa\n"), validation...)
+
+	cache := &resourcesCache{}
+	require.Nil(t, cache.get(validation))
+
+	resources := types.DomainResources{"pod": map[string]interface{}{"kind": "Pod"}}
+	cache.set(validation, &resources)
+	require.JSONEq(t, `{"pod": {"kind": "Pod"}}`, string(cache.get(validation)))
+	require.NotNil(t, cache.get(changedProvider))
+	require.Nil(t, cache.get(changedDomain))
+
+	// Empty resources are not cached
+	cache.set(changedDomain, &types.DomainResources{})
+	require.Nil(t, cache.get(changedDomain))
+
+	// Resources of local domains change with their inputs, so they are not cached
+	for _, local := range [][]byte{
+		[]byte("domain:\n  type: file\n  file-spec:\n    filepaths:\n    - name: pod\n      path: pod.yaml\n"),
+		[]byte("domain:\n  type: sql\n  sql-spec:\n    driver: sqlite\n    dsn: app.db\n"),
+		[]byte("domains:\n- name: api\n  type: api\n- name: plan\n  type: terraform\n"),
+	} {
+		cache := &resourcesCache{}
+		cache.set(local, &resources)
+		require.Nil(t, cache.get(local))
+	}
+}
+
+func TestReferencedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	suite := "tests:\n  - name: suite-test\n    fixture: suite-fixture.yaml\n    expected-result: satisfied\n"
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "suites"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "suites", "suite.yaml"), []byte(suite), 0600))
+
+	validation := []byte(`
+provider:
+  type: opa
+  opa-spec:
+    rego: package validate
+    modules:
+      lib: lib.rego
+      remote: https://example.com/remote.rego
+tests:
+  - name: test
+    fixture: /fixtures/pods.yaml
+    expected-result: satisfied
+test-suites:
+  - suites/suite.yaml
+`)
+
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, tmpDir)
+	files := referencedFiles(ctx, validation)
+	require.Equal(t, []string{
+		"/fixtures/pods.yaml",
+		filepath.Join(tmpDir, "lib.rego"),
+		filepath.Join(tmpDir, "suites", "suite-fixture.yaml"),
+		filepath.Join(tmpDir, "suites", "suite.yaml"),
+	}, files)
+}
+
+func TestWatchValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+	watchedFile := filepath.Join(tmpDir, "validation.yaml")
+	require.NoError(t, os.WriteFile(watchedFile, []byte("first"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := make(chan int, 10)
+	count := 0
+	cached := true
+	run := func(ctx context.Context, cache *resourcesCache) (*validateRun, error) {
+		cached = cached && cache != nil
+		count++
+		runs <- count
+		return &validateRun{
+			Files:  []string{watchedFile},
+			Result: &types.Result{Passing: count},
+		}, nil
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- watchValidation(ctx, run)
+	}()
+
+	waitForRun := func(expected int) {
+		t.Helper()
+		select {
+		case got := <-runs:
+			require.Equal(t, expected, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for run %d", expected)
+		}
+	}
+
+	waitForRun(1)
+
+	// Files that are not watched don't trigger a run
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "other.yaml"), []byte("other"), 0600))
+	time.Sleep(2 * watchDebounce)
+	require.Empty(t, runs)
+
+	require.NoError(t, os.WriteFile(watchedFile, []byte("second"), 0600))
+	waitForRun(2)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.Empty(t, runs)
+	require.True(t, cached)
+}
@@ -29,6 +29,12 @@ To run validations non-interactively (no execution)
 	lula dev validate -f ./oscal-component.yaml --non-interactive
 To run validations and their tests, generating a test-results file
 	lula dev validate -f ./oscal-component.yaml --run-tests
+To run validations and their tests, generating a JUnit XML test-results file
+	lula validate -f ./oscal-component.yaml --run-tests --test-report-format junit
+To run only the validations tagged cluster, except those tagged slow
+	lula validate -f ./oscal-component.yaml --tags cluster --exclude-tags slow
+To assess only specific controls
+	lula validate -f ./oscal-component.yaml --controls ac-1,ac-2
 `
 
 var (
@@ -51,6 +57,11 @@ func ValidateCommand() *cobra.Command {
 		runNonInteractively bool
 		saveResources       bool
 		runTests            bool
+		testReportFormat    string
+		tags                []string
+		excludeTags         []string
+		controls            []string
+		excludeControls     []string
 	)
 
 	cmd := &cobra.Command{
@@ -88,6 +99,9 @@ func ValidateCommand() *cobra.Command {
 				validation.WithSaveResources(saveResources),
 				validation.WithAllowExecution(confirmExecution, runNonInteractively),
 				validation.WithTests(runTests),
+				validation.WithTestReportFormat(testReportFormat),
+				validation.WithTagFilter(tags, excludeTags),
+				validation.WithControlFilter(controls, excludeControls),
 			)
 			if err != nil {
 				return fmt.Errorf("error creating new validator: %v", err)
@@ -123,8 +137,13 @@ func ValidateCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&confirmExecution, "confirm-execution", false, "confirm execution scripts run as part of the validation")
 	cmd.Flags().BoolVar(&runNonInteractively, "non-interactive", false, "run the command non-interactively")
 	cmd.Flags().BoolVar(&saveResources, "save-resources", false, "saves the resources to 'resources' directory at assessment-results level")
-	cmd.Flags().BoolVar(&runTests, "run-tests", false, "run tests specified in the validation, writes to test-results-<timestamp>.yaml in output directory")
+	cmd.Flags().BoolVar(&runTests, "run-tests", false, "run tests specified in the validation, writes to test-results-<timestamp>.<format> in output directory")
+	cmd.Flags().StringVar(&testReportFormat, "test-report-format", string(types.DefaultTestReportFormat), "the format of the test-results file: junit, tap, yaml or json")
 	cmd.Flags().StringSliceVarP(&setOpts, "set", "s", []string{}, "set a value in the template data")
+	cmd.Flags().StringSliceVar(&tags, "tags", []string{}, "run only validations with any of the tags (comma-separated), others are not assessed")
+	cmd.Flags().StringSliceVar(&excludeTags, "exclude-tags", []string{}, "do not run validations with any of the tags (comma-separated), they are not assessed")
+	cmd.Flags().StringSliceVar(&controls, "controls", []string{}, "assess only the requirements of the control IDs (comma-separated), others are not assessed")
+	cmd.Flags().StringSliceVar(&excludeControls, "exclude-controls", []string{}, "do not assess the requirements of the control IDs (comma-separated)")
 
 	return cmd
 }
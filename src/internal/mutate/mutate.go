@@ -0,0 +1,211 @@
+package mutate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/lula/src/internal/transform"
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+type MutationKind string
+
+const (
+	MutationKindDeleteField MutationKind = "delete-field"
+	MutationKindFlipBoolean MutationKind = "flip-boolean"
+	MutationKindEmptyList   MutationKind = "empty-list"
+)
+
+type MutationStatus string
+
+const (
+	// MutationStatusKilled means the mutation changed the result of the validation
+	MutationStatusKilled MutationStatus = "killed"
+	// MutationStatusSurvived means the validation result did not change, i.e. the validation
+	// doesn't depend on the mutated data
+	MutationStatusSurvived MutationStatus = "survived"
+	// MutationStatusError means the mutated resources could not be evaluated
+	MutationStatusError MutationStatus = "error"
+)
+
+// Mutation is a perturbation of the resources of a validation, written as a test change so a
+// surviving mutation can be added to the tests of the validation
+type Mutation struct {
+	Kind   MutationKind                   `json:"kind" yaml:"kind"`
+	Path   string                         `json:"path" yaml:"path"`
+	Change types.LulaValidationTestChange `json:"change" yaml:"change"`
+	Status MutationStatus                 `json:"status" yaml:"status"`
+	Result string                         `json:"result,omitempty" yaml:"result,omitempty"`
+	Error  string                         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// MutationReport is the result of mutating the resources of a validation
+type MutationReport struct {
+	Name      string     `json:"name" yaml:"name"`
+	Result    string     `json:"result" yaml:"result"`
+	Killed    int        `json:"killed" yaml:"killed"`
+	Survived  int        `json:"survived" yaml:"survived"`
+	Errors    int        `json:"errors" yaml:"errors"`
+	Skipped   int        `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Score     float64    `json:"score" yaml:"score"`
+	Mutations []Mutation `json:"mutations" yaml:"mutations"`
+}
+
+// Generate returns the mutations of the resources in document order, with map keys sorted:
+// deleting every field, flipping every boolean and emptying every non-empty list
+func Generate(resources types.DomainResources) []Mutation {
+	mutations := make([]Mutation, 0)
+	generate(map[string]interface{}(resources), "", &mutations)
+	return mutations
+}
+
+// generate appends the mutations of the value at the JSON Pointer and of its descendants
+func generate(value interface{}, pointer string, mutations *[]Mutation) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := pointer + "/" + escapePointer(key)
+			*mutations = append(*mutations, newMutation(MutationKindDeleteField, child, transform.JSONPatchOperation{Op: "remove", Path: child}))
+			generate(v[key], child, mutations)
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			*mutations = append(*mutations, newMutation(MutationKindEmptyList, pointer, transform.JSONPatchOperation{Op: "replace", Path: pointer, Value: []interface{}{}}))
+		}
+		for i, item := range v {
+			generate(item, fmt.Sprintf("%s/%d", pointer, i), mutations)
+		}
+	case bool:
+		*mutations = append(*mutations, newMutation(MutationKindFlipBoolean, pointer, transform.JSONPatchOperation{Op: "replace", Path: pointer, Value: !v}))
+	}
+}
+
+func newMutation(kind MutationKind, pointer string, op transform.JSONPatchOperation) Mutation {
+	return Mutation{
+		Kind: kind,
+		Path: pointer,
+		Change: types.LulaValidationTestChange{
+			Type:  transform.ChangeTypeJSONPatch,
+			Patch: []transform.JSONPatchOperation{op},
+		},
+	}
+}
+
+// escapePointer escapes a key as a JSON Pointer reference token
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// Run evaluates the validation against the resources, then against each mutation of the resources,
+// reporting which mutations change the result. At most maxMutations are evaluated, if positive.
+func Run(ctx context.Context, validation *types.LulaValidation, resources types.DomainResources, maxMutations int) (*MutationReport, error) {
+	if validation.Provider == nil {
+		return nil, fmt.Errorf("validation provider is nil")
+	}
+
+	baseline, err := evaluate(ctx, validation, resources)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating the resources: %v", err)
+	}
+
+	mutations := Generate(resources)
+	report := &MutationReport{
+		Name:      validation.Name,
+		Result:    baseline,
+		Mutations: make([]Mutation, 0, len(mutations)),
+	}
+	if maxMutations > 0 && len(mutations) > maxMutations {
+		report.Skipped = len(mutations) - maxMutations
+		mutations = mutations[:maxMutations]
+	}
+
+	for _, mutation := range mutations {
+		target, err := transform.CreateTransformTarget(resources)
+		if err != nil {
+			return nil, err
+		}
+		mutated, err := target.ExecuteJSONPatch(mutation.Change.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("error applying mutation %s: %v", mutation.Path, err)
+		}
+
+		result, err := evaluate(ctx, validation, mutated)
+		switch {
+		case err != nil:
+			mutation.Status = MutationStatusError
+			mutation.Error = err.Error()
+			report.Errors++
+		case result == baseline:
+			mutation.Status = MutationStatusSurvived
+			mutation.Result = result
+			report.Survived++
+		default:
+			mutation.Status = MutationStatusKilled
+			mutation.Result = result
+			report.Killed++
+		}
+		message.Debugf("Mutation %s %s: %s", mutation.Kind, mutation.Path, mutation.Status)
+		report.Mutations = append(report.Mutations, mutation)
+	}
+
+	if evaluated := report.Killed + report.Survived; evaluated > 0 {
+		report.Score = float64(report.Killed) / float64(evaluated) * 100
+	}
+
+	return report, nil
+}
+
+// evaluate runs the provider of the validation against the resources, returning satisfied or
+// not-satisfied
+func evaluate(ctx context.Context, validation *types.LulaValidation, resources types.DomainResources) (string, error) {
+	v := &types.LulaValidation{
+		Provider: validation.Provider,
+	}
+	if err := v.Validate(ctx, types.WithStaticResources(resources)); err != nil {
+		return "", err
+	}
+	if v.Result.Passing > 0 {
+		return "satisfied", nil
+	}
+	return "not-satisfied", nil
+}
+
+// SurvivingMutations returns the mutations that did not change the result of the validation
+func (r *MutationReport) SurvivingMutations() []Mutation {
+	surviving := make([]Mutation, 0, r.Survived)
+	for _, mutation := range r.Mutations {
+		if mutation.Status == MutationStatusSurvived {
+			surviving = append(surviving, mutation)
+		}
+	}
+	return surviving
+}
+
+// PrintReport prints the surviving mutations and the mutation score
+func (r *MutationReport) PrintReport() {
+	message.HeaderInfof("Mutation report: %s", r.Name)
+	message.Infof("Result without mutations: %s", r.Result)
+
+	surviving := r.SurvivingMutations()
+	if len(surviving) > 0 {
+		message.Infof("Surviving mutations:")
+		for _, mutation := range surviving {
+			message.Infof("--> %s %s", mutation.Kind, mutation.Path)
+		}
+	}
+	if r.Errors > 0 {
+		message.Warnf("%d mutations could not be evaluated", r.Errors)
+	}
+	if r.Skipped > 0 {
+		message.Warnf("%d mutations were skipped, exceeding the maximum number of mutations", r.Skipped)
+	}
+	message.Infof("Mutation score: %.2f%% (%d killed, %d survived)", r.Score, r.Killed, r.Survived)
+}
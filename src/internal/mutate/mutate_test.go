@@ -0,0 +1,99 @@
+package mutate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/internal/mutate"
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestGenerate(t *testing.T) {
+	resources := types.DomainResources{
+		"config": map[string]interface{}{
+			"enabled": true,
+			"a/b":     "value",
+		},
+		"items": []interface{}{"one"},
+	}
+
+	mutations := mutate.Generate(resources)
+
+	type expected struct {
+		kind mutate.MutationKind
+		path string
+	}
+	want := []expected{
+		{mutate.MutationKindDeleteField, "/config"},
+		{mutate.MutationKindDeleteField, "/config/a~1b"},
+		{mutate.MutationKindDeleteField, "/config/enabled"},
+		{mutate.MutationKindFlipBoolean, "/config/enabled"},
+		{mutate.MutationKindDeleteField, "/items"},
+		{mutate.MutationKindEmptyList, "/items"},
+	}
+	require.Len(t, mutations, len(want))
+	for i, mutation := range mutations {
+		require.Equal(t, want[i].kind, mutation.Kind)
+		require.Equal(t, want[i].path, mutation.Path)
+		require.Len(t, mutation.Change.Patch, 1)
+	}
+	require.Equal(t, false, mutations[3].Change.Patch[0].Value)
+}
+
+func TestRun(t *testing.T) {
+	resources := types.DomainResources{
+		"pods": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "pod-1",
+				},
+				"spec": map[string]interface{}{
+					"hostNetwork": false,
+				},
+			},
+		},
+	}
+
+	runMutations := func(t *testing.T, rego string, maxMutations int) *mutate.MutationReport {
+		t.Helper()
+		opaProvider, err := opa.CreateOpaProvider(context.Background(), &opa.OpaSpec{Rego: rego})
+		require.NoError(t, err)
+
+		validation := &types.LulaValidation{
+			Name:     "test-validation",
+			Provider: &opaProvider,
+		}
+		report, err := mutate.Run(context.Background(), validation, resources, maxMutations)
+		require.NoError(t, err)
+		return report
+	}
+
+	t.Run("validation depending on the resources kills mutations", func(t *testing.T) {
+		report := runMutations(t, "package validate\n\nvalidate {input.pods[0].spec.hostNetwork == false}", 0)
+
+		require.Equal(t, "satisfied", report.Result)
+		require.Greater(t, report.Killed, 0)
+		for _, mutation := range report.SurvivingMutations() {
+			require.Contains(t, []string{"/pods/0/metadata", "/pods/0/metadata/name"}, mutation.Path)
+		}
+		require.Less(t, report.Score, float64(100))
+	})
+
+	t.Run("vacuous validation survives all mutations", func(t *testing.T) {
+		report := runMutations(t, "package validate\n\nvalidate {true}", 0)
+
+		require.Equal(t, "satisfied", report.Result)
+		require.Equal(t, 0, report.Killed)
+		require.Equal(t, float64(0), report.Score)
+	})
+
+	t.Run("mutations over the maximum are skipped", func(t *testing.T) {
+		report := runMutations(t, "package validate\n\nvalidate {true}", 2)
+
+		require.Len(t, report.Mutations, 2)
+		require.Equal(t, len(mutate.Generate(resources))-2, report.Skipped)
+	})
+}
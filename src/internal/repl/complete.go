@@ -0,0 +1,203 @@
+package repl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var commands = []string{":dump", ":exit", ":help", ":lang", ":quit", ":reset", ":rules"}
+
+// pathChars matches the characters of a path at the end of a line
+var pathChars = regexp.MustCompile(`[A-Za-z0-9_.\[\]"'*$-]*$`)
+
+// identifier matches keys that can follow a dot in a path
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pathSegment is a complete key, index or wildcard of a path
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// Complete returns the completions of the line, each the whole line with its last word completed:
+// the commands, and the keys of the resources at the path at the end of the line, e.g.
+// input.pods[_].meta in rego or .pods[].meta in jq
+func (s *Session) Complete(line string) []string {
+	if strings.HasPrefix(line, ":") {
+		var candidates []string
+		for _, command := range commands {
+			if strings.HasPrefix(command, line) {
+				candidates = append(candidates, command)
+			}
+		}
+		return candidates
+	}
+
+	token := pathChars.FindString(line)
+	base := line[:len(line)-len(token)]
+
+	var path string
+	switch s.language {
+	case LanguageRego, LanguageCEL, LanguageCUE:
+		if !strings.HasPrefix(token, "input") {
+			if token != "" && strings.HasPrefix("input", token) {
+				return []string{base + "input"}
+			}
+			return nil
+		}
+		base += "input"
+		path = strings.TrimPrefix(token, "input")
+	case LanguageJMESPath:
+		// Paths start at the root, without a leading dot
+		path = "." + token
+	default:
+		path = strings.TrimPrefix(token, "$")
+		base += token[:len(token)-len(path)]
+		if !strings.HasPrefix(path, ".") {
+			return nil
+		}
+	}
+
+	// Split the path into its complete segments and the partial key at the end
+	lastDot := strings.LastIndex(path, ".")
+	if lastDot < 0 || strings.Contains(path[lastDot:], "[") {
+		return nil
+	}
+	segments, ok := parsePath(path[:lastDot])
+	if !ok {
+		return nil
+	}
+	partial := path[lastDot+1:]
+	root := lastDot == 0
+	if s.language == LanguageJMESPath {
+		base += strings.TrimPrefix(path[:lastDot], ".")
+	} else {
+		base += path[:lastDot]
+	}
+
+	values := resolvePath([]interface{}{map[string]interface{}(s.resources)}, segments)
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				if !strings.HasPrefix(key, partial) || seen[key] {
+					continue
+				}
+				seen[key] = true
+				candidates = append(candidates, base+s.formatKey(key, root))
+			}
+		case []interface{}:
+			// Lists are completed with a wildcard, in the languages that have one
+			if partial == "" && !seen["[]"] && s.wildcard() != "" {
+				seen["[]"] = true
+				candidates = append(candidates, base+s.wildcard())
+			}
+		}
+	}
+	return candidates
+}
+
+// formatKey formats a key as a path segment of the language, at the root of the path or not
+func (s *Session) formatKey(key string, root bool) string {
+	dot := "."
+	if s.language == LanguageJMESPath && root {
+		// A JMESPath path starts at the root, without a leading dot
+		dot = ""
+	}
+	if identifier.MatchString(key) {
+		return dot + key
+	}
+	switch s.language {
+	case LanguageJSONPath:
+		return "['" + key + "']"
+	case LanguageJMESPath:
+		return dot + strconv.Quote(key)
+	default:
+		return "[" + strconv.Quote(key) + "]"
+	}
+}
+
+// wildcard returns the segment of the language matching every item of a list
+func (s *Session) wildcard() string {
+	switch s.language {
+	case LanguageJQ:
+		return "[]"
+	case LanguageJSONPath, LanguageJMESPath:
+		return "[*]"
+	case LanguageCEL, LanguageCUE:
+		return ""
+	default:
+		return "[_]"
+	}
+}
+
+// parsePath parses the dot and bracket segments of a path, where brackets hold an index, a quoted
+// key, or anything else, e.g. a variable, as a wildcard
+func parsePath(path string) ([]pathSegment, bool) {
+	var segments []pathSegment
+	for path != "" {
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			key := path[1 : end+1]
+			if key == "" {
+				return nil, false
+			}
+			segments = append(segments, pathSegment{key: key})
+			path = path[end+1:]
+		case '[':
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return nil, false
+			}
+			content := path[1:end]
+			if index, err := strconv.Atoi(content); err == nil {
+				segments = append(segments, pathSegment{index: index, isIndex: true})
+			} else if key, err := strconv.Unquote(strings.ReplaceAll(content, "'", "\"")); err == nil {
+				segments = append(segments, pathSegment{key: key})
+			} else {
+				segments = append(segments, pathSegment{wildcard: true})
+			}
+			path = path[end+1:]
+		default:
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// resolvePath returns the values at the path in each of the values
+func resolvePath(values []interface{}, segments []pathSegment) []interface{} {
+	for _, segment := range segments {
+		var next []interface{}
+		for _, value := range values {
+			switch v := value.(type) {
+			case map[string]interface{}:
+				if segment.wildcard {
+					for _, key := range sortedKeys(v) {
+						next = append(next, v[key])
+					}
+				} else if child, ok := v[segment.key]; ok && !segment.isIndex {
+					next = append(next, child)
+				}
+			case []interface{}:
+				if segment.wildcard {
+					next = append(next, v...)
+				} else if segment.isIndex && segment.index >= 0 && segment.index < len(v) {
+					next = append(next, v[segment.index])
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
@@ -0,0 +1,98 @@
+package repl
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	cuelang "cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/google/cel-go/cel"
+	"github.com/jmespath-community/go-jmespath"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// input returns the resources as plain JSON values, with the same types as when they are
+// evaluated by the providers
+func (s *Session) input() (interface{}, error) {
+	data, err := json.Marshal(s.resources)
+	if err != nil {
+		return nil, err
+	}
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// evalCEL evaluates a CEL expression, with the resources available as input
+func (s *Session) evalCEL(line string) (string, error) {
+	env, err := cel.NewEnv(cel.Variable("input", cel.DynType))
+	if err != nil {
+		return "", err
+	}
+	ast, issues := env.Compile(line)
+	if issues.Err() != nil {
+		return "", issues.Err()
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return "", err
+	}
+	input, err := s.input()
+	if err != nil {
+		return "", err
+	}
+	out, _, err := program.Eval(map[string]interface{}{"input": input})
+	if err != nil {
+		return "", err
+	}
+	value, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return "", fmt.Errorf("error converting the result: %v", err)
+	}
+	return formatValue(value.(*structpb.Value).AsInterface()), nil
+}
+
+// evalJMESPath evaluates a JMESPath expression, as used by Kyverno variables, against the resources
+func (s *Session) evalJMESPath(line string) (string, error) {
+	input, err := s.input()
+	if err != nil {
+		return "", err
+	}
+	result, err := jmespath.Search(line, input)
+	if err != nil {
+		return "", err
+	}
+	if result == nil {
+		return "null", nil
+	}
+	return formatValue(result), nil
+}
+
+// evalCUE evaluates a CUE expression, with the resources available as input
+func (s *Session) evalCUE(line string) (string, error) {
+	input, err := s.input()
+	if err != nil {
+		return "", err
+	}
+	ctx := cuecontext.New()
+	scope := ctx.Encode(map[string]interface{}{"input": input})
+	if err := scope.Err(); err != nil {
+		return "", err
+	}
+	value := ctx.CompileString(line, cuelang.Scope(scope))
+	if err := value.Err(); err != nil {
+		return "", err
+	}
+	if err := value.Validate(cuelang.Concrete(true)); err != nil {
+		// Values that are not concrete, e.g. definitions, are shown as CUE
+		return fmt.Sprint(value), nil
+	}
+	var result interface{}
+	if err := value.Decode(&result); err != nil {
+		return "", err
+	}
+	return formatValue(result), nil
+}
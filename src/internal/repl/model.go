@@ -0,0 +1,224 @@
+package repl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type keys struct {
+	Quit     key.Binding
+	Cancel   key.Binding
+	Confirm  key.Binding
+	Complete key.Binding
+	Previous key.Binding
+	Next     key.Binding
+}
+
+var replKeys = keys{
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "quit"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "clear the input, or quit if empty"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "evaluate"),
+	),
+	Complete: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "complete"),
+	),
+	Previous: key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "previous history entry"),
+	),
+	Next: key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "next history entry"),
+	),
+}
+
+// Model is the interactive prompt of a session
+type Model struct {
+	ctx           context.Context
+	session       *Session
+	keys          keys
+	input         textinput.Model
+	buffer        []string
+	candidates    []string
+	history       []string
+	historyIndex  int
+	historyWriter io.Writer
+}
+
+// NewModel creates the prompt of the session, with the previous entries of the history. Each new
+// entry is written to the historyWriter as a line, if it is not nil.
+func NewModel(ctx context.Context, session *Session, history []string, historyWriter io.Writer) Model {
+	input := textinput.New()
+	input.Focus()
+
+	m := Model{
+		ctx:           ctx,
+		session:       session,
+		keys:          replKeys,
+		input:         input,
+		history:       history,
+		historyIndex:  len(history),
+		historyWriter: historyWriter,
+	}
+	m.updatePrompt()
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Sequence(
+		tea.Println("Type :help for the commands, tab to complete input paths"),
+		textinput.Blink,
+	)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			if m.input.Value() == "" && len(m.buffer) == 0 {
+				return m, tea.Quit
+			}
+		case key.Matches(msg, m.keys.Cancel):
+			if m.input.Value() == "" && len(m.buffer) == 0 {
+				return m, tea.Quit
+			}
+			m.buffer = nil
+			m.candidates = nil
+			m.input.SetValue("")
+			m.updatePrompt()
+			return m, nil
+		case key.Matches(msg, m.keys.Complete):
+			m.complete()
+			return m, nil
+		case key.Matches(msg, m.keys.Previous):
+			if m.historyIndex > 0 {
+				m.historyIndex--
+				m.setValue(m.history[m.historyIndex])
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Next):
+			if m.historyIndex < len(m.history) {
+				m.historyIndex++
+				value := ""
+				if m.historyIndex < len(m.history) {
+					value = m.history[m.historyIndex]
+				}
+				m.setValue(value)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Confirm):
+			return m.confirm()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	view := m.input.View()
+	if len(m.candidates) > 0 {
+		view += "\n" + strings.Join(m.candidates, "  ")
+	}
+	return view + "\n"
+}
+
+// confirm evaluates the input, or adds it to the buffer if its braces are not balanced
+func (m Model) confirm() (tea.Model, tea.Cmd) {
+	line := m.input.Value()
+	cmds := []tea.Cmd{tea.Println(m.input.Prompt + line)}
+	m.candidates = nil
+	m.input.SetValue("")
+	m.addHistory(line)
+
+	m.buffer = append(m.buffer, line)
+	input := strings.Join(m.buffer, "\n")
+	if strings.Count(input, "{") > strings.Count(input, "}") {
+		m.updatePrompt()
+		return m, tea.Sequence(cmds...)
+	}
+	m.buffer = nil
+
+	output, err := m.session.Exec(m.ctx, input)
+	switch {
+	case errors.Is(err, ErrQuit):
+		return m, tea.Sequence(append(cmds, tea.Quit)...)
+	case err != nil:
+		cmds = append(cmds, tea.Println(fmt.Sprintf("error: %v", err)))
+	case output != "":
+		cmds = append(cmds, tea.Println(output))
+	}
+	m.updatePrompt()
+	return m, tea.Sequence(cmds...)
+}
+
+// complete completes the input to its only completion, or to the common prefix of its completions
+// and shows them
+func (m *Model) complete() {
+	candidates := m.session.Complete(m.input.Value())
+	m.candidates = nil
+	switch len(candidates) {
+	case 0:
+		return
+	case 1:
+		m.setValue(candidates[0])
+	default:
+		m.setValue(commonPrefix(candidates))
+		m.candidates = candidates
+	}
+}
+
+// addHistory adds the line to the history, unless it is empty or repeats the last entry
+func (m *Model) addHistory(line string) {
+	if strings.TrimSpace(line) != "" && (len(m.history) == 0 || m.history[len(m.history)-1] != line) {
+		m.history = append(m.history, line)
+		if m.historyWriter != nil {
+			// History is best effort, and does not interrupt the session
+			//nolint:errcheck
+			fmt.Fprintln(m.historyWriter, line)
+		}
+	}
+	m.historyIndex = len(m.history)
+}
+
+func (m *Model) setValue(value string) {
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+}
+
+// updatePrompt shows the language of the session, or that the input continues the buffer
+func (m *Model) updatePrompt() {
+	prompt := fmt.Sprintf("%s> ", m.session.Language())
+	if len(m.buffer) > 0 {
+		prompt = strings.Repeat(" ", len(prompt)-2) + "| "
+	}
+	m.input.Prompt = prompt
+}
+
+// commonPrefix returns the longest prefix of all of the strings
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
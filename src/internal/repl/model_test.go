@@ -0,0 +1,64 @@
+package repl_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/internal/repl"
+)
+
+func TestModel(t *testing.T) {
+	session := newSession(t, nil)
+	var historyWriter bytes.Buffer
+	var model tea.Model = repl.NewModel(context.Background(), session, []string{"input.pods[0]"}, &historyWriter)
+
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		var cmd tea.Cmd
+		model, cmd = model.Update(msg)
+		return cmd
+	}
+	typeText := func(text string) {
+		t.Helper()
+		update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	}
+
+	require.Contains(t, model.View(), "rego> ")
+
+	// Tab completes the input path
+	typeText("input.po")
+	update(tea.KeyMsg{Type: tea.KeyTab})
+	require.Contains(t, model.View(), "rego> input.pods")
+
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, "input.pods\n", historyWriter.String())
+
+	// Unbalanced braces continue on the next line
+	typeText("names := {")
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Contains(t, model.View(), "   | ")
+	typeText("}")
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Contains(t, model.View(), "rego> ")
+	require.Contains(t, session.Rego(), "names := {\n}")
+
+	// The history includes the entries of previous sessions
+	update(tea.KeyMsg{Type: tea.KeyUp})
+	require.Contains(t, model.View(), "rego> }")
+	update(tea.KeyMsg{Type: tea.KeyUp})
+	update(tea.KeyMsg{Type: tea.KeyUp})
+	update(tea.KeyMsg{Type: tea.KeyUp})
+	require.Contains(t, model.View(), "rego> input.pods[0]")
+	update(tea.KeyMsg{Type: tea.KeyDown})
+	require.Contains(t, model.View(), "rego> input.pods ")
+
+	// ctrl+c clears the input, then quits
+	cmd := update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	require.Nil(t, cmd)
+	cmd = update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	require.Equal(t, tea.QuitMsg{}, cmd())
+}
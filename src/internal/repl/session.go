@@ -0,0 +1,433 @@
+package repl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/go-oscal/src/pkg/files"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/providers/expression"
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// Language is the query language of the REPL
+type Language string
+
+const (
+	LanguageRego     Language = "rego"
+	LanguageJQ       Language = "jq"
+	LanguageJSONPath Language = "jsonpath"
+	LanguageCEL      Language = "cel"
+	LanguageJMESPath Language = "jmespath"
+	LanguageCUE      Language = "cue"
+)
+
+// languages are the query languages of the REPL, in the order they are listed
+var languages = []Language{LanguageRego, LanguageJQ, LanguageJSONPath, LanguageCEL, LanguageJMESPath, LanguageCUE}
+
+// ErrQuit is returned by Exec when the session should end
+var ErrQuit = fmt.Errorf("quit")
+
+const defaultPackage = "package validate"
+
+// ruleModuleName is the name of the module of the rules of the session
+const ruleModuleName = "validate.rego"
+
+var helpText = `Enter a query in the current language to evaluate it against the resources, available as input in rego, cel and cue, and as the root in jq, jsonpath and jmespath.
+In rego, rules, e.g. "validate := count(input.pods) > 0", and imports are added to the rule set, and assigning a rule with := replaces it.
+jmespath is the language of Kyverno variables, without the custom functions of Kyverno; Kyverno patterns are not evaluated in the REPL.
+Lines with unbalanced braces continue on the next line.
+
+Commands:
+  :help            show this help
+  :lang [language] show or set the query language: rego, jq, jsonpath, cel, jmespath or cue
+  :rules           show the rule set
+  :reset           restore the rule set of the validation
+  :dump <file>     write the validation to a file, with the rule set as its rego if the provider is opa
+  :quit            exit the REPL (also :exit or ctrl+d)`
+
+// regoRule is a rule of the rule set, with its source text
+type regoRule struct {
+	name string
+	text string
+}
+
+// Session evaluates queries against domain resources, and keeps the rule set built up with them
+type Session struct {
+	resources  types.DomainResources
+	validation *common.Validation
+	modules    map[string]string
+	language   Language
+
+	pkg     string
+	imports []string
+	rules   []regoRule
+}
+
+// NewSession creates a session over the resources. The rule set starts with the rego of the
+// validation if it has an OPA provider, and its modules are included when evaluating queries.
+// The validation may be nil.
+func NewSession(resources types.DomainResources, validation *common.Validation, modules map[string]string) (*Session, error) {
+	if resources == nil {
+		resources = types.DomainResources{}
+	}
+	s := &Session{
+		resources:  resources,
+		validation: validation,
+		modules:    modules,
+		language:   LanguageRego,
+	}
+	if err := s.Reset(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Language returns the query language of the session
+func (s *Session) Language() Language {
+	return s.language
+}
+
+// Resources returns the resources the queries are evaluated against
+func (s *Session) Resources() types.DomainResources {
+	return s.resources
+}
+
+// Reset restores the rule set to the rego of the validation
+func (s *Session) Reset() error {
+	s.pkg = defaultPackage
+	s.imports = nil
+	s.rules = nil
+
+	if s.validation == nil || s.validation.Provider == nil || s.validation.Provider.OpaSpec == nil || s.validation.Provider.OpaSpec.Rego == "" {
+		return nil
+	}
+	module, err := ast.ParseModule(ruleModuleName, s.validation.Provider.OpaSpec.Rego)
+	if err != nil {
+		return fmt.Errorf("error parsing the rego of the validation: %v", err)
+	}
+	s.pkg = module.Package.String()
+	for _, imp := range module.Imports {
+		s.imports = append(s.imports, imp.String())
+	}
+	for _, rule := range module.Rules {
+		s.rules = append(s.rules, regoRule{
+			name: rule.Head.Ref().String(),
+			text: string(rule.Location.Text),
+		})
+	}
+	return nil
+}
+
+// Rego returns the rule set as a rego module
+func (s *Session) Rego() string {
+	var sb strings.Builder
+	sb.WriteString(s.pkg + "\n")
+	if len(s.imports) > 0 {
+		sb.WriteString("\n" + strings.Join(s.imports, "\n") + "\n")
+	}
+	for _, rule := range s.rules {
+		sb.WriteString("\n" + rule.text + "\n")
+	}
+	return sb.String()
+}
+
+// Exec executes a line of input, a command or a query, and returns its output
+func (s *Session) Exec(ctx context.Context, line string) (string, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(line, ":") {
+		return s.command(line)
+	}
+
+	switch s.language {
+	case LanguageJQ:
+		return s.query(ctx, expression.Expression{Name: "repl", JQ: line})
+	case LanguageJSONPath:
+		return s.query(ctx, expression.Expression{Name: "repl", JSONPath: line})
+	case LanguageCEL:
+		return s.evalCEL(line)
+	case LanguageJMESPath:
+		return s.evalJMESPath(line)
+	case LanguageCUE:
+		return s.evalCUE(line)
+	default:
+		return s.rego(ctx, line)
+	}
+}
+
+// command executes a REPL command
+func (s *Session) command(line string) (string, error) {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case ":help":
+		return helpText, nil
+	case ":quit", ":exit":
+		return "", ErrQuit
+	case ":lang":
+		if len(fields) == 1 {
+			return string(s.language), nil
+		}
+		language := Language(fields[1])
+		names := make([]string, 0, len(languages))
+		for _, l := range languages {
+			if l == language {
+				s.language = language
+				return fmt.Sprintf("language set to %s", language), nil
+			}
+			names = append(names, string(l))
+		}
+		return "", fmt.Errorf("unsupported language %s, must be one of %s", fields[1], strings.Join(names, ", "))
+	case ":rules":
+		return strings.TrimSuffix(s.Rego(), "\n"), nil
+	case ":reset":
+		if err := s.Reset(); err != nil {
+			return "", err
+		}
+		return "rule set restored", nil
+	case ":dump":
+		if len(fields) != 2 {
+			return "", fmt.Errorf("usage: :dump <file>")
+		}
+		if err := s.Dump(fields[1]); err != nil {
+			return "", err
+		}
+		if !s.dumpsRules() {
+			return fmt.Sprintf("validation written to %s, without the rule set as its provider is not opa", fields[1]), nil
+		}
+		return fmt.Sprintf("validation written to %s", fields[1]), nil
+	default:
+		return "", fmt.Errorf("unknown command %s, type :help for the commands", fields[0])
+	}
+}
+
+// query evaluates a jq or JSONPath query
+func (s *Session) query(ctx context.Context, expr expression.Expression) (string, error) {
+	results, err := expression.Query(ctx, expr, s.resources)
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 {
+		return "no results", nil
+	}
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		lines = append(lines, formatValue(result))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// rego adds the imports and rules of the input to the rule set, and evaluates its queries
+func (s *Session) rego(ctx context.Context, line string) (string, error) {
+	// Parse with the imports of the rule set, so future keywords are available
+	header := strings.Join(s.imports, "\n")
+	statements, _, err := ast.ParseStatements("", header+"\n"+line)
+	if err != nil {
+		return "", err
+	}
+	statements = statements[len(s.imports):]
+
+	var output []string
+	for _, statement := range statements {
+		switch stmt := statement.(type) {
+		case *ast.Package:
+			return "", fmt.Errorf("the package of the rule set cannot be changed")
+		case *ast.Import:
+			if err := s.addImport(stmt.String()); err != nil {
+				return "", err
+			}
+		case *ast.Rule:
+			if err := s.addRule(stmt, string(stmt.Location.Text)); err != nil {
+				return "", err
+			}
+			output = append(output, fmt.Sprintf("rule %s defined", stmt.Head.Ref()))
+		case ast.Body:
+			// An assignment to a variable defines a rule
+			if len(stmt) == 1 && stmt[0].IsAssignment() {
+				if rule, err := ast.ParseRuleFromBody(&ast.Module{Package: &ast.Package{Path: ast.DefaultRootRef}}, stmt); err == nil {
+					if err := s.addRule(rule, string(stmt[0].Location.Text)); err != nil {
+						return "", err
+					}
+					output = append(output, fmt.Sprintf("rule %s defined", rule.Head.Ref()))
+					continue
+				}
+			}
+			result, err := s.evalRego(ctx, stmt)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, result)
+		}
+	}
+	return strings.Join(output, "\n"), nil
+}
+
+// addImport adds the import to the rule set if it compiles
+func (s *Session) addImport(imp string) error {
+	for _, existing := range s.imports {
+		if existing == imp {
+			return nil
+		}
+	}
+	s.imports = append(s.imports, imp)
+	if _, err := s.compile(); err != nil {
+		s.imports = s.imports[:len(s.imports)-1]
+		return err
+	}
+	return nil
+}
+
+// addRule adds the rule to the rule set if it compiles. A rule assigned with := replaces the
+// rules with the same name.
+func (s *Session) addRule(rule *ast.Rule, text string) error {
+	name := rule.Head.Ref().String()
+	rules := s.rules
+	s.rules = make([]regoRule, 0, len(rules)+1)
+	for _, existing := range rules {
+		if !rule.Head.Assign || existing.name != name {
+			s.rules = append(s.rules, existing)
+		}
+	}
+	s.rules = append(s.rules, regoRule{name: name, text: text})
+	if _, err := s.compile(); err != nil {
+		s.rules = rules
+		return err
+	}
+	return nil
+}
+
+// compile parses and compiles the rule set with the modules of the validation
+func (s *Session) compile() (*ast.Module, error) {
+	module, err := ast.ParseModule(ruleModuleName, s.Rego())
+	if err != nil {
+		return nil, err
+	}
+	modules := map[string]*ast.Module{ruleModuleName: module}
+	for name, content := range s.modules {
+		m, err := ast.ParseModule(name, content)
+		if err != nil {
+			return nil, err
+		}
+		modules[name] = m
+	}
+	compiler := ast.NewCompiler()
+	if compiler.Compile(modules); compiler.Failed() {
+		return nil, compiler.Errors
+	}
+	return module, nil
+}
+
+// evalRego evaluates the query in the package of the rule set
+func (s *Session) evalRego(ctx context.Context, query ast.Body) (string, error) {
+	module, err := s.compile()
+	if err != nil {
+		return "", err
+	}
+
+	options := []func(*rego.Rego){
+		rego.ParsedQuery(query),
+		rego.ParsedPackage(module.Package),
+		rego.ParsedImports(module.Imports),
+		rego.ParsedModule(module),
+		rego.Input(map[string]interface{}(s.resources)),
+	}
+	for name, content := range s.modules {
+		options = append(options, rego.Module(name, content))
+	}
+
+	results, err := rego.New(options...).Eval(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(results) == 0 {
+		return "undefined", nil
+	}
+
+	// Show the bindings of the variables of the query, or the value of a single expression
+	if len(results[0].Bindings) == 0 && len(results) == 1 && len(results[0].Expressions) == 1 {
+		return formatValue(results[0].Expressions[0].Value), nil
+	}
+	if len(results[0].Bindings) == 0 {
+		values := make([]interface{}, 0, len(results))
+		for _, result := range results {
+			for _, expression := range result.Expressions {
+				values = append(values, expression.Value)
+			}
+		}
+		return formatValue(values), nil
+	}
+	bindings := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		bindings = append(bindings, map[string]interface{}(result.Bindings))
+	}
+	return formatValue(bindings), nil
+}
+
+// dumpsRules reports whether Dump writes the rule set, i.e. the validation of the session has an
+// OPA provider or no provider
+func (s *Session) dumpsRules() bool {
+	return s.validation == nil || s.validation.Provider == nil || s.validation.Provider.Type == "" || s.validation.Provider.Type == "opa"
+}
+
+// Dump writes a validation with the rule set as the rego of its OPA provider. The domain, tests and
+// other OPA settings of the validation of the session are kept. A validation with another provider
+// is written with its provider unchanged.
+func (s *Session) Dump(path string) error {
+	var validation common.Validation
+	if s.validation != nil {
+		validation = *s.validation
+	} else {
+		validation = common.Validation{
+			Metadata: &common.Metadata{
+				Name: "repl",
+			},
+		}
+	}
+
+	if s.dumpsRules() {
+		spec := &opa.OpaSpec{}
+		if validation.Provider != nil && validation.Provider.OpaSpec != nil {
+			*spec = *validation.Provider.OpaSpec
+		}
+		spec.Rego = s.Rego()
+		validation.Provider = &common.Provider{
+			Type:    "opa",
+			OpaSpec: spec,
+		}
+	}
+
+	data, err := yaml.Marshal(validation)
+	if err != nil {
+		return err
+	}
+	return files.WriteOutput(data, path)
+}
+
+// formatValue formats a value of a result as indented JSON
+func formatValue(value interface{}) string {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
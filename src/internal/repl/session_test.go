@@ -0,0 +1,301 @@
+package repl_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/internal/repl"
+	"github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/providers/cue"
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var resources = types.DomainResources{
+	"pods": []interface{}{
+		map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      "pod-1",
+				"namespace": "default",
+				"labels": map[string]interface{}{
+					"app.kubernetes.io/name": "nginx",
+				},
+			},
+		},
+		map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": "pod-2",
+			},
+		},
+	},
+}
+
+func newSession(t *testing.T, validation *common.Validation) *repl.Session {
+	t.Helper()
+	session, err := repl.NewSession(resources, validation, nil)
+	require.NoError(t, err)
+	return session
+}
+
+func TestExec(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rego queries", func(t *testing.T) {
+		session := newSession(t, nil)
+
+		output, err := session.Exec(ctx, "input.pods[0].metadata.name")
+		require.NoError(t, err)
+		require.Equal(t, `"pod-1"`, output)
+
+		output, err = session.Exec(ctx, "input.pods[i].metadata.namespace")
+		require.NoError(t, err)
+		require.JSONEq(t, `[{"i": 0}]`, output)
+
+		output, err = session.Exec(ctx, "input.deployments")
+		require.NoError(t, err)
+		require.Equal(t, "undefined", output)
+
+		_, err = session.Exec(ctx, "input.pods[")
+		require.Error(t, err)
+	})
+
+	t.Run("rego rules", func(t *testing.T) {
+		session := newSession(t, nil)
+
+		output, err := session.Exec(ctx, "names := {name | name := input.pods[_].metadata.name}")
+		require.NoError(t, err)
+		require.Equal(t, "rule names defined", output)
+
+		output, err = session.Exec(ctx, "import future.keywords.in")
+		require.NoError(t, err)
+		require.Empty(t, output)
+
+		output, err = session.Exec(ctx, `validate { "pod-2" in names }`)
+		require.NoError(t, err)
+		require.Equal(t, "rule validate defined", output)
+
+		output, err = session.Exec(ctx, "validate")
+		require.NoError(t, err)
+		require.Equal(t, "true", output)
+
+		// Assigning a rule again replaces it
+		_, err = session.Exec(ctx, "names := {}")
+		require.NoError(t, err)
+		output, err = session.Exec(ctx, "validate")
+		require.NoError(t, err)
+		require.Equal(t, "undefined", output)
+
+		require.Equal(t, "package validate\n\nimport future.keywords.in\n\nvalidate { \"pod-2\" in names }\n\nnames := {}\n", session.Rego())
+
+		_, err = session.Exec(ctx, "broken { input.pods[_] == undefined_var }")
+		require.Error(t, err)
+		_, err = session.Exec(ctx, "package other")
+		require.ErrorContains(t, err, "cannot be changed")
+	})
+
+	t.Run("rego of the validation", func(t *testing.T) {
+		validation := &common.Validation{
+			Provider: &common.Provider{
+				Type: "opa",
+				OpaSpec: &opa.OpaSpec{
+					Rego: "package validate\n\nimport future.keywords.if\n\n# the result\nvalidate if count(input.pods) == 2\n",
+				},
+			},
+		}
+		session := newSession(t, validation)
+
+		output, err := session.Exec(ctx, "validate")
+		require.NoError(t, err)
+		require.Equal(t, "true", output)
+
+		_, err = session.Exec(ctx, "extra := 1")
+		require.NoError(t, err)
+		output, err = session.Exec(ctx, ":reset")
+		require.NoError(t, err)
+		require.Equal(t, "rule set restored", output)
+		require.Equal(t, "package validate\n\nimport future.keywords.if\n\nvalidate if count(input.pods) == 2\n", session.Rego())
+	})
+
+	t.Run("jq and jsonpath queries", func(t *testing.T) {
+		session := newSession(t, nil)
+
+		output, err := session.Exec(ctx, ":lang jq")
+		require.NoError(t, err)
+		require.Equal(t, "language set to jq", output)
+		output, err = session.Exec(ctx, ".pods[].metadata.name")
+		require.NoError(t, err)
+		require.Equal(t, "\"pod-1\"\n\"pod-2\"", output)
+
+		_, err = session.Exec(ctx, ":lang jsonpath")
+		require.NoError(t, err)
+		output, err = session.Exec(ctx, "{.pods[1].metadata.name}")
+		require.NoError(t, err)
+		require.Equal(t, `"pod-2"`, output)
+		output, err = session.Exec(ctx, ".pods[*].spec")
+		require.NoError(t, err)
+		require.Equal(t, "no results", output)
+
+		_, err = session.Exec(ctx, ":lang python")
+		require.ErrorContains(t, err, "unsupported language")
+	})
+
+	t.Run("cel, jmespath and cue queries", func(t *testing.T) {
+		session := newSession(t, nil)
+
+		_, err := session.Exec(ctx, ":lang cel")
+		require.NoError(t, err)
+		output, err := session.Exec(ctx, "input.pods.map(p, p.metadata.name)")
+		require.NoError(t, err)
+		require.JSONEq(t, `["pod-1", "pod-2"]`, output)
+		output, err = session.Exec(ctx, "input.pods.all(p, has(p.metadata.namespace))")
+		require.NoError(t, err)
+		require.Equal(t, "false", output)
+		_, err = session.Exec(ctx, "input.pods.map(")
+		require.Error(t, err)
+
+		_, err = session.Exec(ctx, ":lang jmespath")
+		require.NoError(t, err)
+		output, err = session.Exec(ctx, "pods[?metadata.namespace == 'default'].metadata.name")
+		require.NoError(t, err)
+		require.JSONEq(t, `["pod-1"]`, output)
+		output, err = session.Exec(ctx, "deployments")
+		require.NoError(t, err)
+		require.Equal(t, "null", output)
+
+		_, err = session.Exec(ctx, ":lang cue")
+		require.NoError(t, err)
+		output, err = session.Exec(ctx, "len(input.pods)")
+		require.NoError(t, err)
+		require.Equal(t, "2", output)
+		output, err = session.Exec(ctx, `[for p in input.pods {p.metadata.name}]`)
+		require.NoError(t, err)
+		require.JSONEq(t, `["pod-1", "pod-2"]`, output)
+		_, err = session.Exec(ctx, "input.deployments")
+		require.Error(t, err)
+	})
+
+	t.Run("commands", func(t *testing.T) {
+		session := newSession(t, nil)
+
+		_, err := session.Exec(ctx, ":quit")
+		require.ErrorIs(t, err, repl.ErrQuit)
+		_, err = session.Exec(ctx, ":unknown")
+		require.ErrorContains(t, err, "unknown command")
+		output, err := session.Exec(ctx, ":help")
+		require.NoError(t, err)
+		require.Contains(t, output, ":dump <file>")
+	})
+}
+
+func TestDump(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	validation := &common.Validation{
+		LulaVersion: ">=v0.2.0",
+		Metadata: &common.Metadata{
+			Name: "validation",
+		},
+		Domain: &common.Domain{
+			Type: "file",
+		},
+		Provider: &common.Provider{
+			Type: "opa",
+			OpaSpec: &opa.OpaSpec{
+				Rego: "package validate\n\nvalidate := true\n",
+				Output: &opa.OpaOutput{
+					Validation: "validate.validate",
+				},
+			},
+		},
+	}
+	session := newSession(t, validation)
+	_, err := session.Exec(ctx, "validate := count(input.pods) > 1")
+	require.NoError(t, err)
+
+	path := filepath.Join(tmpDir, "validation.yaml")
+	output, err := session.Exec(ctx, ":dump "+path)
+	require.NoError(t, err)
+	require.Contains(t, output, "validation written to")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var dumped common.Validation
+	require.NoError(t, yaml.Unmarshal(data, &dumped))
+	require.Equal(t, "validation", dumped.Metadata.Name)
+	require.Equal(t, "file", dumped.Domain.Type)
+	require.Equal(t, "validate.validate", dumped.Provider.OpaSpec.Output.Validation)
+	require.Equal(t, "package validate\n\nvalidate := count(input.pods) > 1\n", dumped.Provider.OpaSpec.Rego)
+
+	// The validation of the session is unchanged
+	require.Equal(t, "package validate\n\nvalidate := true\n", validation.Provider.OpaSpec.Rego)
+
+	// Without a validation, only the provider is written
+	session = newSession(t, nil)
+	path = filepath.Join(tmpDir, "new.yaml")
+	_, err = session.Exec(ctx, ":dump "+path)
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(data, &dumped))
+	require.Equal(t, "opa", dumped.Provider.Type)
+	require.Equal(t, "package validate\n", dumped.Provider.OpaSpec.Rego)
+
+	// The provider of a validation that is not opa is kept
+	validation.Provider = &common.Provider{Type: "cue", CueSpec: &cue.CueSpec{Cue: "#Pod: {}"}}
+	session = newSession(t, validation)
+	path = filepath.Join(tmpDir, "cue.yaml")
+	output, err = session.Exec(ctx, ":dump "+path)
+	require.NoError(t, err)
+	require.Contains(t, output, "without the rule set")
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	dumped = common.Validation{}
+	require.NoError(t, yaml.Unmarshal(data, &dumped))
+	require.Equal(t, "cue", dumped.Provider.Type)
+	require.Nil(t, dumped.Provider.OpaSpec)
+	require.Equal(t, "#Pod: {}", dumped.Provider.CueSpec.Cue)
+}
+
+func TestComplete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		language string
+		line     string
+		want     []string
+	}{
+		{name: "command", line: ":re", want: []string{":reset"}},
+		{name: "input", line: "count(in", want: []string{"count(input"}},
+		{name: "root keys", line: "input.", want: []string{"input.pods"}},
+		{name: "list", line: "input.pods.", want: []string{"input.pods[_]"}},
+		{name: "keys of every item", line: "x := input.pods[_].metadata.n", want: []string{"x := input.pods[_].metadata.name", "x := input.pods[_].metadata.namespace"}},
+		{name: "keys of an item", line: "input.pods[1].metadata.", want: []string{"input.pods[1].metadata.name"}},
+		{name: "quoted keys", line: "input.pods[i].metadata.labels.", want: []string{`input.pods[i].metadata.labels["app.kubernetes.io/name"]`}},
+		{name: "unknown path", line: "input.deployments.", want: nil},
+		{name: "jq", language: "jq", line: ".pods[].meta", want: []string{".pods[].metadata"}},
+		{name: "jq list", language: "jq", line: ".pods.", want: []string{".pods[]"}},
+		{name: "cel", language: "cel", line: "input.pods[0].meta", want: []string{"input.pods[0].metadata"}},
+		{name: "cel list", language: "cel", line: "input.pods.", want: nil},
+		{name: "jmespath root", language: "jmespath", line: "length(po", want: []string{"length(pods"}},
+		{name: "jmespath", language: "jmespath", line: "pods[*].metadata.labels.", want: []string{`pods[*].metadata.labels."app.kubernetes.io/name"`}},
+		{name: "jsonpath", language: "jsonpath", line: "{.pods[*].metadata.labels.", want: []string{"{.pods[*].metadata.labels['app.kubernetes.io/name']"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newSession(t, nil)
+			if tt.language != "" {
+				_, err := session.Exec(ctx, ":lang "+tt.language)
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, session.Complete(tt.line))
+		})
+	}
+}
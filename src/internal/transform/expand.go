@@ -0,0 +1,168 @@
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// plainKey matches the map keys that can be written in a path without quotes
+var plainKey = regexp.MustCompile(`^[A-Za-z_/-][A-Za-z0-9_/-]*$`)
+
+// pathMatch is a node matched by a path, along with its concrete path
+type pathMatch struct {
+	node *yaml.RNode
+	path string
+}
+
+// hasWildcards checks if the path parts include a wildcard or recursive descent
+func hasWildcards(pathParts []PathPart) bool {
+	for _, part := range pathParts {
+		if part.Type == PartTypeWildcard || part.Type == PartTypeRecursive {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPath resolves a path with wildcards or recursive descent against the node, returning the
+// concrete path of every matched node in document order. Selectors match every matching item,
+// rather than only the first. As with other paths, the last key doesn't need to exist unless it
+// follows a recursive descent.
+func expandPath(node *yaml.RNode, pathParts []PathPart) ([]string, error) {
+	matches := []pathMatch{{node: node}}
+
+	for i, part := range pathParts {
+		last := i == len(pathParts)-1
+		next := make([]pathMatch, 0, len(matches))
+
+		switch part.Type {
+		case PartTypeRecursive:
+			if last {
+				return nil, fmt.Errorf("invalid path, recursive descent must be followed by a key, selector or wildcard")
+			}
+			for _, m := range matches {
+				next = appendDescendants(next, m)
+			}
+
+		case PartTypeWildcard:
+			for _, m := range matches {
+				switch m.node.YNode().Kind {
+				case yaml.SequenceNode:
+					next = appendElements(next, m, func(*yaml.RNode) bool { return true })
+				case yaml.MappingNode:
+					err := m.node.VisitFields(func(field *yaml.MapNode) error {
+						next = append(next, pathMatch{node: field.Value, path: joinKey(m.path, field.Key.YNode().Value)})
+						return nil
+					})
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+		case PartTypeSelector:
+			selectorParts, err := extractSelector(part.Value)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range matches {
+				if m.node.YNode().Kind == yaml.SequenceNode {
+					next = appendElements(next, m, func(element *yaml.RNode) bool {
+						return nodeMatchesAllFilters(element, selectorParts)
+					})
+				}
+			}
+
+		case PartTypeIndex:
+			for _, m := range matches {
+				if m.node.YNode().Kind != yaml.SequenceNode {
+					continue
+				}
+				elements := m.node.YNode().Content
+				idx := len(elements) - 1
+				if part.Value != "-" {
+					var err error
+					idx, err = strconv.Atoi(part.Value)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if idx >= 0 && idx < len(elements) {
+					next = append(next, pathMatch{node: yaml.NewRNode(elements[idx]), path: fmt.Sprintf("%s[%d]", m.path, idx)})
+				}
+			}
+
+		default:
+			if part.Value == "" {
+				next = matches
+				break
+			}
+			// The last key may be added, unless it is matched at any depth
+			optional := last && (i == 0 || pathParts[i-1].Type != PartTypeRecursive)
+			for _, m := range matches {
+				if m.node.YNode().Kind != yaml.MappingNode {
+					continue
+				}
+				field := m.node.Field(part.Value)
+				if field != nil {
+					next = append(next, pathMatch{node: field.Value, path: joinKey(m.path, part.Value)})
+				} else if optional {
+					next = append(next, pathMatch{path: joinKey(m.path, part.Value)})
+				}
+			}
+		}
+
+		if len(next) == 0 {
+			return nil, fmt.Errorf("no nodes match the path")
+		}
+		matches = next
+	}
+
+	paths := make([]string, 0, len(matches))
+	for _, m := range matches {
+		paths = append(paths, m.path)
+	}
+	return paths, nil
+}
+
+// appendDescendants appends the match and all of its descendants, in document order
+func appendDescendants(matches []pathMatch, m pathMatch) []pathMatch {
+	matches = append(matches, m)
+	switch m.node.YNode().Kind {
+	case yaml.SequenceNode:
+		for i, element := range m.node.YNode().Content {
+			matches = appendDescendants(matches, pathMatch{node: yaml.NewRNode(element), path: fmt.Sprintf("%s[%d]", m.path, i)})
+		}
+	case yaml.MappingNode:
+		content := m.node.YNode().Content
+		for i := 0; i+1 < len(content); i += 2 {
+			matches = appendDescendants(matches, pathMatch{node: yaml.NewRNode(content[i+1]), path: joinKey(m.path, content[i].Value)})
+		}
+	}
+	return matches
+}
+
+// appendElements appends the items of the matched list that satisfy the condition
+func appendElements(matches []pathMatch, m pathMatch, condition func(*yaml.RNode) bool) []pathMatch {
+	for i, element := range m.node.YNode().Content {
+		node := yaml.NewRNode(element)
+		if condition(node) {
+			matches = append(matches, pathMatch{node: node, path: fmt.Sprintf("%s[%d]", m.path, i)})
+		}
+	}
+	return matches
+}
+
+// joinKey appends a map key to a concrete path, quoting keys that are not plain
+func joinKey(path, key string) string {
+	if !plainKey.MatchString(key) {
+		return fmt.Sprintf(`%s["%s"]`, path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,100 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// JSONPatchOperation is a single operation of an RFC 6902 JSON Patch
+type JSONPatchOperation struct {
+	Op    string      `json:"op" yaml:"op"`
+	Path  string      `json:"path" yaml:"path"`
+	From  string      `json:"from,omitempty" yaml:"from,omitempty"`
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Validate checks the operation has the fields required by its op
+func (o JSONPatchOperation) Validate() error {
+	switch o.Op {
+	case "add", "replace", "test", "remove":
+	case "move", "copy":
+		if o.From == "" {
+			return fmt.Errorf("json patch %s operation requires from", o.Op)
+		}
+	default:
+		return fmt.Errorf("invalid json patch operation: %s", o.Op)
+	}
+	return nil
+}
+
+// MarshalJSON marshals the operation, keeping a null value for the operations that require one
+func (o JSONPatchOperation) MarshalJSON() ([]byte, error) {
+	op := map[string]interface{}{
+		"op":   o.Op,
+		"path": o.Path,
+	}
+	if o.From != "" {
+		op["from"] = o.From
+	}
+	switch o.Op {
+	case "add", "replace", "test":
+		op["value"] = o.Value
+	}
+	return json.Marshal(op)
+}
+
+// ExecuteJSONPatch applies the operations of an RFC 6902 JSON Patch to the target, in order.
+// The patch fails, leaving the target unchanged, if any operation fails, including a test operation.
+func (t *TransformTarget) ExecuteJSONPatch(operations []JSONPatchOperation) (map[string]interface{}, error) {
+	patchBytes, err := json.Marshal(operations)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling json patch: %v", err)
+	}
+	patch, err := jsonpatch.DecodePatch(patchBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding json patch: %v", err)
+	}
+
+	return t.executePatch(func(doc []byte) ([]byte, error) {
+		return patch.Apply(doc)
+	})
+}
+
+// ExecuteMergePatch applies an RFC 7386 JSON Merge Patch to the target, where null values
+// delete fields and lists are replaced
+func (t *TransformTarget) ExecuteMergePatch(patch map[string]interface{}) (map[string]interface{}, error) {
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling merge patch: %v", err)
+	}
+
+	return t.executePatch(func(doc []byte) ([]byte, error) {
+		return jsonpatch.MergePatch(doc, patchBytes)
+	})
+}
+
+// executePatch applies a patch to the JSON document of the root node and updates the root node
+func (t *TransformTarget) executePatch(apply func([]byte) ([]byte, error)) (map[string]interface{}, error) {
+	doc, err := t.RootNode.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling root node: %v", err)
+	}
+
+	patched, err := apply(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error applying patch: %v", err)
+	}
+
+	node, err := yaml.ConvertJSONToYamlNode(string(patched))
+	if err != nil {
+		return nil, fmt.Errorf("error creating node from patched resources: %v", err)
+	}
+	if node.YNode().Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid patch, root must be a map")
+	}
+
+	return t.UpdateRootNode(node)
+}
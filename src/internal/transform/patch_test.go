@@ -0,0 +1,156 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/internal/transform"
+)
+
+var patchTarget = []byte(`
+pods:
+  - metadata:
+      name: pod-1
+      labels:
+        app: nginx
+  - metadata:
+      name: pod-2
+replicas: 2
+`)
+
+// TestExecuteJSONPatch tests the ExecuteJSONPatch function
+func TestExecuteJSONPatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations []transform.JSONPatchOperation
+		expected   []byte
+		wantErr    bool
+	}{
+		{
+			name: "add, replace and remove",
+			operations: []transform.JSONPatchOperation{
+				{Op: "add", Path: "/pods/1/metadata/labels", Value: map[string]interface{}{"app": "redis"}},
+				{Op: "replace", Path: "/pods/0/metadata/name", Value: "pod-0"},
+				{Op: "remove", Path: "/replicas"},
+			},
+			expected: []byte(`
+pods:
+  - metadata:
+      name: pod-0
+      labels:
+        app: nginx
+  - metadata:
+      name: pod-2
+      labels:
+        app: redis
+`),
+		},
+		{
+			name: "move and copy",
+			operations: []transform.JSONPatchOperation{
+				{Op: "copy", From: "/pods/0/metadata/labels", Path: "/pods/1/metadata/labels"},
+				{Op: "move", From: "/replicas", Path: "/count"},
+			},
+			expected: []byte(`
+pods:
+  - metadata:
+      name: pod-1
+      labels:
+        app: nginx
+  - metadata:
+      name: pod-2
+      labels:
+        app: nginx
+count: 2
+`),
+		},
+		{
+			name: "append to list",
+			operations: []transform.JSONPatchOperation{
+				{Op: "test", Path: "/pods/0/metadata/labels/app", Value: "nginx"},
+				{Op: "add", Path: "/pods/-", Value: map[string]interface{}{"metadata": map[string]interface{}{"name": "pod-3"}}},
+				{Op: "remove", Path: "/pods/0"},
+			},
+			expected: []byte(`
+pods:
+  - metadata:
+      name: pod-2
+  - metadata:
+      name: pod-3
+replicas: 2
+`),
+		},
+		{
+			name: "failing test operation",
+			operations: []transform.JSONPatchOperation{
+				{Op: "replace", Path: "/replicas", Value: 3},
+				{Op: "test", Path: "/pods/0/metadata/name", Value: "pod-2"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing path",
+			operations: []transform.JSONPatchOperation{
+				{Op: "remove", Path: "/pods/0/spec"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "replace root with a list",
+			operations: []transform.JSONPatchOperation{
+				{Op: "replace", Path: "", Value: []interface{}{"a"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := transform.CreateTransformTarget(convertBytesToMap(t, patchTarget))
+			require.NoError(t, err)
+
+			result, err := target.ExecuteJSONPatch(tt.operations)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				// The target is unchanged by a failing patch
+				unchanged, err := target.UpdateRootNode(target.RootNode)
+				require.NoError(t, err)
+				require.Equal(t, convertBytesToMap(t, patchTarget), unchanged)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, convertBytesToMap(t, tt.expected), result)
+		})
+	}
+}
+
+// TestExecuteMergePatch tests the ExecuteMergePatch function
+func TestExecuteMergePatch(t *testing.T) {
+	target, err := transform.CreateTransformTarget(convertBytesToMap(t, patchTarget))
+	require.NoError(t, err)
+
+	result, err := target.ExecuteMergePatch(map[string]interface{}{
+		"pods": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "pod-3"}},
+		},
+		"replicas":  nil,
+		"namespace": "default",
+	})
+	require.NoError(t, err)
+	require.Equal(t, convertBytesToMap(t, []byte(`
+pods:
+  - metadata:
+      name: pod-3
+namespace: default
+`)), result)
+}
+
+// TestJSONPatchOperationValidate tests the Validate function of JSONPatchOperation
+func TestJSONPatchOperationValidate(t *testing.T) {
+	require.NoError(t, transform.JSONPatchOperation{Op: "add", Path: "/a", Value: "b"}.Validate())
+	require.NoError(t, transform.JSONPatchOperation{Op: "move", Path: "/a", From: "/b"}.Validate())
+	require.Error(t, transform.JSONPatchOperation{Op: "copy", Path: "/a"}.Validate())
+	require.Error(t, transform.JSONPatchOperation{Op: "update", Path: "/a"}.Validate())
+}
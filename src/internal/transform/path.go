@@ -13,11 +13,13 @@ import (
 type PartType int
 
 const (
-	PartTypeMap      PartType = iota // e.g., a in a.b
-	PartTypeSequence                 // e.g., a in a[b=c]
-	PartTypeScalar                   // e.g., b in a.b
-	PartTypeSelector                 // e.g., [a=b]
-	PartTypeIndex                    // e.g., [0]
+	PartTypeMap       PartType = iota // e.g., a in a.b
+	PartTypeSequence                  // e.g., a in a[b=c]
+	PartTypeScalar                    // e.g., b in a.b
+	PartTypeSelector                  // e.g., [a=b]
+	PartTypeIndex                     // e.g., [0]
+	PartTypeWildcard                  // e.g., [*], every item of a list or value of a map
+	PartTypeRecursive                 // e.g., .. in a..b, the node and all of its descendants
 )
 
 type PathPart struct {
@@ -30,7 +32,7 @@ func PathToParts(path string) []PathPart {
 	pathSlice := utils.SmarterPathSplitter(normalizePath(path), ".")
 	message.Debug("Path Slice: %v\n", pathSlice) // Helpful for understanding issues with how a path is parsed
 
-	return makePathParts(pathSlice)
+	return makePathParts(pathSlice, strings.HasPrefix(path, ".."))
 }
 
 // Normalize the path to kyaml syntax by inserting any missing "." before "["
@@ -57,11 +59,20 @@ func normalizePath(path string) string {
 	return result
 }
 
-// makePathParts creates the pathParts from the pathSlice
-func makePathParts(pathSlice []string) []PathPart {
+// makePathParts creates the pathParts from the pathSlice, where each ".." in the path is an empty
+// element. A leading empty element is only recursive descent if the path starts with ".."
+func makePathParts(pathSlice []string, leadingRecursive bool) []PathPart {
 	pathParts := make([]PathPart, 0, len(pathSlice))
 
 	for i, p := range pathSlice {
+		if p == "" && (i > 0 || leadingRecursive) {
+			pathParts = append(pathParts, PathPart{Type: PartTypeRecursive})
+			continue
+		}
+		if p == "[*]" {
+			pathParts = append(pathParts, PathPart{Type: PartTypeWildcard, Value: "*"})
+			continue
+		}
 		p = cleanPart(p)
 		currentPartType := getPartType(p)
 
@@ -69,8 +80,11 @@ func makePathParts(pathSlice []string) []PathPart {
 
 		// If the current part is a scalar, look ahead to see if it's a map or sequence
 		if currentPartType == PartTypeScalar && i < len(pathSlice)-1 {
-			nextPart := cleanPart(pathSlice[i+1])
-			pathPart = pathPartFromLookAhead(trimDoubleQuotes(p), getPartType(nextPart))
+			nextType := getPartType(cleanPart(pathSlice[i+1]))
+			if pathSlice[i+1] == "[*]" {
+				nextType = PartTypeWildcard
+			}
+			pathPart = pathPartFromLookAhead(trimDoubleQuotes(p), nextType)
 		} else {
 			// Calculate the pathPart from the current element
 			pathPart = PathPart{Type: currentPartType, Value: trimDoubleQuotes(p)}
@@ -107,7 +121,7 @@ func trimDoubleQuotes(part string) string {
 
 func pathPartFromLookAhead(current string, nextType PartType) PathPart {
 	switch nextType {
-	case PartTypeSelector, PartTypeIndex:
+	case PartTypeSelector, PartTypeIndex, PartTypeWildcard:
 		return PathPart{Type: PartTypeSequence, Value: current}
 	case PartTypeScalar:
 		fallthrough
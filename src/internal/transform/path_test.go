@@ -83,6 +83,34 @@ func TestPathToParts(t *testing.T) {
 				{Type: transform.PartTypeScalar, Value: "j"},
 			},
 		},
+		{
+			name: "wildcard-path",
+			path: `a[*].b`,
+			expected: []transform.PathPart{
+				{Type: transform.PartTypeSequence, Value: "a"},
+				{Type: transform.PartTypeWildcard, Value: "*"},
+				{Type: transform.PartTypeScalar, Value: "b"},
+			},
+		},
+		{
+			name: "recursive-descent-path",
+			path: `a..b[c=d].e`,
+			expected: []transform.PathPart{
+				{Type: transform.PartTypeMap, Value: "a"},
+				{Type: transform.PartTypeRecursive},
+				{Type: transform.PartTypeSequence, Value: "b"},
+				{Type: transform.PartTypeSelector, Value: "c=d"},
+				{Type: transform.PartTypeScalar, Value: "e"},
+			},
+		},
+		{
+			name: "leading-recursive-descent-path",
+			path: `..b`,
+			expected: []transform.PathPart{
+				{Type: transform.PartTypeRecursive},
+				{Type: transform.PartTypeScalar, Value: "b"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
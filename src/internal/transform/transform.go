@@ -2,6 +2,7 @@ package transform
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -14,6 +15,11 @@ const (
 	ChangeTypeAdd    ChangeType = "add"
 	ChangeTypeUpdate ChangeType = "update"
 	ChangeTypeDelete ChangeType = "delete"
+
+	// ChangeTypeJSONPatch applies the operations of an RFC 6902 JSON Patch
+	ChangeTypeJSONPatch ChangeType = "json-patch"
+	// ChangeTypeMergePatch applies an RFC 7386 JSON Merge Patch
+	ChangeTypeMergePatch ChangeType = "merge-patch"
 )
 
 type TransformTarget struct {
@@ -48,9 +54,40 @@ func (t *TransformTarget) UpdateRootNode(node *yaml.RNode) (map[string]interface
 	return nodeMap, nil
 }
 
+// ExecuteTransform applies the change to the node at the path, or to every node matched by a path
+// with wildcards or recursive descent
 func (t *TransformTarget) ExecuteTransform(path string, cType ChangeType, value string, valueMap map[string]interface{}) (map[string]interface{}, error) {
 	rootNodeCopy := t.RootNode.Copy()
 
+	pathParts := PathToParts(path)
+	if !hasWildcards(pathParts) {
+		rootNodeCopy, err := executeTransform(rootNodeCopy, path, cType, value, valueMap)
+		if err != nil {
+			return nil, err
+		}
+		return t.UpdateRootNode(rootNodeCopy)
+	}
+
+	paths, err := expandPath(rootNodeCopy, pathParts)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving path: %v", err)
+	}
+	if cType == ChangeTypeDelete {
+		// Delete the last matches first, so deleting a list item doesn't shift the indexes of the others
+		slices.Reverse(paths)
+	}
+	for _, p := range paths {
+		rootNodeCopy, err = executeTransform(rootNodeCopy, p, cType, value, valueMap)
+		if err != nil {
+			return nil, fmt.Errorf("error transforming %s: %v", p, err)
+		}
+	}
+
+	return t.UpdateRootNode(rootNodeCopy)
+}
+
+// executeTransform applies the change to the node at the path, returning the changed root node
+func executeTransform(rootNodeCopy *yaml.RNode, path string, cType ChangeType, value string, valueMap map[string]interface{}) (*yaml.RNode, error) {
 	pathParts, filters, err := ResolvePathWithFilters(rootNodeCopy, path)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving path: %v", err)
@@ -124,7 +161,7 @@ func (t *TransformTarget) ExecuteTransform(path string, cType ChangeType, value
 		return nil, fmt.Errorf("invalid transform type: %s", cType)
 	}
 
-	return t.UpdateRootNode(rootNodeCopy)
+	return rootNodeCopy, nil
 }
 
 // Add adds the subset to the target at the path, appends to lists
@@ -972,6 +972,154 @@ metadata:
     only-target-field: target-data
   some-list:
     - item1
+`),
+		},
+		{
+			name:       "update-wildcard-path",
+			path:       "pods[*].spec.containers[*].securityContext",
+			changeType: transform.ChangeTypeUpdate,
+			target: []byte(`
+pods:
+  - spec:
+      containers:
+        - name: nginx
+        - name: sidecar
+          securityContext:
+            runAsNonRoot: true
+  - spec:
+      containers:
+        - name: redis
+`),
+			valueByte: []byte(`
+privileged: true
+`),
+			expected: []byte(`
+pods:
+  - spec:
+      containers:
+        - name: nginx
+          securityContext:
+            privileged: true
+        - name: sidecar
+          securityContext:
+            runAsNonRoot: true
+            privileged: true
+  - spec:
+      containers:
+        - name: redis
+          securityContext:
+            privileged: true
+`),
+		},
+		{
+			name:       "update-wildcard-path-value",
+			path:       "pods[*].metadata.labels.app",
+			changeType: transform.ChangeTypeUpdate,
+			value:      "changed",
+			target: []byte(`
+pods:
+  - metadata:
+      labels:
+        app: nginx
+  - metadata:
+      labels: {}
+`),
+			expected: []byte(`
+pods:
+  - metadata:
+      labels:
+        app: changed
+  - metadata:
+      labels:
+        app: changed
+`),
+		},
+		{
+			name:       "update-recursive-descent-path",
+			path:       "..image",
+			changeType: transform.ChangeTypeUpdate,
+			value:      "registry.example.com/nginx",
+			target: []byte(`
+pods:
+  - spec:
+      initContainers:
+        - name: init
+          image: busybox
+      containers:
+        - name: nginx
+          image: nginx
+deployments:
+  - spec:
+      template:
+        spec:
+          containers:
+            - name: nginx
+              image: nginx
+`),
+			expected: []byte(`
+pods:
+  - spec:
+      initContainers:
+        - name: init
+          image: registry.example.com/nginx
+      containers:
+        - name: nginx
+          image: registry.example.com/nginx
+deployments:
+  - spec:
+      template:
+        spec:
+          containers:
+            - name: nginx
+              image: registry.example.com/nginx
+`),
+		},
+		{
+			name:       "delete-recursive-descent-quoted-key",
+			path:       `pods..labels["app.kubernetes.io/name"]`,
+			changeType: transform.ChangeTypeDelete,
+			target: []byte(`
+pods:
+  - metadata:
+      labels:
+        app.kubernetes.io/name: nginx
+        foo: bar
+  - metadata:
+      labels:
+        foo: bar
+`),
+			expected: []byte(`
+pods:
+  - metadata:
+      labels:
+        foo: bar
+  - metadata:
+      labels:
+        foo: bar
+`),
+		},
+		{
+			name:       "delete-wildcard-selector-path",
+			path:       "pods[*].spec.containers[name=sidecar]",
+			changeType: transform.ChangeTypeDelete,
+			target: []byte(`
+pods:
+  - spec:
+      containers:
+        - name: sidecar
+        - name: nginx
+        - name: sidecar
+  - spec:
+      containers:
+        - name: sidecar
+`),
+			expected: []byte(`
+pods:
+  - spec:
+      containers:
+        - name: nginx
+  - spec:
+      containers: []
 `),
 		},
 	}
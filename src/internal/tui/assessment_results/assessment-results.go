@@ -105,7 +105,9 @@ func GetResults(assessmentResults *oscalTypes.AssessmentResults) []result {
 						} else if e.Description == "Result: not-satisfied\n" {
 							state = "not-satisfied"
 						}
-						if e.Remarks != "" {
+						if strings.HasPrefix(e.Description, "Violation: ") {
+							remarks.WriteString(fmt.Sprintf("- %s ", e.Remarks))
+						} else if e.Remarks != "" {
 							remarks.WriteString(strings.ReplaceAll(e.Remarks, "\n", " "))
 						}
 					}
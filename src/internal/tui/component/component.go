@@ -182,29 +182,13 @@ func getValidationText(validationLink validationLink) string {
 
 	if validation.Domain != nil {
 		text.WriteString(fmt.Sprintf("Domain: %s\n", important.Render(validation.Domain.Type)))
-		switch validation.Domain.Type {
-		case "kubernetes":
-			kubeSpec, err := common.ToYamlString(validation.Domain.KubernetesSpec)
-			if err != nil {
-				common.PrintToLog("error converting kubeSpec to yaml: %v", err)
-				kubeSpec = ""
-			}
-			text.WriteString(kubeSpec)
-		case "api":
-			apiSpec, err := common.ToYamlString(validation.Domain.ApiSpec)
-			if err != nil {
-				common.PrintToLog("error converting apiSpec to yaml: %v", err)
-				apiSpec = ""
-			}
-			text.WriteString(apiSpec)
-		case "file":
-			fileSpec, err := common.ToYamlString(validation.Domain.FileSpec)
-			if err != nil {
-				common.PrintToLog("error converting fileSpec to yaml: %v", err)
-				fileSpec = ""
-			}
-			text.WriteString(fileSpec)
-		}
+		text.WriteString(domainSpecText(validation.Domain))
+		text.WriteString("\n\n")
+	}
+
+	for _, domain := range validation.Domains {
+		text.WriteString(fmt.Sprintf("Domain: %s (%s)\n", important.Render(domain.Name), domain.Type))
+		text.WriteString(domainSpecText(&domain.Domain))
 		text.WriteString("\n\n")
 	}
 
@@ -225,8 +209,98 @@ func getValidationText(validationLink validationLink) string {
 				kyvernoSpec = ""
 			}
 			text.WriteString(kyvernoSpec)
+		case "expression":
+			expressionSpec, err := common.ToYamlString(validation.Provider.ExpressionSpec)
+			if err != nil {
+				common.PrintToLog("error converting expressionSpec to yaml: %v", err)
+				expressionSpec = ""
+			}
+			text.WriteString(expressionSpec)
+		case "cue":
+			cueSpec, err := common.ToYamlString(validation.Provider.CueSpec)
+			if err != nil {
+				common.PrintToLog("error converting cueSpec to yaml: %v", err)
+				cueSpec = ""
+			}
+			text.WriteString(cueSpec)
 		}
 	}
 
 	return text.String()
 }
+
+func domainSpecText(domain *pkgcommon.Domain) string {
+	switch domain.Type {
+	case "kubernetes":
+		kubeSpec, err := common.ToYamlString(domain.KubernetesSpec)
+		if err != nil {
+			common.PrintToLog("error converting kubeSpec to yaml: %v", err)
+			kubeSpec = ""
+		}
+		return kubeSpec
+	case "api":
+		apiSpec, err := common.ToYamlString(domain.ApiSpec)
+		if err != nil {
+			common.PrintToLog("error converting apiSpec to yaml: %v", err)
+			apiSpec = ""
+		}
+		return apiSpec
+	case "file":
+		fileSpec, err := common.ToYamlString(domain.FileSpec)
+		if err != nil {
+			common.PrintToLog("error converting fileSpec to yaml: %v", err)
+			fileSpec = ""
+		}
+		return fileSpec
+	case "vuln-scan":
+		vulnScanSpec, err := common.ToYamlString(domain.VulnScanSpec)
+		if err != nil {
+			common.PrintToLog("error converting vulnScanSpec to yaml: %v", err)
+			vulnScanSpec = ""
+		}
+		return vulnScanSpec
+	case "host":
+		hostSpec, err := common.ToYamlString(domain.HostSpec)
+		if err != nil {
+			common.PrintToLog("error converting hostSpec to yaml: %v", err)
+			hostSpec = ""
+		}
+		return hostSpec
+	case "terraform":
+		terraformSpec, err := common.ToYamlString(domain.TerraformSpec)
+		if err != nil {
+			common.PrintToLog("error converting terraformSpec to yaml: %v", err)
+			terraformSpec = ""
+		}
+		return terraformSpec
+	case "render":
+		renderSpec, err := common.ToYamlString(domain.RenderSpec)
+		if err != nil {
+			common.PrintToLog("error converting renderSpec to yaml: %v", err)
+			renderSpec = ""
+		}
+		return renderSpec
+	case "tls":
+		tlsSpec, err := common.ToYamlString(domain.TLSSpec)
+		if err != nil {
+			common.PrintToLog("error converting tlsSpec to yaml: %v", err)
+			tlsSpec = ""
+		}
+		return tlsSpec
+	case "sql":
+		sqlSpec, err := common.ToYamlString(domain.SQLSpec)
+		if err != nil {
+			common.PrintToLog("error converting sqlSpec to yaml: %v", err)
+			sqlSpec = ""
+		}
+		return sqlSpec
+	case "metrics":
+		metricsSpec, err := common.ToYamlString(domain.MetricsSpec)
+		if err != nil {
+			common.PrintToLog("error converting metricsSpec to yaml: %v", err)
+			metricsSpec = ""
+		}
+		return metricsSpec
+	}
+	return ""
+}
@@ -18,8 +18,17 @@ import (
 	"github.com/defenseunicorns/lula/src/pkg/common/network"
 	"github.com/defenseunicorns/lula/src/pkg/domains/api"
 	"github.com/defenseunicorns/lula/src/pkg/domains/files"
+	"github.com/defenseunicorns/lula/src/pkg/domains/host"
 	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+	"github.com/defenseunicorns/lula/src/pkg/domains/metrics"
+	"github.com/defenseunicorns/lula/src/pkg/domains/render"
+	"github.com/defenseunicorns/lula/src/pkg/domains/sql"
+	"github.com/defenseunicorns/lula/src/pkg/domains/terraform"
+	"github.com/defenseunicorns/lula/src/pkg/domains/tls"
+	"github.com/defenseunicorns/lula/src/pkg/domains/vulnscan"
 	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/pkg/providers/cue"
+	"github.com/defenseunicorns/lula/src/pkg/providers/expression"
 	"github.com/defenseunicorns/lula/src/pkg/providers/kyverno"
 	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
 	"github.com/defenseunicorns/lula/src/types"
@@ -155,6 +164,20 @@ func GetDomain(domain *Domain) (types.Domain, error) {
 		return api.CreateApiDomain(domain.ApiSpec)
 	case "file":
 		return files.CreateDomain(domain.FileSpec)
+	case "vuln-scan":
+		return vulnscan.CreateDomain(domain.VulnScanSpec)
+	case "host":
+		return host.CreateDomain(domain.HostSpec)
+	case "terraform":
+		return terraform.CreateDomain(domain.TerraformSpec)
+	case "render":
+		return render.CreateDomain(domain.RenderSpec)
+	case "tls":
+		return tls.CreateDomain(domain.TLSSpec)
+	case "sql":
+		return sql.CreateDomain(domain.SQLSpec)
+	case "metrics":
+		return metrics.CreateDomain(domain.MetricsSpec)
 	default:
 		return nil, fmt.Errorf("domain is unsupported")
 	}
@@ -169,6 +192,10 @@ func GetProvider(provider *Provider, ctx context.Context) (types.Provider, error
 		return opa.CreateOpaProvider(ctx, provider.OpaSpec)
 	case "kyverno":
 		return kyverno.CreateKyvernoProvider(ctx, provider.KyvernoSpec)
+	case "expression":
+		return expression.CreateExpressionProvider(ctx, provider.ExpressionSpec)
+	case "cue":
+		return cue.CreateCueProvider(ctx, provider.CueSpec)
 	default:
 		return nil, fmt.Errorf("provider is unsupported")
 	}
@@ -254,3 +281,39 @@ func isUUIDReference(path string) bool {
 	path = strings.TrimPrefix(path, UUID_PREFIX)
 	return checkValidUuid(path)
 }
+
+// Filter selects items by their values, e.g. validations by their tags or requirements by their
+// control IDs. An item is selected if none of its values are excluded and, if any values are
+// included, at least one of its values is included. Values are compared case-insensitively.
+type Filter struct {
+	Include []string
+	Exclude []string
+}
+
+// IsEmpty reports whether the filter selects every item
+func (f Filter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Matches reports whether an item with the values is selected by the filter
+func (f Filter) Matches(values ...string) bool {
+	contains := func(list []string, value string) bool {
+		for _, item := range list {
+			if strings.EqualFold(item, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	included := len(f.Include) == 0
+	for _, value := range values {
+		if contains(f.Exclude, value) {
+			return false
+		}
+		if contains(f.Include, value) {
+			included = true
+		}
+	}
+	return included
+}
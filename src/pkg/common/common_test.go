@@ -13,9 +13,20 @@ import (
 
 	"github.com/defenseunicorns/lula/src/pkg/common"
 	"github.com/defenseunicorns/lula/src/pkg/domains/api"
+	"github.com/defenseunicorns/lula/src/pkg/domains/files"
+	"github.com/defenseunicorns/lula/src/pkg/domains/host"
 	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+	"github.com/defenseunicorns/lula/src/pkg/domains/metrics"
+	"github.com/defenseunicorns/lula/src/pkg/domains/render"
+	"github.com/defenseunicorns/lula/src/pkg/domains/sql"
+	"github.com/defenseunicorns/lula/src/pkg/domains/terraform"
+	"github.com/defenseunicorns/lula/src/pkg/domains/tls"
+	"github.com/defenseunicorns/lula/src/pkg/domains/vulnscan"
+	"github.com/defenseunicorns/lula/src/pkg/providers/cue"
+	"github.com/defenseunicorns/lula/src/pkg/providers/expression"
 	"github.com/defenseunicorns/lula/src/pkg/providers/kyverno"
 	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
 )
 
 const multiValidationPath = "../../test/e2e/scenarios/remote-validations/multi-validations.yaml"
@@ -109,6 +120,170 @@ func TestGetDomain(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "valid vuln-scan domain",
+			domain: common.Domain{
+				Type: "vuln-scan",
+				VulnScanSpec: &vulnscan.Spec{
+					Reports: []vulnscan.Report{
+						{
+							Name: "grype",
+							Path: "grype.json",
+						},
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "vulnscan.Domain",
+		},
+		{
+			name: "invalid vuln-scan domain",
+			domain: common.Domain{
+				Type:         "vuln-scan",
+				VulnScanSpec: &vulnscan.Spec{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid host domain",
+			domain: common.Domain{
+				Type: "host",
+				HostSpec: &host.Spec{
+					Collectors: []host.Collector{host.CollectorSysctl},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "host.Domain",
+		},
+		{
+			name: "invalid host domain",
+			domain: common.Domain{
+				Type: "host",
+				HostSpec: &host.Spec{
+					Collectors: []host.Collector{"kernel-modules"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid terraform domain",
+			domain: common.Domain{
+				Type: "terraform",
+				TerraformSpec: &terraform.Spec{
+					Files: []terraform.File{
+						{
+							Name: "plan",
+							Path: "plan.json",
+						},
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "terraform.Domain",
+		},
+		{
+			name: "invalid terraform domain",
+			domain: common.Domain{
+				Type:          "terraform",
+				TerraformSpec: &terraform.Spec{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid render domain",
+			domain: common.Domain{
+				Type: "render",
+				RenderSpec: &render.Spec{
+					Kustomize: &render.KustomizeSpec{
+						Path: "overlays/prod",
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "render.Domain",
+		},
+		{
+			name: "invalid render domain",
+			domain: common.Domain{
+				Type:       "render",
+				RenderSpec: &render.Spec{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid tls domain",
+			domain: common.Domain{
+				Type: "tls",
+				TLSSpec: &tls.Spec{
+					Targets: []tls.Target{
+						{
+							Name:    "web",
+							Address: "example.com:443",
+						},
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "tls.Domain",
+		},
+		{
+			name: "invalid tls domain",
+			domain: common.Domain{
+				Type:    "tls",
+				TLSSpec: &tls.Spec{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid sql domain",
+			domain: common.Domain{
+				Type: "sql",
+				SQLSpec: &sql.Spec{
+					Driver: sql.DriverSQLite,
+					DSN:    "app.db",
+					Queries: []sql.Query{
+						{
+							Name:  "users",
+							Query: "SELECT name FROM users",
+						},
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "sql.Domain",
+		},
+		{
+			name: "invalid sql domain",
+			domain: common.Domain{
+				Type:    "sql",
+				SQLSpec: &sql.Spec{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid metrics domain",
+			domain: common.Domain{
+				Type: "metrics",
+				MetricsSpec: &metrics.Spec{
+					Scrapes: []metrics.Scrape{
+						{
+							Name: "apiserver",
+							URL:  "https://localhost:6443/metrics",
+						},
+					},
+				},
+			},
+			expectedErr:    false,
+			expectedDomain: "metrics.Domain",
+		},
+		{
+			name: "invalid metrics domain",
+			domain: common.Domain{
+				Type:        "metrics",
+				MetricsSpec: &metrics.Spec{},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "invalid type domain",
 			domain: common.Domain{
@@ -134,6 +309,34 @@ func TestGetDomain(t *testing.T) {
 				if _, ok := result.(api.ApiDomain); !ok {
 					t.Errorf("Expected result to be api.ApiDomain, got %T", result)
 				}
+			case "vulnscan.Domain":
+				if _, ok := result.(vulnscan.Domain); !ok {
+					t.Errorf("Expected result to be vulnscan.Domain, got %T", result)
+				}
+			case "host.Domain":
+				if _, ok := result.(host.Domain); !ok {
+					t.Errorf("Expected result to be host.Domain, got %T", result)
+				}
+			case "terraform.Domain":
+				if _, ok := result.(terraform.Domain); !ok {
+					t.Errorf("Expected result to be terraform.Domain, got %T", result)
+				}
+			case "render.Domain":
+				if _, ok := result.(render.Domain); !ok {
+					t.Errorf("Expected result to be render.Domain, got %T", result)
+				}
+			case "tls.Domain":
+				if _, ok := result.(tls.Domain); !ok {
+					t.Errorf("Expected result to be tls.Domain, got %T", result)
+				}
+			case "sql.Domain":
+				if _, ok := result.(sql.Domain); !ok {
+					t.Errorf("Expected result to be sql.Domain, got %T", result)
+				}
+			case "metrics.Domain":
+				if _, ok := result.(metrics.Domain); !ok {
+					t.Errorf("Expected result to be metrics.Domain, got %T", result)
+				}
 			case "nil":
 				if result != nil {
 					t.Errorf("Expected result to be nil, got %T", result)
@@ -143,6 +346,64 @@ func TestGetDomain(t *testing.T) {
 	}
 }
 
+func TestGetDomains(t *testing.T) {
+	files := func(path string) common.Domain {
+		return common.Domain{
+			Type:     "file",
+			FileSpec: &files.Spec{Filepaths: []files.FileInfo{{Name: "data", Path: path}}},
+		}
+	}
+	apiDomain := func(executable bool) common.Domain {
+		return common.Domain{
+			Type:    "api",
+			ApiSpec: &api.ApiSpec{Requests: []api.Request{{Name: "healthz", URL: "http://localhost/healthz", Executable: executable}}},
+		}
+	}
+
+	t.Run("invalid domains", func(t *testing.T) {
+		_, err := common.GetDomains(nil)
+		require.Error(t, err)
+		_, err = common.GetDomains([]common.NamedDomain{{Domain: files("bar.json")}})
+		require.Error(t, err)
+		_, err = common.GetDomains([]common.NamedDomain{{Name: "a", Domain: files("bar.json")}, {Name: "a", Domain: files("foo.yaml")}})
+		require.Error(t, err)
+		_, err = common.GetDomains([]common.NamedDomain{{Name: "a", Domain: common.Domain{Type: "foo"}}})
+		require.ErrorContains(t, err, "domain a")
+	})
+
+	t.Run("resources are namespaced by name", func(t *testing.T) {
+		domain, err := common.GetDomains([]common.NamedDomain{
+			{Name: "json", Domain: files("bar.json")},
+			{Name: "yaml", Domain: files("foo.yaml")},
+			{Name: "missing", Domain: files("missing.json")},
+		})
+		require.NoError(t, err)
+		require.False(t, domain.IsExecutable())
+
+		ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "../domains/files/testdata")
+		resources, err := domain.GetResources(ctx)
+		require.ErrorContains(t, err, "domain missing")
+		require.Equal(t, types.DomainResources{
+			"json":    map[string]interface{}{"data": map[string]interface{}{"cat": "Cheetarah"}},
+			"yaml":    map[string]interface{}{"data": "cat = Li Shou"},
+			"missing": map[string]interface{}{"data": map[string]interface{}{}},
+		}, resources)
+	})
+
+	t.Run("executable if any domain is executable", func(t *testing.T) {
+		domain, err := common.GetDomains([]common.NamedDomain{
+			{Name: "baseline", Domain: files("bar.json")},
+			{Name: "api", Domain: apiDomain(true)},
+		})
+		require.NoError(t, err)
+		require.True(t, domain.IsExecutable())
+
+		domain, err = common.GetDomains([]common.NamedDomain{{Name: "api", Domain: apiDomain(false)}})
+		require.NoError(t, err)
+		require.False(t, domain.IsExecutable())
+	})
+}
+
 func TestGetProvider(t *testing.T) {
 	t.Parallel()
 
@@ -192,6 +453,47 @@ func TestGetProvider(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "valid expression provider",
+			provider: common.Provider{
+				Type: "expression",
+				ExpressionSpec: &expression.ExpressionSpec{
+					Expressions: []expression.Expression{
+						{Name: "pods", JQ: ".pods | length", Operator: expression.OperatorCountGTE, Value: 1},
+					},
+				},
+			},
+			expectedErr:      false,
+			expectedProvider: "expression.ExpressionProvider",
+		},
+		{
+			name: "invalid expression provider",
+			provider: common.Provider{
+				Type:           "expression",
+				ExpressionSpec: &expression.ExpressionSpec{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid cue provider",
+			provider: common.Provider{
+				Type: "cue",
+				CueSpec: &cue.CueSpec{
+					Cue:  "#Resources: pods: [...{metadata: name: string}]",
+					Path: "#Resources",
+				},
+			},
+			expectedErr:      false,
+			expectedProvider: "cue.CueProvider",
+		},
+		{
+			name: "invalid cue provider",
+			provider: common.Provider{
+				Type:    "cue",
+				CueSpec: &cue.CueSpec{},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "invalid type provider",
 			provider: common.Provider{
@@ -219,6 +521,14 @@ func TestGetProvider(t *testing.T) {
 				if _, ok := result.(kyverno.KyvernoProvider); !ok {
 					t.Errorf("Expected result to be kyverno.KyvernoProvider, got %T", result)
 				}
+			case "expression.ExpressionProvider":
+				if _, ok := result.(expression.ExpressionProvider); !ok {
+					t.Errorf("Expected result to be expression.ExpressionProvider, got %T", result)
+				}
+			case "cue.CueProvider":
+				if _, ok := result.(cue.CueProvider); !ok {
+					t.Errorf("Expected result to be cue.CueProvider, got %T", result)
+				}
 			case "nil":
 				if result != nil {
 					t.Errorf("Expected result to be nil, got %T", result)
@@ -696,3 +1006,29 @@ func FuzzRemapPath(f *testing.F) {
 		}
 	})
 }
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter common.Filter
+		values []string
+		want   bool
+	}{
+		{name: "empty filter", filter: common.Filter{}, values: []string{"slow"}, want: true},
+		{name: "empty filter without values", filter: common.Filter{}, want: true},
+		{name: "included", filter: common.Filter{Include: []string{"cluster"}}, values: []string{"slow", "Cluster"}, want: true},
+		{name: "not included", filter: common.Filter{Include: []string{"cluster"}}, values: []string{"slow"}, want: false},
+		{name: "no values with include", filter: common.Filter{Include: []string{"cluster"}}, want: false},
+		{name: "excluded", filter: common.Filter{Exclude: []string{"slow"}}, values: []string{"cluster", "slow"}, want: false},
+		{name: "exclude takes precedence", filter: common.Filter{Include: []string{"cluster"}, Exclude: []string{"slow"}}, values: []string{"cluster", "slow"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Matches(tt.values...))
+		})
+	}
+
+	require.True(t, common.Filter{}.IsEmpty())
+	require.False(t, common.Filter{Exclude: []string{"slow"}}.IsEmpty())
+}
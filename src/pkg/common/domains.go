@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// MultiDomain combines several named domains into a single domain, so that one provider
+// evaluation can correlate resources across them
+type MultiDomain struct {
+	names   []string
+	domains []types.Domain
+}
+
+// GetDomains creates each named domain and returns them combined as a MultiDomain
+func GetDomains(domains []NamedDomain) (types.Domain, error) {
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("domains is empty")
+	}
+
+	multi := MultiDomain{
+		names:   make([]string, 0, len(domains)),
+		domains: make([]types.Domain, 0, len(domains)),
+	}
+	seen := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		if d.Name == "" {
+			return nil, fmt.Errorf("domain name cannot be empty")
+		}
+		if seen[d.Name] {
+			return nil, fmt.Errorf("domain name %s must be unique", d.Name)
+		}
+		seen[d.Name] = true
+
+		domain, err := GetDomain(&d.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("domain %s: %w", d.Name, err)
+		}
+		multi.names = append(multi.names, d.Name)
+		multi.domains = append(multi.domains, domain)
+	}
+
+	return multi, nil
+}
+
+// GetResources collects the resources of every domain, namespaced by domain name. All domains are
+// queried even if one fails, and the errors are returned together.
+func (m MultiDomain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	var errs error
+	drs := make(types.DomainResources, len(m.domains))
+	for i, domain := range m.domains {
+		resources, err := domain.GetResources(ctx)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("domain %s: %w", m.names[i], err))
+		}
+		if resources == nil {
+			// Assign empty data value for reporting purposes
+			resources = types.DomainResources{}
+		}
+		drs[m.names[i]] = map[string]interface{}(resources)
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns true if any of the domains are executable
+func (m MultiDomain) IsExecutable() bool {
+	for _, domain := range m.domains {
+		if domain.IsExecutable() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,442 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// Severity is the severity of a finding
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// ParseSeverity returns the severity with the given name
+func ParseSeverity(s string) (Severity, error) {
+	switch severity := Severity(strings.ToLower(s)); severity {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("unsupported severity %s, must be one of error, warning or info", s)
+	}
+}
+
+// AtLeast reports whether the severity is at least as severe as other
+func (s Severity) AtLeast(other Severity) bool {
+	return s.level() >= other.level()
+}
+
+func (s Severity) level() int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Finding is an issue with a validation reported by a lint rule
+type Finding struct {
+	RuleID   string   `json:"rule-id" yaml:"rule-id"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Message  string   `json:"message" yaml:"message"`
+	// Field is the path of the field of the validation the finding is about, if any
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+	// Validation is the name of the validation
+	Validation string `json:"validation,omitempty" yaml:"validation,omitempty"`
+	// DocumentPath is the path of the file of the validation
+	DocumentPath string `json:"document-path,omitempty" yaml:"document-path,omitempty"`
+}
+
+func (f Finding) String() string {
+	if f.Field == "" {
+		return fmt.Sprintf("%s [%s] %s", f.Severity, f.RuleID, f.Message)
+	}
+	return fmt.Sprintf("%s [%s] %s: %s", f.Severity, f.RuleID, f.Field, f.Message)
+}
+
+// Rule is a semantic check of a validation
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	check       func(l *linter) []Finding
+}
+
+// Rules are the rules run by Lint, in order
+var Rules = []Rule{
+	{
+		ID:          "rego-compile",
+		Severity:    SeverityError,
+		Description: "The rego of the OPA provider, with its modules and bundles, fails to compile",
+		check:       checkRegoCompile,
+	},
+	{
+		ID:          "output-validation-undefined",
+		Severity:    SeverityError,
+		Description: "The output validation path of the OPA provider is not defined by any rule of the policy",
+		check:       checkOutputValidation,
+	},
+	{
+		ID:          "observation-undefined",
+		Severity:    SeverityWarning,
+		Description: "An output observation path of the OPA provider is not defined by any rule of the policy",
+		check:       checkObservations,
+	},
+	{
+		ID:          "kubernetes-unknown-resource",
+		Severity:    SeverityWarning,
+		Description: "A Kubernetes resource rule references a version or resource unknown to its built-in API group",
+		check:       checkKubernetesResources,
+	},
+	{
+		ID:          "unused-module",
+		Severity:    SeverityWarning,
+		Description: "A module of the OPA provider is not referenced by the policy",
+		check:       checkUnusedModules,
+	},
+	{
+		ID:          "api-request-no-timeout",
+		Severity:    SeverityInfo,
+		Description: "An API request has no timeout, and uses the default of 30s",
+		check:       checkApiTimeouts,
+	},
+	{
+		ID:          "missing-uuid",
+		Severity:    SeverityWarning,
+		Description: "The metadata of the validation has no UUID, so a new one is generated each time it is run",
+		check:       checkUUID,
+	},
+	{
+		ID:          "tests-missing-failing-case",
+		Severity:    SeverityWarning,
+		Description: "The validation has tests, but none of them expects the result to be not-satisfied",
+		check:       checkFailingTests,
+	},
+}
+
+// linter holds the state shared by the rules linting a validation
+type linter struct {
+	ctx        context.Context
+	validation *common.Validation
+	// compiler is the compiled policy of an OPA provider, nil if it failed to compile
+	compiler   *ast.Compiler
+	compileErr error
+}
+
+// Lint runs the rules against the validation and returns their findings. Files referenced by the
+// validation, e.g. OPA modules and test suites, are read relative to the working directory in the
+// context.
+func Lint(ctx context.Context, validation *common.Validation) []Finding {
+	l := &linter{
+		ctx:        ctx,
+		validation: validation,
+	}
+	if spec := l.opaSpec(); spec != nil {
+		l.compiler, l.compileErr = opa.Compile(ctx, spec)
+	}
+
+	var findings []Finding
+	for _, rule := range Rules {
+		for _, finding := range rule.check(l) {
+			finding.RuleID = rule.ID
+			finding.Severity = rule.Severity
+			if validation.Metadata != nil {
+				finding.Validation = validation.Metadata.Name
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// opaSpec returns the spec of the OPA provider of the validation, if any
+func (l *linter) opaSpec() *opa.OpaSpec {
+	if l.validation.Provider == nil {
+		return nil
+	}
+	return l.validation.Provider.OpaSpec
+}
+
+// definesPath reports whether any rule of the policy defines the document at the path, given
+// without the data prefix
+func (l *linter) definesPath(path string) bool {
+	ref, err := ast.ParseRef("data." + path)
+	if err != nil {
+		return false
+	}
+	return len(l.compiler.GetRules(ref)) > 0
+}
+
+// domains returns the domains of the validation with the path of their fields
+func (l *linter) domains() map[string]*common.Domain {
+	domains := make(map[string]*common.Domain)
+	if l.validation.Domain != nil {
+		domains["domain"] = l.validation.Domain
+	}
+	for i := range l.validation.Domains {
+		domains[fmt.Sprintf("domains[%d]", i)] = &l.validation.Domains[i].Domain
+	}
+	return domains
+}
+
+func checkRegoCompile(l *linter) []Finding {
+	if l.compileErr == nil {
+		return nil
+	}
+	var astErrs ast.Errors
+	if !errors.As(l.compileErr, &astErrs) {
+		return []Finding{{Message: l.compileErr.Error(), Field: "provider.opa-spec"}}
+	}
+	findings := make([]Finding, 0, len(astErrs))
+	for _, err := range astErrs {
+		findings = append(findings, Finding{Message: err.Error(), Field: "provider.opa-spec.rego"})
+	}
+	return findings
+}
+
+func checkOutputValidation(l *linter) []Finding {
+	// Bundles may provide the path as a data document rather than a rule
+	spec := l.opaSpec()
+	if l.compiler == nil || len(spec.Bundles) > 0 {
+		return nil
+	}
+	path := "validate.validate"
+	if spec.Output != nil && spec.Output.Validation != "" {
+		path = spec.Output.Validation
+	}
+	if l.definesPath(path) {
+		return nil
+	}
+	return []Finding{{
+		Message: fmt.Sprintf("validation path %s is not defined by the policy, so the validation is never satisfied", path),
+		Field:   "provider.opa-spec.output.validation",
+	}}
+}
+
+func checkObservations(l *linter) []Finding {
+	spec := l.opaSpec()
+	if l.compiler == nil || len(spec.Bundles) > 0 || spec.Output == nil {
+		return nil
+	}
+	var findings []Finding
+	for i, observation := range spec.Output.Observations {
+		if !l.definesPath(observation) {
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("observation path %s is not defined by the policy", observation),
+				Field:   fmt.Sprintf("provider.opa-spec.output.observations[%d]", i),
+			})
+		}
+	}
+	return findings
+}
+
+// builtinResources are the resources of the versions of each API group built into Kubernetes,
+// derived from the kinds of the client scheme as kubectl guesses them without API discovery
+var builtinResources = func() map[string]map[string]map[string]bool {
+	groups := make(map[string]map[string]map[string]bool)
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version == "__internal" {
+			continue
+		}
+		if groups[gvk.Group] == nil {
+			groups[gvk.Group] = make(map[string]map[string]bool)
+		}
+		if groups[gvk.Group][gvk.Version] == nil {
+			groups[gvk.Group][gvk.Version] = make(map[string]bool)
+		}
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		groups[gvk.Group][gvk.Version][plural.Resource] = true
+		groups[gvk.Group][gvk.Version][singular.Resource] = true
+	}
+	return groups
+}()
+
+func checkKubernetesResources(l *linter) []Finding {
+	var findings []Finding
+	domains := l.domains()
+	for _, field := range sortedKeys(domains) {
+		domain := domains[field]
+		if domain.KubernetesSpec == nil {
+			continue
+		}
+		for i, resource := range domain.KubernetesSpec.Resources {
+			rule := resource.ResourceRule
+			// Resources of other groups, e.g. custom resources, can't be known without API discovery
+			if rule == nil || builtinResources[rule.Group] == nil {
+				continue
+			}
+			gv := schema.GroupVersion{Group: rule.Group, Version: rule.Version}
+			ruleField := fmt.Sprintf("%s.kubernetes-spec.resources[%d].resource-rule", field, i)
+			versions := builtinResources[rule.Group]
+			if versions[rule.Version] == nil {
+				findings = append(findings, Finding{
+					Message: fmt.Sprintf("version %s is unknown to API group %q", gv, rule.Group),
+					Field:   ruleField,
+				})
+				continue
+			}
+			// Subresources are checked by their resource
+			name := strings.Split(rule.Resource, "/")[0]
+			if !versions[rule.Version][name] {
+				findings = append(findings, Finding{
+					Message: fmt.Sprintf("resource %s is unknown to %s", rule.Resource, gv),
+					Field:   ruleField,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func checkUnusedModules(l *linter) []Finding {
+	spec := l.opaSpec()
+	if l.compiler == nil || len(spec.Modules) == 0 {
+		return nil
+	}
+
+	// The references to data by each module, and by the output of the provider
+	outputs := []string{"validate.validate"}
+	if spec.Output != nil {
+		if spec.Output.Validation != "" {
+			outputs[0] = spec.Output.Validation
+		}
+		outputs = append(outputs, spec.Output.Violations)
+		outputs = append(outputs, spec.Output.Observations...)
+	}
+	var outputRefs []ast.Ref
+	for _, output := range outputs {
+		if ref, err := ast.ParseRef("data." + output); output != "" && err == nil {
+			outputRefs = append(outputRefs, ref)
+		}
+	}
+	refs := make(map[string][]ast.Ref, len(l.compiler.Modules))
+	for name, module := range l.compiler.Modules {
+		ast.WalkRefs(module, func(ref ast.Ref) bool {
+			if ref.HasPrefix(ast.DefaultRootRef) {
+				refs[name] = append(refs[name], ref)
+			}
+			return false
+		})
+	}
+
+	var findings []Finding
+	for _, name := range sortedKeys(spec.Modules) {
+		module, ok := l.compiler.Modules[name]
+		if !ok {
+			continue
+		}
+		references := outputRefs
+		for other, otherRefs := range refs {
+			if other != name {
+				references = append(references, otherRefs...)
+			}
+		}
+		used := false
+		for _, rule := range module.Rules {
+			path := rule.Path()
+			for _, ref := range references {
+				if ref.HasPrefix(path) || path.HasPrefix(ref) {
+					used = true
+					break
+				}
+			}
+			if used {
+				break
+			}
+		}
+		if !used {
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("module %s (%s) is not referenced by the policy", name, module.Package.Path),
+				Field:   fmt.Sprintf("provider.opa-spec.modules.%s", name),
+			})
+		}
+	}
+	return findings
+}
+
+func checkApiTimeouts(l *linter) []Finding {
+	var findings []Finding
+	domains := l.domains()
+	for _, field := range sortedKeys(domains) {
+		spec := domains[field].ApiSpec
+		if spec == nil || (spec.Options != nil && spec.Options.Timeout != "") {
+			continue
+		}
+		for i, request := range spec.Requests {
+			if request.Options == nil || request.Options.Timeout == "" {
+				findings = append(findings, Finding{
+					Message: fmt.Sprintf("request %s has no timeout", request.Name),
+					Field:   fmt.Sprintf("%s.api-spec.requests[%d]", field, i),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func checkUUID(l *linter) []Finding {
+	if l.validation.Metadata != nil && l.validation.Metadata.UUID != "" {
+		return nil
+	}
+	return []Finding{{
+		Message: "metadata has no uuid",
+		Field:   "metadata.uuid",
+	}}
+}
+
+func checkFailingTests(l *linter) []Finding {
+	var tests []types.LulaValidationTest
+	if l.validation.Tests != nil {
+		tests = append(tests, *l.validation.Tests...)
+	}
+	for _, suite := range l.validation.TestSuites {
+		suiteTests, err := types.LoadTestSuite(l.ctx, suite)
+		if err != nil {
+			// Tests that can't be read are reported when they run
+			message.Debugf("skipping test suite %s: %v", suite, err)
+			continue
+		}
+		tests = append(tests, suiteTests...)
+	}
+	if len(tests) == 0 {
+		return nil
+	}
+	for _, test := range tests {
+		if test.ExpectedResult == "not-satisfied" {
+			return nil
+		}
+	}
+	return []Finding{{
+		Message: fmt.Sprintf("none of the %d tests expects the result to be not-satisfied", len(tests)),
+		Field:   "tests",
+	}}
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
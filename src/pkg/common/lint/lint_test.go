@@ -0,0 +1,237 @@
+package lint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/pkg/common"
+	"github.com/defenseunicorns/lula/src/pkg/common/lint"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestLint(t *testing.T) {
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+
+	tests := []struct {
+		name       string
+		validation string
+		want       []lint.Finding
+	}{
+		{
+			name: "no findings",
+			validation: `
+metadata:
+  name: valid
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+domain:
+  type: kubernetes
+  kubernetes-spec:
+    resources:
+      - name: pods
+        resource-rule:
+          version: v1
+          resource: pods
+      - name: deployments
+        resource-rule:
+          group: apps
+          version: v1
+          resource: deployments
+      - name: widgets
+        resource-rule:
+          group: example.com
+          version: v1
+          resource: widgets
+provider:
+  type: opa
+  opa-spec:
+    rego: |
+      package validate
+
+      validate {
+        data.lib.has_label(input.pods[_], "foo")
+      }
+
+      msg := "checked"
+    modules:
+      lib: lib.rego
+    output:
+      observations:
+        - validate.msg
+tests:
+  - name: satisfied
+    expected-result: satisfied
+test-suites:
+  - suite.yaml
+`,
+		},
+		{
+			name: "rego fails to compile",
+			validation: `
+metadata:
+  name: compile
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+provider:
+  type: opa
+  opa-spec:
+    rego: |
+      package validate
+
+      validate {
+        undefined_var
+      }
+`,
+			want: []lint.Finding{
+				{RuleID: "rego-compile", Severity: lint.SeverityError, Message: "validate.rego:4: rego_unsafe_var_error: var undefined_var is unsafe", Field: "provider.opa-spec.rego", Validation: "compile"},
+			},
+		},
+		{
+			name: "undefined output paths",
+			validation: `
+metadata:
+  name: output
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+provider:
+  type: opa
+  opa-spec:
+    rego: |
+      package validate
+
+      validate := true
+    output:
+      validation: validate.passed
+      observations:
+        - validate.validate
+        - validate.msg
+`,
+			want: []lint.Finding{
+				{RuleID: "output-validation-undefined", Severity: lint.SeverityError, Message: "validation path validate.passed is not defined by the policy, so the validation is never satisfied", Field: "provider.opa-spec.output.validation", Validation: "output"},
+				{RuleID: "observation-undefined", Severity: lint.SeverityWarning, Message: "observation path validate.msg is not defined by the policy", Field: "provider.opa-spec.output.observations[1]", Validation: "output"},
+			},
+		},
+		{
+			name: "unknown kubernetes resources",
+			validation: `
+metadata:
+  name: kubernetes
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+domains:
+  - name: cluster
+    type: kubernetes
+    kubernetes-spec:
+      resources:
+        - name: pods
+          resource-rule:
+            version: v1
+            resource: podz
+        - name: deployments
+          resource-rule:
+            group: apps
+            version: v1beta9
+            resource: deployments
+`,
+			want: []lint.Finding{
+				{RuleID: "kubernetes-unknown-resource", Severity: lint.SeverityWarning, Message: "resource podz is unknown to v1", Field: "domains[0].kubernetes-spec.resources[0].resource-rule", Validation: "kubernetes"},
+				{RuleID: "kubernetes-unknown-resource", Severity: lint.SeverityWarning, Message: `version apps/v1beta9 is unknown to API group "apps"`, Field: "domains[0].kubernetes-spec.resources[1].resource-rule", Validation: "kubernetes"},
+			},
+		},
+		{
+			name: "unused module",
+			validation: `
+metadata:
+  name: modules
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+provider:
+  type: opa
+  opa-spec:
+    rego: |
+      package validate
+
+      import data.lib
+
+      validate {
+        lib.has_label(input.pods[_], "foo")
+      }
+    modules:
+      lib: lib.rego
+      unused: unused.rego
+`,
+			want: []lint.Finding{
+				{RuleID: "unused-module", Severity: lint.SeverityWarning, Message: "module unused (data.unused) is not referenced by the policy", Field: "provider.opa-spec.modules.unused", Validation: "modules"},
+			},
+		},
+		{
+			name: "api requests without timeout",
+			validation: `
+metadata:
+  name: api
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+domain:
+  type: api
+  api-spec:
+    requests:
+      - name: healthcheck
+        url: https://example.com/healthz
+      - name: status
+        url: https://example.com/status
+        options:
+          timeout: 5s
+`,
+			want: []lint.Finding{
+				{RuleID: "api-request-no-timeout", Severity: lint.SeverityInfo, Message: "request healthcheck has no timeout", Field: "domain.api-spec.requests[0]", Validation: "api"},
+			},
+		},
+		{
+			name: "spec timeout applies to all requests",
+			validation: `
+metadata:
+  name: api
+  uuid: 3c0e7b4f-6d4c-4f8a-9c77-0d5a2b1f3e11
+domain:
+  type: api
+  api-spec:
+    options:
+      timeout: 10s
+    requests:
+      - name: healthcheck
+        url: https://example.com/healthz
+`,
+		},
+		{
+			name: "missing uuid and failing tests",
+			validation: `
+metadata:
+  name: tests
+tests:
+  - name: satisfied
+    expected-result: satisfied
+`,
+			want: []lint.Finding{
+				{RuleID: "missing-uuid", Severity: lint.SeverityWarning, Message: "metadata has no uuid", Field: "metadata.uuid", Validation: "tests"},
+				{RuleID: "tests-missing-failing-case", Severity: lint.SeverityWarning, Message: "none of the 1 tests expects the result to be not-satisfied", Field: "tests", Validation: "tests"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validation common.Validation
+			require.NoError(t, validation.UnmarshalYaml([]byte(tt.validation)))
+			require.Equal(t, tt.want, lint.Lint(ctx, &validation))
+		})
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	severity, err := lint.ParseSeverity("Warning")
+	require.NoError(t, err)
+	require.Equal(t, lint.SeverityWarning, severity)
+
+	_, err = lint.ParseSeverity("fatal")
+	require.ErrorContains(t, err, "unsupported severity")
+
+	require.True(t, lint.SeverityError.AtLeast(lint.SeverityWarning))
+	require.True(t, lint.SeverityWarning.AtLeast(lint.SeverityWarning))
+	require.False(t, lint.SeverityInfo.AtLeast(lint.SeverityWarning))
+}
@@ -0,0 +1,5 @@
+package lib
+
+has_label(resource, label) {
+  resource.metadata.labels[label]
+}
@@ -370,6 +370,53 @@ func CreateObservation(method string, relevantEvidence *[]oscalTypes.RelevantEvi
 	return observation
 }
 
+// AddViolations records per-resource violations on an observation. Each distinct resource is added
+// as a subject, and each violation as a relevant evidence entry following the result evidence.
+func AddViolations(observation *oscalTypes.Observation, violations []types.Violation) {
+	if len(violations) == 0 {
+		return
+	}
+
+	evidence := make([]oscalTypes.RelevantEvidence, 0, len(violations))
+	if observation.RelevantEvidence != nil {
+		evidence = append(evidence, *observation.RelevantEvidence...)
+	}
+	subjects := make([]oscalTypes.SubjectReference, 0)
+	seen := make(map[string]bool)
+
+	for _, v := range violations {
+		props := []oscalTypes.Property{}
+		if v.Rule != "" {
+			props = append(props, oscalTypes.Property{Name: "rule", Ns: LULA_NAMESPACE, Value: v.Rule})
+		}
+		if v.Severity != "" {
+			props = append(props, oscalTypes.Property{Name: "severity", Ns: LULA_NAMESPACE, Value: v.Severity})
+		}
+		re := oscalTypes.RelevantEvidence{
+			Description: fmt.Sprintf("Violation: %s\n", v.Resource),
+			Remarks:     v.String(),
+		}
+		if len(props) > 0 {
+			re.Props = &props
+		}
+		evidence = append(evidence, re)
+
+		if v.Resource != "" && !seen[v.Resource] {
+			seen[v.Resource] = true
+			subjects = append(subjects, oscalTypes.SubjectReference{
+				SubjectUuid: uuid.NewUUIDWithSource(v.Resource),
+				Type:        "resource",
+				Title:       v.Resource,
+			})
+		}
+	}
+
+	observation.RelevantEvidence = &evidence
+	if len(subjects) > 0 {
+		observation.Subjects = &subjects
+	}
+}
+
 // Creates a result from findings and observations
 func CreateResult(findingMap map[string]oscalTypes.Finding, observations []oscalTypes.Observation) (oscalTypes.Result, error) {
 
@@ -16,6 +16,7 @@ import (
 	"github.com/defenseunicorns/lula/src/internal/testhelpers"
 	"github.com/defenseunicorns/lula/src/pkg/common/oscal"
 	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
 )
 
 const validAssessmentPath = "../../../test/unit/common/oscal/valid-assessment-results.yaml"
@@ -662,6 +663,38 @@ func TestCreateResult(t *testing.T) {
 	}
 }
 
+func TestAddViolations(t *testing.T) {
+	t.Parallel()
+
+	observation := oscalTypes.Observation{
+		RelevantEvidence: &[]oscalTypes.RelevantEvidence{
+			{Description: "Result: not-satisfied\n"},
+		},
+	}
+	oscal.AddViolations(&observation, []types.Violation{
+		{Resource: "default/nginx", Rule: "require-labels", Message: "missing label foo", Severity: "high"},
+		{Resource: "default/nginx", Rule: "require-limits", Message: "missing cpu limit"},
+		{Resource: "default/redis", Message: "missing label foo"},
+	})
+
+	require.NotNil(t, observation.RelevantEvidence)
+	evidence := *observation.RelevantEvidence
+	require.Len(t, evidence, 4)
+	assert.Equal(t, "Result: not-satisfied\n", evidence[0].Description)
+	assert.Equal(t, "Violation: default/nginx\n", evidence[1].Description)
+	assert.Equal(t, "default/nginx [high] require-labels: missing label foo", evidence[1].Remarks)
+	require.NotNil(t, evidence[1].Props)
+	assert.Len(t, *evidence[1].Props, 2)
+	assert.Nil(t, evidence[3].Props)
+
+	require.NotNil(t, observation.Subjects)
+	subjects := *observation.Subjects
+	require.Len(t, subjects, 2)
+	assert.Equal(t, "default/nginx", subjects[0].Title)
+	assert.Equal(t, "default/redis", subjects[1].Title)
+	assert.Equal(t, uuid.NewUUIDWithSource("default/redis"), subjects[1].SubjectUuid)
+}
+
 func TestGetObservationByUuid(t *testing.T) {
 	t.Parallel()
 
@@ -7,6 +7,7 @@ import (
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/defenseunicorns/lula/src/pkg/common"
 	"github.com/defenseunicorns/lula/src/pkg/common/oscal"
+	"github.com/defenseunicorns/lula/src/pkg/common/result"
 	validationstore "github.com/defenseunicorns/lula/src/pkg/common/validation-store"
 	"github.com/defenseunicorns/lula/src/pkg/message"
 	"github.com/defenseunicorns/lula/src/types"
@@ -15,11 +16,14 @@ import (
 type RequirementStore struct {
 	requirementMap map[string]oscal.Requirement
 	findingMap     map[string]oscalTypes.Finding
+	controlFilter  common.Filter
 }
 
 type Stats struct {
 	TotalRequirements        int
+	ExcludedRequirements     int
 	TotalValidations         int
+	ExcludedValidations      int
 	ExecutableValidations    bool
 	ExecutableValidationsMsg string
 	TotalFindings            int
@@ -33,11 +37,25 @@ func NewRequirementStore(controlImplementations *[]oscalTypes.ControlImplementat
 	}
 }
 
+// SetControlFilter sets the filter of the control IDs of the requirements that are assessed. The
+// validations of requirements excluded by the filter are not resolved, and their findings are not assessed.
+func (r *RequirementStore) SetControlFilter(filter common.Filter) {
+	r.controlFilter = filter
+}
+
+// isExcluded checks if the requirement is excluded by the control filter
+func (r *RequirementStore) isExcluded(requirement oscal.Requirement) bool {
+	return !r.controlFilter.Matches(requirement.ImplementedRequirement.ControlId)
+}
+
 // ResolveLulaValidations resolves the linked Lula validations with the requirements and populates the ValidationStore.validationMap
 func (r *RequirementStore) ResolveLulaValidations(validationStore *validationstore.ValidationStore) {
 	// get all Lula validations linked to the requirement
 	var lulaValidation *types.LulaValidation
 	for _, requirement := range r.requirementMap {
+		if r.isExcluded(requirement) {
+			continue
+		}
 		if requirement.ImplementedRequirement.Links != nil {
 			for _, link := range *requirement.ImplementedRequirement.Links {
 				if common.IsLulaLink(link) {
@@ -63,7 +81,7 @@ func (r *RequirementStore) GenerateFindings(validationStore *validationstore.Val
 	for _, requirement := range r.requirementMap {
 		// This should produce a finding - check if an existing finding for the control-id has been processed
 		var finding oscalTypes.Finding
-		var pass, fail int
+		var pass, fail, excluded int
 
 		// A single finding should be "control-id centric"
 		if _, ok := r.findingMap[requirement.ImplementedRequirement.ControlId]; ok {
@@ -77,9 +95,29 @@ func (r *RequirementStore) GenerateFindings(validationStore *validationstore.Val
 			}
 		}
 
+		// Requirements excluded by the control filter are not assessed
+		if r.isExcluded(requirement) {
+			finding.Target = oscalTypes.FindingTarget{
+				Status: oscalTypes.ObjectiveStatus{
+					State:   "not-satisfied",
+					Reason:  result.NotAssessed,
+					Remarks: "Control was excluded from the assessment",
+				},
+				TargetId: requirement.ImplementedRequirement.ControlId,
+				Type:     "objective-id",
+			}
+			r.findingMap[requirement.ImplementedRequirement.ControlId] = finding
+			continue
+		}
+
 		if requirement.ImplementedRequirement.Links != nil {
 			relatedObservations := make([]oscalTypes.RelatedObservation, 0, len(*requirement.ImplementedRequirement.Links))
 			for _, link := range *requirement.ImplementedRequirement.Links {
+				// Validations excluded by the tag filter were not run
+				if validationStore.IsExcluded(link.Href) {
+					excluded++
+					continue
+				}
 				observation, passBool := validationStore.GetRelatedObservation(link.Href)
 				relatedObservations = append(relatedObservations, observation)
 				if passBool {
@@ -92,13 +130,15 @@ func (r *RequirementStore) GenerateFindings(validationStore *validationstore.Val
 			if finding.RelatedObservations != nil {
 				relatedObservations = append(relatedObservations, *finding.RelatedObservations...)
 			}
-			finding.RelatedObservations = &relatedObservations
+			if len(relatedObservations) > 0 {
+				finding.RelatedObservations = &relatedObservations
+			}
 		}
 
 		// Using language from Assessment Results model for Target Objective Status State
 		var state, reason, remarks string
-		message.Debugf("Pass: %v / Fail: %v / Existing State: %s", pass, fail, finding.Target.Status.State)
-		if finding.Target.Status.State == "not-satisfied" {
+		message.Debugf("Pass: %v / Fail: %v / Excluded: %v / Existing State: %s", pass, fail, excluded, finding.Target.Status.State)
+		if finding.Target.Status.State == "not-satisfied" && finding.Target.Status.Reason != result.NotAssessed {
 			state = "not-satisfied"
 			// If the previous state was not-satisfied but there are RelatedObservations
 			// Then we want to update the reason or remarks in the event the reason
@@ -110,6 +150,17 @@ func (r *RequirementStore) GenerateFindings(validationStore *validationstore.Val
 		} else if pass > 0 && fail == 0 {
 			state = "satisfied"
 			reason = "pass"
+		} else if pass == 0 && fail == 0 && excluded > 0 {
+			// If every validation was excluded, the control is not assessed, unless another
+			// requirement of the control was already satisfied
+			if finding.Target.Status.State == "satisfied" {
+				state = "satisfied"
+				reason = "pass"
+			} else {
+				state = "not-satisfied"
+				reason = result.NotAssessed
+				remarks = "All Lula validations for this control were excluded from the assessment"
+			}
 		} else if pass == 0 && fail == 0 {
 			// If there is no result (pass or fail) it means that no validation was performed by Lula.
 			// When that happens we can explicitly add a note to the finding, to properly explain the
@@ -145,9 +196,18 @@ func (r *RequirementStore) GetStats(validationStore *validationstore.ValidationS
 		executableValidations, executableValidationsMsg = validationStore.DryRun()
 	}
 
+	var excludedRequirements int
+	for _, requirement := range r.requirementMap {
+		if r.isExcluded(requirement) {
+			excludedRequirements++
+		}
+	}
+
 	return Stats{
 		TotalRequirements:        len(r.requirementMap),
+		ExcludedRequirements:     excludedRequirements,
 		TotalValidations:         validationStore.Count(),
+		ExcludedValidations:      validationStore.CountExcluded(),
 		ExecutableValidations:    executableValidations,
 		ExecutableValidationsMsg: executableValidationsMsg,
 		TotalFindings:            len(r.findingMap),
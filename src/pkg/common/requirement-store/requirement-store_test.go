@@ -7,8 +7,10 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/defenseunicorns/lula/src/internal/testhelpers"
+	"github.com/defenseunicorns/lula/src/pkg/common"
 	"github.com/defenseunicorns/lula/src/pkg/common/oscal"
 	requirementstore "github.com/defenseunicorns/lula/src/pkg/common/requirement-store"
+	"github.com/defenseunicorns/lula/src/pkg/common/result"
 	validationstore "github.com/defenseunicorns/lula/src/pkg/common/validation-store"
 )
 
@@ -44,3 +46,44 @@ func TestGenerateFindings(t *testing.T) {
 	assert.Equal(t, "other", findings["ID-2"].Target.Status.Reason)
 	assert.Equal(t, "No Lula validations were defined for this control", findings["ID-2"].Target.Status.Remarks)
 }
+
+func TestGenerateFindingsWithFilters(t *testing.T) {
+	model := testhelpers.OscalFromPath(t, validCompDefMultiValidations)
+	controlMap := oscal.FilterControlImplementations(model.ComponentDefinition)
+	impls := controlMap[controlImplementationSource]
+
+	t.Run("excluded controls are not assessed", func(t *testing.T) {
+		vs := validationstore.NewValidationStoreFromBackMatter(*model.ComponentDefinition.BackMatter)
+		rs := requirementstore.NewRequirementStore(&impls)
+		rs.SetControlFilter(common.Filter{Exclude: []string{"id-1"}})
+		rs.ResolveLulaValidations(vs)
+
+		stats := rs.GetStats(vs)
+		assert.Equal(t, 1, stats.ExcludedRequirements)
+		assert.Equal(t, 0, stats.TotalValidations)
+
+		findings := rs.GenerateFindings(vs)
+		assert.Len(t, findings, 2)
+		assert.Equal(t, "not-satisfied", findings["ID-1"].Target.Status.State)
+		assert.Equal(t, result.NotAssessed, findings["ID-1"].Target.Status.Reason)
+		assert.Equal(t, "Control was excluded from the assessment", findings["ID-1"].Target.Status.Remarks)
+		assert.Nil(t, findings["ID-1"].RelatedObservations)
+		assert.Equal(t, "other", findings["ID-2"].Target.Status.Reason)
+	})
+
+	t.Run("excluded validations are not assessed", func(t *testing.T) {
+		vs := validationstore.NewValidationStoreFromBackMatter(*model.ComponentDefinition.BackMatter)
+		vs.SetTagFilter(common.Filter{Include: []string{"cluster"}})
+		rs := requirementstore.NewRequirementStore(&impls)
+		rs.ResolveLulaValidations(vs)
+
+		stats := rs.GetStats(vs)
+		assert.Equal(t, 0, stats.ExcludedRequirements)
+		assert.Equal(t, stats.TotalValidations, stats.ExcludedValidations)
+
+		findings := rs.GenerateFindings(vs)
+		assert.Equal(t, "not-satisfied", findings["ID-1"].Target.Status.State)
+		assert.Equal(t, result.NotAssessed, findings["ID-1"].Target.Status.Reason)
+		assert.Nil(t, findings["ID-1"].RelatedObservations)
+	})
+}
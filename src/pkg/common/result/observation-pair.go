@@ -1,6 +1,7 @@
 package result
 
 import (
+	"fmt"
 	"strings"
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
@@ -130,6 +131,9 @@ func getObservationResult(relevantEvidence *[]oscalTypes.RelevantEvidence) bool
 	var satisfied bool
 	if relevantEvidence != nil {
 		for _, re := range *relevantEvidence {
+			if isViolation(re) {
+				continue
+			}
 			if !strings.Contains(re.Description, "not-satisfied") {
 				satisfied = true
 			}
@@ -138,10 +142,21 @@ func getObservationResult(relevantEvidence *[]oscalTypes.RelevantEvidence) bool
 	return satisfied
 }
 
+// getRemarks returns the remarks of the result evidence, followed by a line for each violation
 func getRemarks(relevantEvidence *[]oscalTypes.RelevantEvidence) string {
 	var remarks string
 	if relevantEvidence != nil {
 		remarks = (*relevantEvidence)[0].Remarks
+		for _, re := range (*relevantEvidence)[1:] {
+			if isViolation(re) {
+				remarks += fmt.Sprintf("- %s\n", re.Remarks)
+			}
+		}
 	}
 	return remarks
 }
+
+// isViolation reports whether the evidence records a per-resource violation rather than the result
+func isViolation(re oscalTypes.RelevantEvidence) bool {
+	return strings.HasPrefix(re.Description, "Violation: ")
+}
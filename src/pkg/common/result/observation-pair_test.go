@@ -22,6 +22,17 @@ func createObservation(description, satisfaction string) *oscalTypes.Observation
 	}
 }
 
+func createObservationWithViolations(description, satisfaction string, resources ...string) *oscalTypes.Observation {
+	observation := createObservation(description, satisfaction)
+	for _, resource := range resources {
+		*observation.RelevantEvidence = append(*observation.RelevantEvidence, oscalTypes.RelevantEvidence{
+			Description: fmt.Sprintf("Violation: %s\n", resource),
+			Remarks:     fmt.Sprintf("%s missing label", resource),
+		})
+	}
+	return observation
+}
+
 func TestCreateObservationPairs(t *testing.T) {
 	// tests different variations of observation pairs
 	tests := []struct {
@@ -89,6 +100,19 @@ func TestCreateObservationPairs(t *testing.T) {
 				"test-3": result.UNCHANGED,
 			},
 		},
+		{
+			name: "One observation pair with violations, satisfied to not-satisfied",
+			observations: []*oscalTypes.Observation{
+				createObservationWithViolations("test-1", "not-satisfied", "default/nginx", "default/redis"),
+			},
+			compareObservations: []*oscalTypes.Observation{
+				createObservation("test-1", "satisfied"),
+			},
+			expectedPairs: 1,
+			expectedStateChange: map[string]result.StateChange{
+				"test-1": result.SATISFIED_TO_NOT_SATISFIED,
+			},
+		},
 		{
 			name:                "No observation pairs",
 			observations:        []*oscalTypes.Observation{},
@@ -18,6 +18,10 @@ const (
 	UNCHANGED                  StateChange = "UNCHANGED"
 )
 
+// NotAssessed is the reason of the status of findings whose control was not assessed, because the
+// control or all of its validations were excluded by a filter. These findings are not compared.
+const NotAssessed = "not-assessed"
+
 type ResultComparison struct {
 	StateChange      StateChange
 	Satisfied        bool
@@ -110,7 +114,15 @@ func NewResultComparisonMap(result oscalTypes.Result, comparedResult oscalTypes.
 	}
 
 	for targetId, finding := range findingMap {
+		if notAssessed(finding) {
+			// The control was excluded from the result, so there is nothing to compare
+			continue
+		}
 		comparedFinding, found := comparedFindingMap[targetId]
+		if found && notAssessed(comparedFinding) {
+			// The control was excluded from the compared result, so it is new to this result
+			found = false
+		}
 		if !found {
 			// Capture new findings that were not found in the compared findings
 			resultComparisonMap[targetId] = newResultComparison(finding, nil, relatedObservationsMap[targetId], nil)
@@ -122,7 +134,7 @@ func NewResultComparisonMap(result oscalTypes.Result, comparedResult oscalTypes.
 
 	for targetId, comparedFinding := range comparedFindingMap {
 		_, found := findingMap[targetId]
-		if !found {
+		if !found && !notAssessed(comparedFinding) {
 			// Capture compared findings that were removed/missing from result
 			resultComparisonMap[targetId] = newResultComparison(nil, comparedFinding, nil, comparedRelatedObservationsMap[targetId])
 		}
@@ -221,6 +233,11 @@ func newResultComparison(finding *oscalTypes.Finding, comparedFinding *oscalType
 	return resultComparison
 }
 
+// notAssessed returns true if the control of the finding was excluded from the assessment
+func notAssessed(finding *oscalTypes.Finding) bool {
+	return finding.Target.Status.Reason == NotAssessed
+}
+
 // generateFindingMap creates a finding map on the TargetId
 // ** Note: this assumes 1:1 relationship between targetId and finding
 func generateFindingMap(findings []oscalTypes.Finding) map[string]*oscalTypes.Finding {
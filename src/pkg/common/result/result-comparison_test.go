@@ -93,6 +93,22 @@ func createTestResultNoObs(findingId, findingState string) oscalTypes.Result {
 	}
 }
 
+func createTestResultNotAssessed(findingId string) oscalTypes.Result {
+	return oscalTypes.Result{
+		Findings: &[]oscalTypes.Finding{
+			{
+				Target: oscalTypes.FindingTarget{
+					TargetId: findingId,
+					Status: oscalTypes.ObjectiveStatus{
+						State:  "not-satisfied",
+						Reason: result.NotAssessed,
+					},
+				},
+			},
+		},
+	}
+}
+
 // Helper function to check if a slice contains a specific string
 func contains(slice []string, item string) bool {
 	for _, v := range slice {
@@ -191,6 +207,48 @@ func TestGetResultComparisonMap(t *testing.T) {
 	}
 }
 
+func TestNewResultComparisonMapNotAssessed(t *testing.T) {
+	tests := []struct {
+		name                string
+		thresholdResult     oscalTypes.Result
+		result              oscalTypes.Result
+		expectedStateChange map[string]result.StateChange
+	}{
+		{
+			name:                "Not assessed finding is not compared",
+			thresholdResult:     createTestResult("id-1", "test-1", "satisfied", "satisfied"),
+			result:              createTestResultNotAssessed("id-1"),
+			expectedStateChange: map[string]result.StateChange{},
+		},
+		{
+			name:                "Finding not assessed in threshold is new",
+			thresholdResult:     createTestResultNotAssessed("id-1"),
+			result:              createTestResult("id-1", "test-1", "satisfied", "satisfied"),
+			expectedStateChange: map[string]result.StateChange{"id-1": result.NEW},
+		},
+		{
+			name:                "Finding not assessed in threshold is not removed",
+			thresholdResult:     createTestResultNotAssessed("id-1"),
+			result:              createTestResult("id-2", "test-2", "satisfied", "satisfied"),
+			expectedStateChange: map[string]result.StateChange{"id-2": result.NEW},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultComparisonMap := result.NewResultComparisonMap(tt.result, tt.thresholdResult)
+
+			stateChanges := make(map[string]result.StateChange, len(resultComparisonMap))
+			for id, resultComparison := range resultComparisonMap {
+				stateChanges[id] = resultComparison.StateChange
+			}
+			if !reflect.DeepEqual(stateChanges, tt.expectedStateChange) {
+				t.Errorf("Expected state changes %v, but got %v", tt.expectedStateChange, stateChanges)
+			}
+		})
+	}
+}
+
 func TestRefactorObservationsByControls(t *testing.T) {
 	// create a bunch of result-comparisons for each ID...
 	result1 := createTestResult("id-1", "test-1", "satisfied", "satisfied")
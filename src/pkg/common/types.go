@@ -16,7 +16,16 @@ import (
 	"github.com/defenseunicorns/lula/src/pkg/common/schemas"
 	"github.com/defenseunicorns/lula/src/pkg/domains/api"
 	"github.com/defenseunicorns/lula/src/pkg/domains/files"
+	"github.com/defenseunicorns/lula/src/pkg/domains/host"
 	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+	"github.com/defenseunicorns/lula/src/pkg/domains/metrics"
+	"github.com/defenseunicorns/lula/src/pkg/domains/render"
+	"github.com/defenseunicorns/lula/src/pkg/domains/sql"
+	"github.com/defenseunicorns/lula/src/pkg/domains/terraform"
+	"github.com/defenseunicorns/lula/src/pkg/domains/tls"
+	"github.com/defenseunicorns/lula/src/pkg/domains/vulnscan"
+	"github.com/defenseunicorns/lula/src/pkg/providers/cue"
+	"github.com/defenseunicorns/lula/src/pkg/providers/expression"
 	"github.com/defenseunicorns/lula/src/pkg/providers/kyverno"
 	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
 	"github.com/defenseunicorns/lula/src/types"
@@ -38,7 +47,9 @@ type Validation struct {
 	Metadata    *Metadata                   `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Provider    *Provider                   `json:"provider,omitempty" yaml:"provider,omitempty"`
 	Domain      *Domain                     `json:"domain,omitempty" yaml:"domain,omitempty"`
+	Domains     []NamedDomain               `json:"domains,omitempty" yaml:"domains,omitempty"`
 	Tests       *[]types.LulaValidationTest `json:"tests,omitempty" yaml:"tests,omitempty"`
+	TestSuites  []string                    `json:"test-suites,omitempty" yaml:"test-suites,omitempty"`
 }
 
 // UnmarshalYaml is a convenience method to unmarshal a Validation object from a YAML byte array
@@ -92,11 +103,14 @@ func (v *Validation) ToResource() (resource *oscalTypes.Resource, err error) {
 type Metadata struct {
 	Name string `json:"name" yaml:"name"`
 	UUID string `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	// Optional: Tags categorize the validation, e.g. cluster, slow or network, to select the
+	// validations that are run
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 // Domain is a structure that contains the domain type and the corresponding spec
 type Domain struct {
-	// Type is the type of domain: enum: kubernetes, api
+	// Type is the type of domain: enum: kubernetes, api, file, vuln-scan, host, terraform, render, tls, sql, metrics
 	Type string `json:"type" yaml:"type"`
 	// KubernetesSpec is the specification for a Kubernetes domain, required if type is kubernetes
 	KubernetesSpec *kube.KubernetesSpec `json:"kubernetes-spec,omitempty" yaml:"kubernetes-spec,omitempty"`
@@ -104,12 +118,35 @@ type Domain struct {
 	ApiSpec *api.ApiSpec `json:"api-spec,omitempty" yaml:"api-spec,omitempty"`
 	// FileSpec is the specification for a File domain, required if type is file
 	FileSpec *files.Spec `json:"file-spec,omitempty" yaml:"file-spec,omitempty"`
+	// VulnScanSpec is the specification for a Vulnerability Scan domain, required if type is vuln-scan
+	VulnScanSpec *vulnscan.Spec `json:"vuln-scan-spec,omitempty" yaml:"vuln-scan-spec,omitempty"`
+	// HostSpec is the specification for a Host domain, required if type is host
+	HostSpec *host.Spec `json:"host-spec,omitempty" yaml:"host-spec,omitempty"`
+	// TerraformSpec is the specification for a Terraform domain, required if type is terraform
+	TerraformSpec *terraform.Spec `json:"terraform-spec,omitempty" yaml:"terraform-spec,omitempty"`
+	// RenderSpec is the specification for a Render domain, required if type is render
+	RenderSpec *render.Spec `json:"render-spec,omitempty" yaml:"render-spec,omitempty"`
+	// TLSSpec is the specification for a TLS domain, required if type is tls
+	TLSSpec *tls.Spec `json:"tls-spec,omitempty" yaml:"tls-spec,omitempty"`
+	// SQLSpec is the specification for a SQL domain, required if type is sql
+	SQLSpec *sql.Spec `json:"sql-spec,omitempty" yaml:"sql-spec,omitempty"`
+	// MetricsSpec is the specification for a Metrics domain, required if type is metrics
+	MetricsSpec *metrics.Spec `json:"metrics-spec,omitempty" yaml:"metrics-spec,omitempty"`
+}
+
+// NamedDomain is a domain within a multi-domain validation, whose resources are provided
+// to the provider under its name
+type NamedDomain struct {
+	Name   string `json:"name" yaml:"name"`
+	Domain `yaml:",inline"`
 }
 
 type Provider struct {
-	Type        string               `json:"type" yaml:"type"`
-	OpaSpec     *opa.OpaSpec         `json:"opa-spec,omitempty" yaml:"opa-spec,omitempty"`
-	KyvernoSpec *kyverno.KyvernoSpec `json:"kyverno-spec,omitempty" yaml:"kyverno-spec,omitempty"`
+	Type           string                     `json:"type" yaml:"type"`
+	OpaSpec        *opa.OpaSpec               `json:"opa-spec,omitempty" yaml:"opa-spec,omitempty"`
+	KyvernoSpec    *kyverno.KyvernoSpec       `json:"kyverno-spec,omitempty" yaml:"kyverno-spec,omitempty"`
+	ExpressionSpec *expression.ExpressionSpec `json:"expression-spec,omitempty" yaml:"expression-spec,omitempty"`
+	CueSpec        *cue.CueSpec               `json:"cue-spec,omitempty" yaml:"cue-spec,omitempty"`
 }
 
 // Lint is a convenience method to lint a Validation object
@@ -153,12 +190,23 @@ func (validation *Validation) ToLulaValidation(uuid string) (lulaValidation type
 	// TODO: Is there a better location for context?
 	ctx := context.Background()
 
-	domain, err := GetDomain(validation.Domain)
-	if domain == nil {
-		return lulaValidation, fmt.Errorf("%w: %s", ErrInvalidDomain, validation.Domain.Type)
-	}
-	if err != nil {
-		return lulaValidation, fmt.Errorf("%w: %v", ErrInvalidDomain, err)
+	var domain types.Domain
+	if len(validation.Domains) > 0 {
+		if validation.Domain != nil {
+			return lulaValidation, fmt.Errorf("%w: domain and domains cannot both be specified", ErrInvalidDomain)
+		}
+		domain, err = GetDomains(validation.Domains)
+		if err != nil {
+			return lulaValidation, fmt.Errorf("%w: %v", ErrInvalidDomain, err)
+		}
+	} else {
+		domain, err = GetDomain(validation.Domain)
+		if domain == nil {
+			return lulaValidation, fmt.Errorf("%w: %s", ErrInvalidDomain, validation.Domain.Type)
+		}
+		if err != nil {
+			return lulaValidation, fmt.Errorf("%w: %v", ErrInvalidDomain, err)
+		}
 	}
 	lulaValidation.Domain = &domain
 
@@ -176,6 +224,7 @@ func (validation *Validation) ToLulaValidation(uuid string) (lulaValidation type
 		lulaValidation.Name = "lula-validation"
 	} else {
 		lulaValidation.Name = validation.Metadata.Name
+		lulaValidation.Tags = validation.Metadata.Tags
 	}
 
 	// Add tests if they exist
@@ -191,6 +240,7 @@ func (validation *Validation) ToLulaValidation(uuid string) (lulaValidation type
 		}
 		lulaValidation.ValidationTestData = validationTestData
 	}
+	lulaValidation.TestSuites = validation.TestSuites
 
 	return lulaValidation, nil
 }
@@ -167,6 +167,252 @@ provider:
 			expectErr:       true,
 			expectedErrType: common.ErrInvalidProvider,
 		},
+		{
+			name: "Valid opa bundle without rego",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-valid-bundle"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "opa"
+  opa-spec:
+    bundles:
+      - ./policy-library
+    output:
+      validation: library.kubernetes.validate
+`),
+		},
+		{
+			name: "Valid kyverno cluster policy",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-valid-cluster-policy"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "kyverno"
+  kyverno-spec:
+    cluster-policy:
+      apiVersion: kyverno.io/v1
+      kind: ClusterPolicy
+      metadata:
+        name: disallow-privileged
+        annotations:
+          pod-policies.kyverno.io/autogen-controllers: none
+      spec:
+        rules:
+          - name: privileged-containers
+            match:
+              any:
+                - resources:
+                    kinds:
+                      - Pod
+            validate:
+              pattern:
+                spec:
+                  containers:
+                    - =(securityContext):
+                        =(privileged): "false"
+`),
+		},
+		{
+			name: "Invalid kyverno policy and policy file",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-cluster-policy"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "kyverno"
+  kyverno-spec:
+    policy-file: ./policies/disallow-privileged.yaml
+    cluster-policy:
+      metadata:
+        name: disallow-privileged
+      spec:
+        rules: []
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Valid expression provider",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-valid-expression"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "expression"
+  expression-spec:
+    expressions:
+      - name: pods-labeled
+        jq: .pods[].metadata.labels.app
+        operator: exists
+      - name: replicas
+        jsonpath: "{.deployments[*].spec.replicas}"
+        operator: count>=
+        value: 2
+`),
+		},
+		{
+			name: "Invalid expression provider, jq and jsonpath",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-expression"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "expression"
+  expression-spec:
+    expressions:
+      - name: pods-labeled
+        jq: .pods[].metadata.labels.app
+        jsonpath: "{.pods[*].metadata.labels.app}"
+        value: nginx
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Valid cue provider",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-valid-cue"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "cue"
+  cue-spec:
+    path: "#Resources"
+    cue: |
+      #Resources: pods: [...{metadata: labels: app: string, ...}]
+`),
+		},
+		{
+			name: "Invalid cue provider, no definitions",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-cue"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: "cue"
+  cue-spec:
+    path: "#Resources"
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Valid multiple domains",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-valid-domains"
+domains:
+  - name: cluster
+    type: "kubernetes"
+    kubernetes-spec:
+      resources: []
+  - name: baseline
+    type: "file"
+    file-spec:
+      filepaths:
+        - name: config
+          path: config.yaml
+provider:
+  type: "opa"
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+`),
+		},
+		{
+			name: "Invalid domain and domains",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-domains"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+domains:
+  - name: cluster
+    type: "kubernetes"
+    kubernetes-spec:
+      resources: []
+provider:
+  type: "opa"
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Invalid domains schema, missing name",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-domains"
+domains:
+  - type: "kubernetes"
+    kubernetes-spec:
+      resources: []
+provider:
+  type: "opa"
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Invalid domains, duplicate names",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-domains"
+domains:
+  - name: cluster
+    type: "kubernetes"
+    kubernetes-spec:
+      resources: []
+  - name: cluster
+    type: "kubernetes"
+    kubernetes-spec:
+      resources: []
+provider:
+  type: "opa"
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidDomain,
+		},
 		{
 			name: "Valid tests",
 			inputYaml: []byte(`
@@ -184,7 +430,176 @@ provider:
 tests:
   - name: "test-1"
     expected-result: satisfied
+  - name: "test-2"
+    expected-result: not-satisfied
+    expected-observations:
+      - key: validate.msg
+        equals: "label foo is missing"
+      - key: validate.resources
+        matches: "^pods: [0-9]+$"
+    golden-file: golden/test-2.yaml
+`),
+		},
+		{
+			name: "Valid tests with fixtures, generators and test suites",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-fixtures"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: opa
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+tests:
+  - name: "test-1"
+    fixture: fixtures/pods.yaml
+    generators:
+      - name: pods
+        count: 3
+        template:
+          metadata:
+            name: pod-${index}
+    expected-result: satisfied
+test-suites:
+  - suites/pods.yaml
+`),
+		},
+		{
+			name: "Valid tests with json patch and merge patch",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-patches"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: opa
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+tests:
+  - name: "test-1"
+    changes:
+      - type: json-patch
+        patch:
+          - op: test
+            path: /pods/0/metadata/name
+            value: nginx
+          - op: copy
+            from: /pods/0
+            path: /pods/-
+          - op: remove
+            path: /pods/0/metadata/labels
+      - type: merge-patch
+        value-map:
+          replicas: null
+    expected-result: satisfied
+`),
+		},
+		{
+			name: "Invalid json patch operation",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-patches"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: opa
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+tests:
+  - name: "test-1"
+    changes:
+      - type: json-patch
+        patch:
+          - op: move
+            path: /pods/0
+    expected-result: satisfied
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Invalid generator",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-fixtures"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: opa
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+tests:
+  - name: "test-1"
+    generators:
+      - name: pods
+        count: 0
+        template: {}
+    expected-result: satisfied
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
+		},
+		{
+			name: "Invalid expected observation",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-provider"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: opa
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+tests:
+  - name: "test-1"
+    expected-result: satisfied
+    expected-observations:
+      - key: validate.msg
+        matches: "("
 `),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidTest,
+		},
+		{
+			name: "Invalid expected observation, equals and matches",
+			inputYaml: []byte(`
+lula-version: "1.0.0"
+metadata:
+  name: "test-invalid-provider"
+domain:
+  type: "kubernetes"
+  kubernetes-spec:
+    resources: []
+provider:
+  type: opa
+  opa-spec:
+    rego: "package validate\n\ndefault validate = false"
+tests:
+  - name: "test-1"
+    expected-result: satisfied
+    expected-observations:
+      - key: validate.msg
+        equals: "a"
+        matches: "a"
+`),
+			expectErr:       true,
+			expectedErrType: common.ErrInvalidSchema,
 		},
 		{
 			name: "Invalid tests",
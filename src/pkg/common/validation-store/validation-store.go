@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/defenseunicorns/go-oscal/src/pkg/files"
 	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
@@ -20,6 +21,7 @@ type ValidationStore struct {
 	backMatterMap  map[string]string
 	validationMap  map[string]*types.LulaValidation
 	observationMap map[string]*oscalTypes.Observation
+	tagFilter      common.Filter
 }
 
 // NewValidationStore creates a new validation store
@@ -45,6 +47,33 @@ func (v *ValidationStore) Count() int {
 	return len(v.validationMap)
 }
 
+// SetTagFilter sets the filter of the tags of the validations that are run. Validations excluded
+// by the filter are not run or tested, and are not assessed.
+func (v *ValidationStore) SetTagFilter(filter common.Filter) {
+	v.tagFilter = filter
+}
+
+// IsExcluded checks if the validation with the given ID is in the store and excluded by the tag filter
+func (v *ValidationStore) IsExcluded(id string) bool {
+	validation, ok := v.validationMap[common.TrimIdPrefix(id)]
+	return ok && v.isExcluded(validation)
+}
+
+// CountExcluded returns the number of validations in the store excluded by the tag filter
+func (v *ValidationStore) CountExcluded() int {
+	count := 0
+	for _, val := range v.validationMap {
+		if v.isExcluded(val) {
+			count++
+		}
+	}
+	return count
+}
+
+func (v *ValidationStore) isExcluded(validation *types.LulaValidation) bool {
+	return validation != nil && !v.tagFilter.Matches(validation.Tags...)
+}
+
 // AddValidation adds a validation to the store
 func (v *ValidationStore) AddValidation(validation *common.Validation) (id string, err error) {
 	if validation.Metadata == nil {
@@ -95,7 +124,7 @@ func (v *ValidationStore) GetLulaValidation(id string) (validation *types.LulaVa
 func (v *ValidationStore) DryRun() (executable bool, msg string) {
 	executableValidations := make([]string, 0)
 	for k, val := range v.validationMap {
-		if val != nil && val.Domain != nil {
+		if val != nil && val.Domain != nil && !v.isExcluded(val) {
 			if (*val.Domain).IsExecutable() {
 				executableValidations = append(executableValidations, k)
 			}
@@ -112,6 +141,10 @@ func (v *ValidationStore) RunValidations(ctx context.Context, confirmExecution,
 	observations := make([]oscalTypes.Observation, 0, len(v.validationMap))
 
 	for k, val := range v.validationMap {
+		if v.isExcluded(val) {
+			message.Infof("Skipping validation %s, excluded by tags -> not-assessed", k)
+			continue
+		}
 		if val != nil {
 			// Create observation for each non-nil validation
 			completedText := "evaluated"
@@ -171,6 +204,7 @@ func (v *ValidationStore) RunValidations(ctx context.Context, confirmExecution,
 				},
 			}
 			observation := oscal.CreateObservation("TEST", relevantEvidence, val, resourceHref, "[TEST]: %s - %s\n", k, val.Name)
+			oscal.AddViolations(&observation, val.Result.Violations)
 			v.observationMap[k] = &observation
 			observations = append(observations, observation)
 
@@ -189,9 +223,12 @@ func (v *ValidationStore) GetRelatedObservation(id string) (oscalTypes.RelatedOb
 	}
 	pass := false
 
-	// check all descriptions in relevant evidence are satisfied
+	// check all result descriptions in relevant evidence are satisfied - violation evidence is ignored
 	if observation.RelevantEvidence != nil {
 		for _, e := range *observation.RelevantEvidence {
+			if !strings.HasPrefix(e.Description, "Result: ") {
+				continue
+			}
 			if e.Description == "Result: satisfied\n" {
 				pass = true
 			} else { // if any are not satisfied, return false
@@ -211,6 +248,9 @@ func (v *ValidationStore) RunTests(ctx context.Context) map[string]types.LulaVal
 	testReportMap := make(map[string]types.LulaValidationTestReport)
 
 	for uuid, validation := range v.validationMap {
+		if v.isExcluded(validation) {
+			continue
+		}
 		// TODO: should test results be saved, e.g., if printResources is true?
 		testReport, err := validation.RunTests(ctx, false)
 		if err != nil {
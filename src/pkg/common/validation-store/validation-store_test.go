@@ -278,3 +278,36 @@ func TestRunTests(t *testing.T) {
 	assert.True(t, reportValidationWithTests.TestResults[0].Pass)
 	assert.True(t, reportValidationWithTests.TestResults[1].Pass)
 }
+
+func TestTagFilter(t *testing.T) {
+	message.NoProgress = true
+	ctx := context.Background()
+	v := validationstore.NewValidationStore()
+
+	validation := generateValidation(t, "./testdata/validation.yaml")
+	validation.Metadata.Tags = []string{"slow"}
+	validationWithTests := generateValidation(t, "./testdata/validation-with-tests.yaml")
+
+	idValidation, err := v.AddValidation(&validation)
+	require.NoError(t, err)
+	idValidationWithTests, err := v.AddValidation(&validationWithTests)
+	require.NoError(t, err)
+
+	v.SetTagFilter(common.Filter{Exclude: []string{"slow"}})
+	assert.True(t, v.IsExcluded(idValidation))
+	assert.False(t, v.IsExcluded(idValidationWithTests))
+	assert.False(t, v.IsExcluded("#unknown"))
+	assert.Equal(t, 1, v.CountExcluded())
+
+	// Excluded validations are not run or tested
+	observations := v.RunValidations(ctx, true, false, "")
+	require.Len(t, observations, 1)
+	relatedObservation, _ := v.GetRelatedObservation(idValidation)
+	assert.Empty(t, relatedObservation.ObservationUuid)
+	relatedObservation, _ = v.GetRelatedObservation(idValidationWithTests)
+	assert.Equal(t, observations[0].UUID, relatedObservation.ObservationUuid)
+
+	testReport := v.RunTests(ctx)
+	assert.Len(t, testReport, 1)
+	assert.Contains(t, testReport, idValidationWithTests)
+}
@@ -3,8 +3,10 @@ package validation
 import (
 	"fmt"
 
+	"github.com/defenseunicorns/lula/src/pkg/common"
 	"github.com/defenseunicorns/lula/src/pkg/common/composition"
 	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
 )
 
 type Option func(*Validator) error
@@ -58,3 +60,31 @@ func WithTests(runTests bool) Option {
 		return nil
 	}
 }
+
+func WithTestReportFormat(format string) Option {
+	return func(v *Validator) error {
+		if err := types.ValidateTestReportFormat(format); err != nil {
+			return err
+		}
+		v.testReportFormat = types.TestReportFormat(format)
+		return nil
+	}
+}
+
+// WithTagFilter selects the validations that are run by their tags. Validations with any of the
+// excluded tags, or, if any tags are given, without any of the tags are not assessed.
+func WithTagFilter(tags, excludeTags []string) Option {
+	return func(v *Validator) error {
+		v.tagFilter = common.Filter{Include: tags, Exclude: excludeTags}
+		return nil
+	}
+}
+
+// WithControlFilter selects the requirements that are assessed by their control IDs. Requirements
+// of the excluded controls, or, if any controls are given, of other controls are not assessed.
+func WithControlFilter(controls, excludeControls []string) Option {
+	return func(v *Validator) error {
+		v.controlFilter = common.Filter{Include: controls, Exclude: excludeControls}
+		return nil
+	}
+}
@@ -10,11 +10,12 @@ import (
 
 	"github.com/defenseunicorns/go-oscal/src/pkg/files"
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
-	"gopkg.in/yaml.v3"
 
+	"github.com/defenseunicorns/lula/src/pkg/common"
 	"github.com/defenseunicorns/lula/src/pkg/common/composition"
 	"github.com/defenseunicorns/lula/src/pkg/common/oscal"
 	requirementstore "github.com/defenseunicorns/lula/src/pkg/common/requirement-store"
+	"github.com/defenseunicorns/lula/src/pkg/common/result"
 	validationstore "github.com/defenseunicorns/lula/src/pkg/common/validation-store"
 	"github.com/defenseunicorns/lula/src/pkg/message"
 	"github.com/defenseunicorns/lula/src/types"
@@ -27,6 +28,9 @@ type Validator struct {
 	outputsDir                   string
 	saveResources                bool
 	runTests                     bool
+	testReportFormat             types.TestReportFormat
+	tagFilter                    common.Filter
+	controlFilter                common.Filter
 }
 
 func New(opts ...Option) (*Validator, error) {
@@ -90,6 +94,7 @@ func (v *Validator) ValidateOnCompDef(ctx context.Context, compDef *oscalTypes.C
 
 	// Create a validation store from the back-matter if it exists
 	validationStore := validationstore.NewValidationStoreFromBackMatter(*compDef.BackMatter)
+	validationStore.SetTagFilter(v.tagFilter)
 
 	// Create a map of control implementations from the component definition
 	// This combines all same source/framework control implementations into an []Control-Implementation
@@ -142,11 +147,18 @@ func (v *Validator) ValidateOnCompDef(ctx context.Context, compDef *oscalTypes.C
 func (v *Validator) ValidateOnControlImplementations(ctx context.Context, controlImplementations *[]oscalTypes.ControlImplementationSet, validationStore *validationstore.ValidationStore, target string) (map[string]oscalTypes.Finding, []oscalTypes.Observation, error) {
 	// Create requirement store for all implemented requirements
 	requirementStore := requirementstore.NewRequirementStore(controlImplementations)
+	requirementStore.SetControlFilter(v.controlFilter)
 	message.Title("\n🔍 Collecting Requirements and Validations for Target: ", target)
 	requirementStore.ResolveLulaValidations(validationStore)
 	reqtStats := requirementStore.GetStats(validationStore)
 	message.Infof("Found %d Implemented Requirements", reqtStats.TotalRequirements)
-	message.Infof("Found %d runnable Lula Validations", reqtStats.TotalValidations)
+	if reqtStats.ExcludedRequirements > 0 {
+		message.Infof("Excluded %d Implemented Requirements by control, which will not be assessed", reqtStats.ExcludedRequirements)
+	}
+	message.Infof("Found %d runnable Lula Validations", reqtStats.TotalValidations-reqtStats.ExcludedValidations)
+	if reqtStats.ExcludedValidations > 0 {
+		message.Infof("Excluded %d Lula Validations by tag, which will not be assessed", reqtStats.ExcludedValidations)
+	}
 
 	// Check if validations perform execution actions
 	if reqtStats.ExecutableValidations {
@@ -172,8 +184,12 @@ func (v *Validator) ValidateOnControlImplementations(ctx context.Context, contro
 	columnSize := []int{20, 25}
 
 	for id, finding := range findings {
+		state := finding.Target.Status.State
+		if finding.Target.Status.Reason == result.NotAssessed {
+			state = result.NotAssessed
+		}
 		rows = append(rows, []string{
-			id, finding.Target.Status.State,
+			id, state,
 		})
 	}
 
@@ -189,7 +205,11 @@ func (v *Validator) ValidateOnControlImplementations(ctx context.Context, contro
 		message.Info(summary)
 		if !noTestsRun {
 			// Print test results
-			err = writeTestsToYaml(testReportsMap, target, v.outputsDir)
+			format := v.testReportFormat
+			if format == "" {
+				format = types.DefaultTestReportFormat
+			}
+			_, err = WriteTestReports(testReportsMap, format, target, v.outputsDir)
 			if err != nil {
 				message.Warnf("Error writing test results to file: %v", err)
 			}
@@ -199,28 +219,29 @@ func (v *Validator) ValidateOnControlImplementations(ctx context.Context, contro
 	return findings, observations, err
 }
 
-func writeTestsToYaml(testReportsMap map[string]types.LulaValidationTestReport, target, dir string) error {
-	// Create a new markdown file
+// WriteTestReports writes the test reports in the format to a test-results-<target>-<timestamp> file
+// in the directory, returning the path of the file
+func WriteTestReports(testReportsMap map[string]types.LulaValidationTestReport, format types.TestReportFormat, target, dir string) (string, error) {
 	timeStr := time.Now().Format("2006-01-02-15-04-05")
 	targetBase := filepath.Base(target)
 	targetClean := cleanString(targetBase)
 
-	filename := fmt.Sprintf("test-results-%s-%s.yaml", targetClean, timeStr)
+	filename := fmt.Sprintf("test-results-%s-%s%s", targetClean, timeStr, format.Extension())
 	filepath := filepath.Join(dir, filename)
 
-	// Convert testReportsMap to yaml
-	reportYaml, err := yaml.Marshal(testReportsMap)
+	// Convert testReportsMap to the format
+	report, err := types.MarshalTestReports(testReportsMap, format)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// Write yaml to file
-	err = files.WriteOutput(reportYaml, filepath)
+	// Write report to file
+	err = files.WriteOutput(report, filepath)
 	if err != nil {
-		return fmt.Errorf("error writing test results to file: %v", err)
+		return "", fmt.Errorf("error writing test results to file: %v", err)
 	}
 
-	return nil
+	return filepath, nil
 }
 
 func cleanString(input string) string {
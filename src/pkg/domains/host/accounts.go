@@ -0,0 +1,106 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// User is an entry from etc/passwd
+type User struct {
+	Name  string `json:"name"`
+	UID   int    `json:"uid"`
+	GID   int    `json:"gid"`
+	Gecos string `json:"gecos"`
+	Home  string `json:"home"`
+	Shell string `json:"shell"`
+}
+
+// Group is an entry from etc/group
+type Group struct {
+	Name    string   `json:"name"`
+	GID     int      `json:"gid"`
+	Members []string `json:"members"`
+}
+
+func collectUsers(root string) ([]User, error) {
+	records, err := readColonFile(rootPath(root, "etc", "passwd"), 7)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]User, 0, len(records))
+	for _, r := range records {
+		uid, err := strconv.Atoi(r[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid uid for user %s: %w", r[0], err)
+		}
+		gid, err := strconv.Atoi(r[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid gid for user %s: %w", r[0], err)
+		}
+		users = append(users, User{
+			Name:  r[0],
+			UID:   uid,
+			GID:   gid,
+			Gecos: r[4],
+			Home:  r[5],
+			Shell: r[6],
+		})
+	}
+	return users, nil
+}
+
+func collectGroups(root string) ([]Group, error) {
+	records, err := readColonFile(rootPath(root, "etc", "group"), 4)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]Group, 0, len(records))
+	for _, r := range records {
+		gid, err := strconv.Atoi(r[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid gid for group %s: %w", r[0], err)
+		}
+		members := make([]string, 0)
+		for _, m := range strings.Split(r[3], ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				members = append(members, m)
+			}
+		}
+		groups = append(groups, Group{
+			Name:    r[0],
+			GID:     gid,
+			Members: members,
+		})
+	}
+	return groups, nil
+}
+
+// readColonFile reads a colon separated database such as etc/passwd, skipping comments
+// and NIS compat entries
+func readColonFile(path string, fields int) ([][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([][]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "+") || strings.HasPrefix(text, "-") {
+			continue
+		}
+		r := strings.Split(text, ":")
+		if len(r) != fields {
+			return nil, fmt.Errorf("%s:%d: expected %d fields, got %d", path, line, fields, len(r))
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,117 @@
+package host
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a host domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+
+	for _, c := range spec.Collectors {
+		if !slices.Contains(AllCollectors, c) {
+			return nil, fmt.Errorf("unsupported host collector %q", c)
+		}
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources reads the configured host facts from the root filesystem, keyed by collector name.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok {
+		// if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	root := d.Spec.Root
+	switch {
+	case root == "":
+		root = "/"
+	case !filepath.IsAbs(root):
+		root = filepath.Join(workDir, root)
+	}
+
+	collectors := d.Spec.Collectors
+	if len(collectors) == 0 {
+		collectors = AllCollectors
+	}
+
+	var errs error
+	drs := make(types.DomainResources, len(collectors))
+	for _, c := range collectors {
+		resource, err := d.collect(root, c)
+		if err == nil {
+			resource, err = toResource(resource)
+		}
+		if err != nil {
+			// Assign empty data value for reporting purposes
+			drs[string(c)] = map[string]interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("error collecting %s: %w", c, err))
+			continue
+		}
+		drs[string(c)] = resource
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns false; the host domain only reads files.
+func (d Domain) IsExecutable() bool { return false }
+
+func (d Domain) collect(root string, c Collector) (interface{}, error) {
+	switch c {
+	case CollectorSysctl:
+		var keys []string
+		if d.Spec.Sysctl != nil {
+			keys = d.Spec.Sysctl.Keys
+		}
+		return collectSysctl(root, keys)
+	case CollectorUsers:
+		return collectUsers(root)
+	case CollectorGroups:
+		return collectGroups(root)
+	case CollectorPackages:
+		return collectPackages(root)
+	case CollectorSockets:
+		return collectSockets(root)
+	case CollectorSystemd:
+		return collectSystemd(root)
+	case CollectorSshd:
+		return collectSshd(root)
+	default:
+		return nil, fmt.Errorf("unsupported host collector %q", c)
+	}
+}
+
+// toResource round-trips the collected facts through JSON so providers and tests
+// receive plain maps and slices.
+func toResource(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource interface{}
+	err = json.Unmarshal(b, &resource)
+	return resource, err
+}
+
+// rootPath returns the location of an absolute host path under the root filesystem
+func rootPath(root string, path ...string) string {
+	return filepath.Join(append([]string{root}, path...)...)
+}
@@ -0,0 +1,222 @@
+package host
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var _ types.Domain = (*Domain)(nil)
+
+func TestCreateDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *Spec
+		wantErr bool
+	}{
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported collector",
+			spec:    &Spec{Collectors: []Collector{"kernel-modules"}},
+			wantErr: true,
+		},
+		{
+			name: "default collectors",
+			spec: &Spec{},
+		},
+		{
+			name: "valid spec",
+			spec: &Spec{Root: "/mnt/host", Collectors: []Collector{CollectorSysctl, CollectorSshd}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDomain(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResources(t *testing.T) {
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+
+	t.Run("all collectors", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Root: "root"}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"sysctl": map[string]interface{}{
+				"runtime": map[string]interface{}{
+					"kernel.randomize_va_space": "2",
+					"net.ipv4.ip_forward":       "1",
+					"net.ipv4.tcp_rmem":         "4096 16384 4194304",
+				},
+				"configured": map[string]interface{}{
+					"kernel.randomize_va_space": "2",
+					"net.ipv4.ip_forward":       "1",
+					"net.ipv4.tcp_syncookies":   "1",
+				},
+			},
+			"users": []interface{}{
+				map[string]interface{}{"name": "root", "uid": 0.0, "gid": 0.0, "gecos": "root", "home": "/root", "shell": "/bin/bash"},
+				map[string]interface{}{"name": "daemon", "uid": 1.0, "gid": 1.0, "gecos": "daemon", "home": "/usr/sbin", "shell": "/usr/sbin/nologin"},
+				map[string]interface{}{"name": "alice", "uid": 1000.0, "gid": 1000.0, "gecos": "Alice,,,", "home": "/home/alice", "shell": "/bin/bash"},
+			},
+			"groups": []interface{}{
+				map[string]interface{}{"name": "root", "gid": 0.0, "members": []interface{}{}},
+				map[string]interface{}{"name": "sudo", "gid": 27.0, "members": []interface{}{"alice"}},
+				map[string]interface{}{"name": "users", "gid": 100.0, "members": []interface{}{"alice", "bob"}},
+			},
+			"packages": []interface{}{
+				map[string]interface{}{"name": "openssh-server", "version": "1:9.2p1-2+deb12u3", "arch": "amd64", "manager": "dpkg"},
+				map[string]interface{}{"name": "bash", "version": "5.2.15-2+b7", "arch": "amd64", "manager": "dpkg"},
+			},
+			"sockets": []interface{}{
+				map[string]interface{}{"protocol": "tcp", "address": "0.0.0.0", "port": 22.0, "uid": 0.0, "inode": "18431"},
+				map[string]interface{}{"protocol": "tcp", "address": "127.0.0.1", "port": 3306.0, "uid": 999.0, "inode": "20112"},
+				map[string]interface{}{"protocol": "tcp6", "address": "::", "port": 22.0, "uid": 0.0, "inode": "18433"},
+				map[string]interface{}{"protocol": "tcp6", "address": "::1", "port": 631.0, "uid": 0.0, "inode": "18500"},
+				map[string]interface{}{"protocol": "udp", "address": "127.0.0.53", "port": 53.0, "uid": 101.0, "inode": "17021"},
+			},
+			"systemd": map[string]interface{}{
+				"ctrl-alt-del.target": map[string]interface{}{
+					"path": "/etc/systemd/system/ctrl-alt-del.target", "state": "masked", "wanted-by": []interface{}{},
+				},
+				"debug-shell.service": map[string]interface{}{
+					"path": "/lib/systemd/system/debug-shell.service", "state": "disabled", "wanted-by": []interface{}{},
+					"description": "Early root shell on /dev/tty9 for debugging",
+				},
+				"getty@.service": map[string]interface{}{
+					"path": "/lib/systemd/system/getty@.service", "state": "enabled", "wanted-by": []interface{}{"getty.target"},
+					"description": "Getty on %I",
+				},
+				"ssh.service": map[string]interface{}{
+					"path": "/lib/systemd/system/ssh.service", "state": "enabled", "wanted-by": []interface{}{"multi-user.target"},
+					"description": "OpenBSD Secure Shell server",
+				},
+				"systemd-journald.service": map[string]interface{}{
+					"path": "/lib/systemd/system/systemd-journald.service", "state": "static", "wanted-by": []interface{}{},
+					"description": "Journal Service",
+				},
+			},
+			"sshd": map[string]interface{}{
+				"config": map[string]interface{}{
+					"permitrootlogin":        "no",
+					"x11forwarding":          "no",
+					"port":                   []interface{}{"22"},
+					"passwordauthentication": "no",
+					"hostkey":                []interface{}{"/etc/ssh/ssh_host_ed25519_key", "/etc/ssh/ssh_host_rsa_key"},
+					"subsystem":              []interface{}{"sftp /usr/lib/openssh/sftp-server"},
+				},
+				"match": []interface{}{
+					map[string]interface{}{
+						"criteria": "User backup",
+						"config":   map[string]interface{}{"forcecommand": "internal-sftp", "permittty": "no"},
+					},
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("sysctl keys", func(t *testing.T) {
+		d := Domain{Spec: &Spec{
+			Root:       "root",
+			Collectors: []Collector{CollectorSysctl},
+			Sysctl:     &SysctlOpts{Keys: []string{"net.ipv4.ip_forward", "net/ipv4/tcp_rmem", "vm.swappiness"}},
+		}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"net.ipv4.ip_forward": "1",
+			"net.ipv4.tcp_rmem":   "4096 16384 4194304",
+		}, resources["sysctl"].(map[string]interface{})["runtime"])
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		d := Domain{Spec: &Spec{
+			Root:       t.TempDir(),
+			Collectors: []Collector{CollectorUsers, CollectorSockets},
+		}}
+
+		resources, err := d.GetResources(ctx)
+		require.Error(t, err)
+		require.Equal(t, map[string]interface{}{}, resources["users"])
+		require.Equal(t, []interface{}{}, resources["sockets"])
+	})
+}
+
+func TestReadRpmSqlite(t *testing.T) {
+	root := t.TempDir()
+	dbPath := filepath.Join(root, "var", "lib", "rpm", "rpmdb.sqlite")
+	require.NoError(t, os.MkdirAll(filepath.Dir(dbPath), 0o755))
+
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")
+	require.NoError(t, err)
+	for _, blob := range [][]byte{
+		rpmHeaderBlob(map[uint32]string{rpmTagName: "openssl", rpmTagVersion: "3.0.7", rpmTagRelease: "27.el9", rpmTagArch: "x86_64"}, 1),
+		rpmHeaderBlob(map[uint32]string{rpmTagName: "bash", rpmTagVersion: "5.1.8", rpmTagRelease: "9.el9", rpmTagArch: "x86_64"}, 0),
+		rpmHeaderBlob(map[uint32]string{rpmTagName: "gpg-pubkey", rpmTagVersion: "fd431d51", rpmTagRelease: "4ae0493b"}, 0),
+	} {
+		_, err = db.Exec("INSERT INTO Packages (blob) VALUES (?)", blob)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	packages, err := collectPackages(root)
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{Name: "openssl", Version: "1:3.0.7-27.el9", Arch: "x86_64", Manager: "rpm"},
+		{Name: "bash", Version: "5.1.8-9.el9", Arch: "x86_64", Manager: "rpm"},
+	}, packages)
+}
+
+// rpmHeaderBlob builds a minimal header blob with string tags and an optional epoch
+func rpmHeaderBlob(tags map[uint32]string, epoch int32) []byte {
+	var index, data []byte
+	entry := func(tag, typ uint32, offset int) {
+		e := make([]byte, 16)
+		binary.BigEndian.PutUint32(e[0:], tag)
+		binary.BigEndian.PutUint32(e[4:], typ)
+		binary.BigEndian.PutUint32(e[8:], uint32(offset))
+		binary.BigEndian.PutUint32(e[12:], 1)
+		index = append(index, e...)
+	}
+	if epoch > 0 {
+		entry(rpmTagEpoch, rpmTypeInt32, len(data))
+		data = binary.BigEndian.AppendUint32(data, uint32(epoch))
+	}
+	for _, tag := range []uint32{rpmTagName, rpmTagVersion, rpmTagRelease, rpmTagArch} {
+		if v, ok := tags[tag]; ok {
+			entry(tag, rpmTypeString, len(data))
+			data = append(append(data, v...), 0)
+		}
+	}
+
+	blob := binary.BigEndian.AppendUint32(nil, uint32(len(index)/16))
+	blob = binary.BigEndian.AppendUint32(blob, uint32(len(data)))
+	return append(append(blob, index...), data...)
+}
@@ -0,0 +1,263 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	// registers the pure Go "sqlite" driver used to read rpmdb.sqlite
+	_ "modernc.org/sqlite"
+)
+
+// Package is an installed package from one of the supported package databases
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Arch    string `json:"arch,omitempty"`
+	Manager string `json:"manager"`
+}
+
+var ErrUnsupportedRpmDB = errors.New("rpm Berkeley DB databases are not supported, only rpmdb.sqlite")
+
+// rpmSqliteDBs are the known locations of the sqlite rpm database
+var rpmSqliteDBs = []string{
+	"var/lib/rpm/rpmdb.sqlite",
+	"usr/lib/sysimage/rpm/rpmdb.sqlite",
+}
+
+// collectPackages returns the packages from every package database present under root
+func collectPackages(root string) ([]Package, error) {
+	packages := make([]Package, 0)
+
+	dpkg, err := readDpkgStatus(rootPath(root, "var", "lib", "dpkg", "status"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	packages = append(packages, dpkg...)
+
+	apk, err := readApkInstalled(rootPath(root, "lib", "apk", "db", "installed"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	packages = append(packages, apk...)
+
+	foundRpm := false
+	for _, db := range rpmSqliteDBs {
+		path := rootPath(root, db)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		rpm, err := readRpmSqlite(path)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, rpm...)
+		foundRpm = true
+		break
+	}
+	if !foundRpm {
+		if _, err := os.Stat(rootPath(root, "var", "lib", "rpm", "Packages")); err == nil {
+			return nil, ErrUnsupportedRpmDB
+		}
+	}
+
+	return packages, nil
+}
+
+// readDpkgStatus parses the dpkg status database, returning only installed packages
+func readDpkgStatus(path string) ([]Package, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]Package, 0)
+	for _, stanza := range readStanzas(data) {
+		if !strings.HasSuffix(stanza["Status"], " installed") {
+			continue
+		}
+		packages = append(packages, Package{
+			Name:    stanza["Package"],
+			Version: stanza["Version"],
+			Arch:    stanza["Architecture"],
+			Manager: "dpkg",
+		})
+	}
+	return packages, nil
+}
+
+// readApkInstalled parses the Alpine apk installed database
+func readApkInstalled(path string) ([]Package, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]Package, 0)
+	for _, stanza := range readStanzas(data) {
+		packages = append(packages, Package{
+			Name:    stanza["P"],
+			Version: stanza["V"],
+			Arch:    stanza["A"],
+			Manager: "apk",
+		})
+	}
+	return packages, nil
+}
+
+// readStanzas splits blank-line separated "Key: value" records; continuation lines
+// (leading whitespace) are ignored as none of the collected fields span lines
+func readStanzas(data []byte) []map[string]string {
+	stanzas := make([]map[string]string, 0)
+	current := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				stanzas = append(stanzas, current)
+				current = make(map[string]string)
+			}
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		if _, exists := current[key]; !exists {
+			current[key] = strings.TrimSpace(value)
+		}
+	}
+	if len(current) > 0 {
+		stanzas = append(stanzas, current)
+	}
+	return stanzas
+}
+
+// readRpmSqlite reads the package headers stored in an rpmdb.sqlite database
+func readRpmSqlite(path string) ([]Package, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=query_only(1)")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT blob FROM Packages")
+	if err != nil {
+		return nil, fmt.Errorf("error reading rpm database: %w", err)
+	}
+	defer rows.Close()
+
+	packages := make([]Package, 0)
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, err
+		}
+		h, err := parseRpmHeader(blob)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing rpm header: %w", err)
+		}
+		name := h.str(rpmTagName)
+		if name == "gpg-pubkey" {
+			// imported signing keys are stored as pseudo-packages
+			continue
+		}
+		version := h.str(rpmTagVersion)
+		if release := h.str(rpmTagRelease); release != "" {
+			version += "-" + release
+		}
+		if epoch, ok := h.int32(rpmTagEpoch); ok && epoch > 0 {
+			version = strconv.Itoa(int(epoch)) + ":" + version
+		}
+		packages = append(packages, Package{
+			Name:    name,
+			Version: version,
+			Arch:    h.str(rpmTagArch),
+			Manager: "rpm",
+		})
+	}
+	return packages, rows.Err()
+}
+
+const (
+	rpmTagName    = 1000
+	rpmTagVersion = 1001
+	rpmTagRelease = 1002
+	rpmTagEpoch   = 1003
+	rpmTagArch    = 1022
+
+	rpmTypeInt32  = 4
+	rpmTypeString = 6
+)
+
+type rpmEntry struct {
+	typ    uint32
+	offset uint32
+	count  uint32
+}
+
+// rpmHeader is a parsed header blob: a big-endian index count and data length, the
+// 16 byte index entries, then the data store the entries point into
+type rpmHeader struct {
+	entries map[uint32]rpmEntry
+	data    []byte
+}
+
+func parseRpmHeader(blob []byte) (*rpmHeader, error) {
+	if len(blob) < 8 {
+		return nil, fmt.Errorf("header too short")
+	}
+	il := binary.BigEndian.Uint32(blob[0:4])
+	dl := binary.BigEndian.Uint32(blob[4:8])
+	dataStart := 8 + uint64(il)*16
+	if dataStart+uint64(dl) > uint64(len(blob)) {
+		return nil, fmt.Errorf("header length exceeds blob size")
+	}
+
+	h := &rpmHeader{
+		entries: make(map[uint32]rpmEntry, il),
+		data:    blob[dataStart : dataStart+uint64(dl)],
+	}
+	for i := uint64(0); i < uint64(il); i++ {
+		e := blob[8+i*16 : 8+(i+1)*16]
+		h.entries[binary.BigEndian.Uint32(e[0:4])] = rpmEntry{
+			typ:    binary.BigEndian.Uint32(e[4:8]),
+			offset: binary.BigEndian.Uint32(e[8:12]),
+			count:  binary.BigEndian.Uint32(e[12:16]),
+		}
+	}
+	return h, nil
+}
+
+func (h *rpmHeader) str(tag uint32) string {
+	e, ok := h.entries[tag]
+	if !ok || e.typ != rpmTypeString || int(e.offset) >= len(h.data) {
+		return ""
+	}
+	s := h.data[e.offset:]
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return string(s)
+}
+
+func (h *rpmHeader) int32(tag uint32) (int32, bool) {
+	e, ok := h.entries[tag]
+	if !ok || e.typ != rpmTypeInt32 || e.count == 0 || int(e.offset)+4 > len(h.data) {
+		return 0, false
+	}
+	return int32(binary.BigEndian.Uint32(h.data[e.offset : e.offset+4])), true
+}
@@ -0,0 +1,111 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// tcpListen is the kernel TCP_LISTEN state
+	tcpListen = "0A"
+	// udpUnconnected is TCP_CLOSE, which proc/net/udp reports for unconnected (bound) sockets
+	udpUnconnected = "07"
+)
+
+// Socket is a listening socket from proc/net
+type Socket struct {
+	Protocol string `json:"protocol"`
+	Address  string `json:"address"`
+	Port     int    `json:"port"`
+	UID      int    `json:"uid"`
+	Inode    string `json:"inode"`
+}
+
+func collectSockets(root string) ([]Socket, error) {
+	sockets := make([]Socket, 0)
+	for _, proto := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		data, err := os.ReadFile(rootPath(root, "proc", "net", proto))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		s, err := parseProcNet(proto, data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proc/net/%s: %w", proto, err)
+		}
+		sockets = append(sockets, s...)
+	}
+	return sockets, nil
+}
+
+// parseProcNet returns the listening sockets from a proc/net/{tcp,udp}[6] table
+func parseProcNet(proto string, data []byte) ([]Socket, error) {
+	listenState := tcpListen
+	if strings.HasPrefix(proto, "udp") {
+		listenState = udpUnconnected
+	}
+
+	sockets := make([]Socket, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// skip the header line
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		if fields[3] != listenState {
+			continue
+		}
+		address, port, err := parseProcNetAddress(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		uid, err := strconv.Atoi(fields[7])
+		if err != nil {
+			return nil, fmt.Errorf("invalid uid %q: %w", fields[7], err)
+		}
+		sockets = append(sockets, Socket{
+			Protocol: proto,
+			Address:  address,
+			Port:     port,
+			UID:      uid,
+			Inode:    fields[9],
+		})
+	}
+	return sockets, scanner.Err()
+}
+
+// parseProcNetAddress decodes an "ADDR:PORT" pair; the address is stored as host-order
+// (little-endian) 32 bit words and the port as big-endian hex
+func parseProcNetAddress(s string) (string, int, error) {
+	addrHex, portHex, found := strings.Cut(s, ":")
+	if !found {
+		return "", 0, fmt.Errorf("invalid address %q", s)
+	}
+	raw, err := hex.DecodeString(addrHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, fmt.Errorf("invalid address %q", s)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q", s)
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+	return ip.String(), int(port), nil
+}
@@ -0,0 +1,43 @@
+package host
+
+// Collector is a category of host facts
+type Collector string
+
+const (
+	CollectorSysctl   Collector = "sysctl"
+	CollectorUsers    Collector = "users"
+	CollectorGroups   Collector = "groups"
+	CollectorPackages Collector = "packages"
+	CollectorSockets  Collector = "sockets"
+	CollectorSystemd  Collector = "systemd"
+	CollectorSshd     Collector = "sshd"
+)
+
+// AllCollectors is the default set of collectors when none are specified
+var AllCollectors = []Collector{
+	CollectorSysctl,
+	CollectorUsers,
+	CollectorGroups,
+	CollectorPackages,
+	CollectorSockets,
+	CollectorSystemd,
+	CollectorSshd,
+}
+
+// Spec is the user-defined specification for the host domain
+type Spec struct {
+	// Root is the root filesystem the facts are read from, defaults to "/".
+	// Relative paths are resolved against the validation directory.
+	Root string `json:"root,omitempty" yaml:"root,omitempty"`
+	// Collectors is the list of facts to collect, defaults to all collectors
+	Collectors []Collector `json:"collectors,omitempty" yaml:"collectors,omitempty"`
+	// Sysctl contains optional settings for the sysctl collector
+	Sysctl *SysctlOpts `json:"sysctl,omitempty" yaml:"sysctl,omitempty"`
+}
+
+// SysctlOpts limits the runtime sysctl values that are read
+type SysctlOpts struct {
+	// Keys is the list of sysctl keys (e.g. net.ipv4.ip_forward) to read from proc/sys.
+	// If empty, all readable keys are returned.
+	Keys []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
@@ -0,0 +1,151 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// maxIncludeDepth bounds recursive Include directives
+const maxIncludeDepth = 16
+
+// sshdRepeatable are the keywords that may be given multiple times and accumulate;
+// every other keyword keeps its first value, as sshd does.
+var sshdRepeatable = map[string]bool{
+	"acceptenv":       true,
+	"allowgroups":     true,
+	"allowusers":      true,
+	"denygroups":      true,
+	"denyusers":       true,
+	"hostcertificate": true,
+	"hostkey":         true,
+	"listenaddress":   true,
+	"permitlisten":    true,
+	"permitopen":      true,
+	"port":            true,
+	"setenv":          true,
+	"subsystem":       true,
+}
+
+// Sshd is the parsed sshd configuration. Keywords are lower-cased; repeatable keywords are
+// lists and all others are strings.
+type Sshd struct {
+	Config map[string]interface{} `json:"config"`
+	Match  []SshdMatch            `json:"match"`
+}
+
+// SshdMatch is a conditional Match block
+type SshdMatch struct {
+	Criteria string                 `json:"criteria"`
+	Config   map[string]interface{} `json:"config"`
+}
+
+func collectSshd(root string) (*Sshd, error) {
+	sshd := &Sshd{
+		Config: make(map[string]interface{}),
+		Match:  make([]SshdMatch, 0),
+	}
+	p := sshdParser{root: root, sshd: sshd, current: sshd.Config}
+	if err := p.parseFile("/etc/ssh/sshd_config", 0); err != nil {
+		return nil, err
+	}
+	return sshd, nil
+}
+
+type sshdParser struct {
+	root    string
+	sshd    *Sshd
+	current map[string]interface{}
+}
+
+func (p *sshdParser) parseFile(file string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("too many nested includes at %s", file)
+	}
+	data, err := os.ReadFile(rootPath(p.root, file))
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value := splitSshdLine(line)
+		switch key {
+		case "include":
+			for _, pattern := range strings.Fields(value) {
+				if err := p.include(pattern, depth); err != nil {
+					return err
+				}
+			}
+		case "match":
+			match := SshdMatch{Criteria: value, Config: make(map[string]interface{})}
+			p.sshd.Match = append(p.sshd.Match, match)
+			p.current = match.Config
+			if strings.EqualFold(value, "all") {
+				// "Match all" ends the conditional block
+				p.current = p.sshd.Config
+			}
+		default:
+			setSshdValue(p.current, key, value)
+		}
+	}
+	return scanner.Err()
+}
+
+// include parses the files matching an Include pattern; relative patterns are relative to /etc/ssh
+func (p *sshdParser) include(pattern string, depth int) error {
+	if !path.IsAbs(pattern) {
+		pattern = path.Join("/etc/ssh", pattern)
+	}
+	matches, err := filepath.Glob(rootPath(p.root, pattern))
+	if err != nil {
+		return err
+	}
+	for _, m := range matches {
+		rel, err := filepath.Rel(p.root, m)
+		if err != nil {
+			return err
+		}
+		if err := p.parseFile("/"+filepath.ToSlash(rel), depth+1); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitSshdLine splits "Keyword value" or "Keyword=value" and lower-cases the keyword
+func splitSshdLine(line string) (string, string) {
+	i := strings.IndexAny(line, " \t=")
+	if i < 0 {
+		return strings.ToLower(line), ""
+	}
+	key := strings.ToLower(line[:i])
+	value := strings.TrimLeft(line[i:], " \t")
+	value = strings.TrimPrefix(value, "=")
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+	return key, value
+}
+
+func setSshdValue(config map[string]interface{}, key, value string) {
+	if sshdRepeatable[key] {
+		values, _ := config[key].([]string)
+		config[key] = append(values, value)
+		return
+	}
+	if _, exists := config[key]; !exists {
+		config[key] = value
+	}
+}
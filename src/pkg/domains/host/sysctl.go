@@ -0,0 +1,160 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// sysctlDirs are the sysctl.d directories in order of precedence; a file in an earlier
+// directory masks a file with the same name in a later one.
+var sysctlDirs = []string{
+	"etc/sysctl.d",
+	"run/sysctl.d",
+	"usr/local/lib/sysctl.d",
+	"usr/lib/sysctl.d",
+	"lib/sysctl.d",
+}
+
+// Sysctl holds the runtime kernel parameters and the values configured to be applied at boot
+type Sysctl struct {
+	Runtime    map[string]string `json:"runtime"`
+	Configured map[string]string `json:"configured"`
+}
+
+func collectSysctl(root string, keys []string) (*Sysctl, error) {
+	runtime, err := readRuntimeSysctl(root, keys)
+	if err != nil {
+		return nil, err
+	}
+	configured, err := readConfiguredSysctl(root)
+	if err != nil {
+		return nil, err
+	}
+	return &Sysctl{Runtime: runtime, Configured: configured}, nil
+}
+
+// readRuntimeSysctl reads values from proc/sys, either the requested keys or every readable entry
+func readRuntimeSysctl(root string, keys []string) (map[string]string, error) {
+	procSys := rootPath(root, "proc", "sys")
+	values := make(map[string]string)
+
+	if len(keys) > 0 {
+		for _, key := range keys {
+			data, err := os.ReadFile(filepath.Join(procSys, filepath.FromSlash(sysctlKeyToPath(key))))
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+					continue
+				}
+				return nil, err
+			}
+			values[normalizeSysctlKey(key)] = sysctlValue(data)
+		}
+		return values, nil
+	}
+
+	if _, err := os.Stat(procSys); errors.Is(err, fs.ErrNotExist) {
+		return values, nil
+	}
+
+	err := filepath.WalkDir(procSys, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// unreadable subtrees are skipped rather than failing the collection
+			if d != nil && d.IsDir() && path != procSys {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			// write-only and permission-restricted entries are expected in proc/sys
+			return nil
+		}
+		rel, err := filepath.Rel(procSys, path)
+		if err != nil {
+			return err
+		}
+		values[strings.ReplaceAll(filepath.ToSlash(rel), "/", ".")] = sysctlValue(data)
+		return nil
+	})
+	return values, err
+}
+
+// readConfiguredSysctl merges the sysctl.d drop-ins in lexical order followed by etc/sysctl.conf,
+// matching the order systemd-sysctl applies them.
+func readConfiguredSysctl(root string) (map[string]string, error) {
+	files := make(map[string]string)
+	for i := len(sysctlDirs) - 1; i >= 0; i-- {
+		matches, err := filepath.Glob(filepath.Join(rootPath(root, sysctlDirs[i]), "*.conf"))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			files[filepath.Base(m)] = m
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	paths := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		paths = append(paths, files[name])
+	}
+	paths = append(paths, rootPath(root, "etc", "sysctl.conf"))
+
+	values := make(map[string]string)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		parseSysctlConf(data, values)
+	}
+	return values, nil
+}
+
+func parseSysctlConf(data []byte, values map[string]string) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		// a leading "-" only suppresses errors when the key cannot be applied
+		key = strings.TrimPrefix(strings.TrimSpace(key), "-")
+		values[normalizeSysctlKey(key)] = strings.TrimSpace(value)
+	}
+}
+
+// normalizeSysctlKey converts slash separated keys (e.g. net/ipv4/ip_forward) to the dotted form
+func normalizeSysctlKey(key string) string {
+	return strings.ReplaceAll(strings.TrimSpace(key), "/", ".")
+}
+
+func sysctlKeyToPath(key string) string {
+	return strings.ReplaceAll(strings.TrimSpace(key), ".", "/")
+}
+
+// sysctlValue trims the trailing newline and collapses the tab separated fields some entries use
+func sysctlValue(data []byte) string {
+	return strings.Join(strings.Fields(string(data)), " ")
+}
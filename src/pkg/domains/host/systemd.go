@@ -0,0 +1,205 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// systemdUnitDirs are the unit search paths in order of precedence; a unit in an earlier
+// directory masks a unit with the same name in a later one.
+var systemdUnitDirs = []string{
+	"/etc/systemd/system",
+	"/run/systemd/system",
+	"/usr/local/lib/systemd/system",
+	"/usr/lib/systemd/system",
+	"/lib/systemd/system",
+}
+
+var systemdUnitSuffixes = []string{
+	".service", ".socket", ".timer", ".target", ".mount", ".automount",
+	".path", ".slice", ".scope", ".swap", ".device",
+}
+
+const (
+	UnitStateEnabled  = "enabled"
+	UnitStateDisabled = "disabled"
+	UnitStateStatic   = "static"
+	UnitStateMasked   = "masked"
+)
+
+// Unit is the install state of a systemd unit file
+type Unit struct {
+	Path        string   `json:"path"`
+	State       string   `json:"state"`
+	Description string   `json:"description,omitempty"`
+	WantedBy    []string `json:"wanted-by"`
+}
+
+// collectSystemd returns the unit files keyed by unit name. The state is derived from the
+// files on disk (as `systemctl is-enabled` would), not from the running service manager.
+func collectSystemd(root string) (map[string]Unit, error) {
+	units := make(map[string]Unit)
+	for _, dir := range systemdUnitDirs {
+		entries, err := os.ReadDir(rootPath(root, dir))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if _, exists := units[name]; exists || e.IsDir() || !isUnitName(name) {
+				continue
+			}
+			unit, err := readUnit(root, dir, name)
+			if err != nil {
+				return nil, err
+			}
+			units[name] = unit
+		}
+	}
+
+	wants, err := readWants(root)
+	if err != nil {
+		return nil, err
+	}
+	for name, unit := range units {
+		wantedBy := wants[name]
+		if unit.State != UnitStateMasked && len(wantedBy) > 0 {
+			unit.State = UnitStateEnabled
+		}
+		sort.Strings(wantedBy)
+		unit.WantedBy = append(make([]string, 0, len(wantedBy)), wantedBy...)
+		units[name] = unit
+	}
+	return units, nil
+}
+
+// readUnit determines the static/disabled/masked state of a unit, following links that
+// point elsewhere under the root filesystem
+func readUnit(root, dir, name string) (Unit, error) {
+	unitPath := path.Join(dir, name)
+	unit := Unit{Path: unitPath}
+
+	target := unitPath
+	if link, err := os.Readlink(rootPath(root, unitPath)); err == nil {
+		if link == "/dev/null" {
+			unit.State = UnitStateMasked
+			return unit, nil
+		}
+		if !path.IsAbs(link) {
+			link = path.Join(dir, link)
+		}
+		target = link
+	}
+
+	data, err := os.ReadFile(rootPath(root, target))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// dangling link, the unit cannot be loaded
+			unit.State = UnitStateDisabled
+			return unit, nil
+		}
+		return unit, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		// empty unit files are treated as masked
+		unit.State = UnitStateMasked
+		return unit, nil
+	}
+
+	sections := parseUnitFile(data)
+	unit.Description = sections["Unit"]["Description"]
+	unit.State = UnitStateStatic
+	for _, key := range []string{"WantedBy", "RequiredBy", "UpheldBy", "Alias", "Also"} {
+		if sections["Install"][key] != "" {
+			unit.State = UnitStateDisabled
+			break
+		}
+	}
+	return unit, nil
+}
+
+// readWants maps unit names to the units that pull them in through the
+// .wants/.requires/.upholds links created by `systemctl enable`
+func readWants(root string) (map[string][]string, error) {
+	wants := make(map[string][]string)
+	for _, dir := range []string{"/etc/systemd/system", "/run/systemd/system"} {
+		entries, err := os.ReadDir(rootPath(root, dir))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			ext := path.Ext(e.Name())
+			if !e.IsDir() || (ext != ".wants" && ext != ".requires" && ext != ".upholds") {
+				continue
+			}
+			by := strings.TrimSuffix(e.Name(), ext)
+			links, err := os.ReadDir(rootPath(root, dir, e.Name()))
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range links {
+				name := l.Name()
+				wants[name] = append(wants[name], by)
+				// enabling an instance (getty@tty1.service) enables the template (getty@.service)
+				if prefix, suffix, found := strings.Cut(name, "@"); found && !strings.HasPrefix(suffix, ".") {
+					template := prefix + "@" + path.Ext(name)
+					wants[template] = append(wants[template], by)
+				}
+			}
+		}
+	}
+	return wants, nil
+}
+
+// parseUnitFile returns the keys of each section; repeated keys are joined with a space
+func parseUnitFile(data []byte) map[string]map[string]string {
+	sections := make(map[string]map[string]string)
+	var current map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			current = sections[name]
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || current == nil {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if existing := current[key]; existing != "" && value != "" {
+			value = existing + " " + value
+		}
+		current[key] = value
+	}
+	return sections
+}
+
+func isUnitName(name string) bool {
+	for _, suffix := range systemdUnitSuffixes {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -85,7 +85,7 @@ func GetResourcesDynamically(ctx context.Context, cluster *Cluster, resource *Re
 
 		// If field is specified, get the field data; can only occur when a single named resource is specified
 		if resource.Field != nil && resource.Field.Jsonpath != "" {
-			item, err = getFieldValue(item, resource.Field)
+			item, err = GetFieldValue(item, resource.Field)
 			if err != nil {
 				return nil, err
 			}
@@ -111,8 +111,8 @@ func GetResourcesDynamically(ctx context.Context, cluster *Cluster, resource *Re
 	return collection, nil
 }
 
-// getFieldValue() looks up the field from a resource and returns a map[string]interface{} representation of the data
-func getFieldValue(item map[string]interface{}, field *Field) (map[string]interface{}, error) {
+// GetFieldValue() looks up the field from a resource and returns a map[string]interface{} representation of the data
+func GetFieldValue(item map[string]interface{}, field *Field) (map[string]interface{}, error) {
 	if field == nil {
 		return nil, fmt.Errorf("field is nil")
 	}
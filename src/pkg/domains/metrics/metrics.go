@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var defaultTimeout = 10 * time.Second
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a metrics domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+	if len(spec.Scrapes) == 0 && len(spec.Queries) == 0 {
+		return nil, fmt.Errorf("metrics-spec must contain at least one scrape or query")
+	}
+
+	names := make(map[string]bool, len(spec.Scrapes)+len(spec.Queries))
+	validate := func(kind, name, rawURL, timeout string) error {
+		if name == "" {
+			return fmt.Errorf("%s name cannot be empty", kind)
+		}
+		if names[name] {
+			return fmt.Errorf("%s name %s must be unique", kind, name)
+		}
+		names[name] = true
+		if _, err := parseURL(rawURL); err != nil {
+			return fmt.Errorf("%s %s: %w", kind, name, err)
+		}
+		if timeout != "" {
+			if _, err := time.ParseDuration(timeout); err != nil {
+				return fmt.Errorf("%s %s has invalid timeout %s: %w", kind, name, timeout, err)
+			}
+		}
+		return nil
+	}
+
+	for _, s := range spec.Scrapes {
+		if err := validate("scrape", s.Name, s.URL, s.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	for _, q := range spec.Queries {
+		if err := validate("query", q.Name, q.URL, q.Timeout); err != nil {
+			return nil, err
+		}
+		if q.Query == "" {
+			return nil, fmt.Errorf("query %s must specify a query", q.Name)
+		}
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources scrapes each endpoint and runs each query, returning the series keyed by name.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	var errs error
+	drs := make(types.DomainResources, len(d.Spec.Scrapes)+len(d.Spec.Queries))
+
+	collect := func(name string, series []Series, err error) {
+		var resource []interface{}
+		if err == nil {
+			resource, err = toResource(series)
+		}
+		if err != nil {
+			// Assign empty data value for reporting purposes
+			drs[name] = []interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("error collecting metrics for %s: %w", name, err))
+			return
+		}
+		drs[name] = resource
+	}
+
+	for _, s := range d.Spec.Scrapes {
+		series, err := scrape(ctx, s)
+		collect(s.Name, series, err)
+	}
+	for _, q := range d.Spec.Queries {
+		series, err := query(ctx, q)
+		collect(q.Name, series, err)
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns false; scrapes and instant queries are read-only requests.
+func (d Domain) IsExecutable() bool { return false }
+
+func parseURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("url must use http or https")
+	}
+	return u, nil
+}
+
+// get performs a GET request and returns the body of a successful response
+func get(ctx context.Context, u *url.URL, accept string, headers map[string]string, timeout string) ([]byte, error) {
+	client := http.Client{Timeout: defaultTimeout}
+	if timeout != "" {
+		client.Timeout, _ = time.ParseDuration(timeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	message.Debugf("GET %s", u.Redacted())
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		// The Prometheus API returns error details in the body, which is handled by the caller
+		return body, statusError{status: res.Status}
+	}
+	return body, nil
+}
+
+type statusError struct {
+	status string
+}
+
+func (e statusError) Error() string { return fmt.Sprintf("unexpected response status %s", e.status) }
+
+// sampleValue returns finite values as numbers, and NaN and infinities in their Prometheus string form
+func sampleValue(v float64) interface{} {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return v
+}
+
+// formatFloat formats a float as Prometheus does for label values such as le and quantile
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	if math.IsInf(v, -1) {
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func timestamp(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339Nano)
+}
+
+// toResource converts the series into plain JSON values
+func toResource(series []Series) ([]interface{}, error) {
+	data, err := json.Marshal(series)
+	if err != nil {
+		return nil, err
+	}
+	resource := []interface{}{}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
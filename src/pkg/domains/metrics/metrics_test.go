@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var _ types.Domain = (*Domain)(nil)
+
+func TestCreateDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *Spec
+		wantErr bool
+	}{
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty spec",
+			spec:    &Spec{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid url",
+			spec:    &Spec{Scrapes: []Scrape{{Name: "api", URL: "localhost:9090/metrics"}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			spec: &Spec{
+				Scrapes: []Scrape{{Name: "api", URL: "http://localhost/metrics"}},
+				Queries: []Query{{Name: "api", URL: "http://prometheus:9090", Query: "up"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing query",
+			spec:    &Spec{Queries: []Query{{Name: "up", URL: "http://prometheus:9090"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout",
+			spec:    &Spec{Scrapes: []Scrape{{Name: "api", URL: "http://localhost/metrics", Timeout: "ten"}}},
+			wantErr: true,
+		},
+		{
+			name: "valid spec",
+			spec: &Spec{
+				Scrapes: []Scrape{{Name: "api", URL: "https://localhost/metrics", Metrics: []string{"up"}, Timeout: "5s"}},
+				Queries: []Query{{Name: "up", URL: "http://prometheus:9090", Query: "up", Headers: map[string]string{"Authorization": "Bearer token"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDomain(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResources(t *testing.T) {
+	text, err := os.ReadFile("testdata/metrics.txt")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write(text)
+	})
+	mux.HandleFunc("/prometheus/api/v1/query", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("query") {
+		case "sum by (plugin) (apiserver_audit_error_total)":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"plugin":"log"},"value":[1717236000.5,"0"]},` +
+				`{"metric":{"plugin":"webhook"},"value":[1717236000.5,"2"]}]}}`))
+		case "scalar(up)":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1717236000,"1"]}}`))
+		case "up[5m]":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("scrape", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Scrapes: []Scrape{
+			{Name: "all", URL: server.URL + "/metrics", Headers: map[string]string{"Authorization": "Bearer token"}},
+			{Name: "audit", URL: server.URL + "/metrics", Headers: map[string]string{"Authorization": "Bearer token"}, Metrics: []string{"apiserver_audit_error_total"}},
+		}}}
+
+		resources, err := d.GetResources(context.Background())
+		require.NoError(t, err)
+
+		want := []interface{}{
+			series("apiserver_audit_error_total", "counter", map[string]interface{}{"plugin": "log"}, 0.0, ""),
+			series("apiserver_audit_error_total", "counter", map[string]interface{}{"plugin": "webhook"}, 2.0, ""),
+		}
+		if diff := cmp.Diff(want, resources["audit"]); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+
+		want = []interface{}{
+			series("apiserver_audit_error_total", "counter", map[string]interface{}{"plugin": "log"}, 0.0, ""),
+			series("apiserver_audit_error_total", "counter", map[string]interface{}{"plugin": "webhook"}, 2.0, ""),
+			series("apiserver_audit_event_total", "counter", map[string]interface{}{}, 1523.0, ""),
+			series("cert_expiry_seconds", "gauge", map[string]interface{}{"cert": "serving"}, 5184000.0, "2024-06-01T10:00:00Z"),
+			series("gc_duration_seconds", "summary", map[string]interface{}{"quantile": "0.5"}, "NaN", ""),
+			series("gc_duration_seconds_sum", "summary", map[string]interface{}{}, 0.0, ""),
+			series("gc_duration_seconds_count", "summary", map[string]interface{}{}, 0.0, ""),
+			series("request_duration_seconds_bucket", "histogram", map[string]interface{}{"le": "0.1"}, 8.0, ""),
+			series("request_duration_seconds_bucket", "histogram", map[string]interface{}{"le": "+Inf"}, 10.0, ""),
+			series("request_duration_seconds_sum", "histogram", map[string]interface{}{}, 1.5, ""),
+			series("request_duration_seconds_count", "histogram", map[string]interface{}{}, 10.0, ""),
+		}
+		if diff := cmp.Diff(want, resources["all"]); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Queries: []Query{
+			{Name: "errors", URL: server.URL + "/prometheus", Query: "sum by (plugin) (apiserver_audit_error_total)"},
+			{Name: "up", URL: server.URL + "/prometheus/", Query: "scalar(up)"},
+		}}}
+
+		resources, err := d.GetResources(context.Background())
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"errors": []interface{}{
+				series("", "", map[string]interface{}{"plugin": "log"}, 0.0, "2024-06-01T10:00:00.5Z"),
+				series("", "", map[string]interface{}{"plugin": "webhook"}, 2.0, "2024-06-01T10:00:00.5Z"),
+			},
+			"up": []interface{}{series("", "", map[string]interface{}{}, 1.0, "2024-06-01T10:00:00Z")},
+		}
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		d := Domain{Spec: &Spec{
+			Scrapes: []Scrape{{Name: "unauthorized", URL: server.URL + "/metrics"}},
+			Queries: []Query{
+				{Name: "invalid", URL: server.URL + "/prometheus", Query: "sum("},
+				{Name: "range", URL: server.URL + "/prometheus", Query: "up[5m]"},
+			},
+		}}
+
+		resources, err := d.GetResources(context.Background())
+		require.ErrorContains(t, err, "401 Unauthorized")
+		require.ErrorContains(t, err, "bad_data: parse error")
+		require.ErrorContains(t, err, `unsupported result type "matrix"`)
+		require.Equal(t, types.DomainResources{
+			"unauthorized": []interface{}{},
+			"invalid":      []interface{}{},
+			"range":        []interface{}{},
+		}, resources)
+	})
+}
+
+func series(metric, typ string, labels map[string]interface{}, value interface{}, ts string) map[string]interface{} {
+	s := map[string]interface{}{"metric": metric, "labels": labels, "value": value}
+	if typ != "" {
+		s["type"] = typ
+	}
+	if ts != "" {
+		s["timestamp"] = ts
+	}
+	return s
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// apiResponse is the envelope returned by the Prometheus HTTP API
+type apiResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// vectorSample is a single element of an instant vector result
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+// query runs an instant query and returns the resulting vector or scalar as series
+func query(ctx context.Context, q Query) ([]Series, error) {
+	u, err := parseURL(q.URL)
+	if err != nil {
+		return nil, err
+	}
+	u = u.JoinPath("api", "v1", "query")
+	params := u.Query()
+	params.Set("query", q.Query)
+	u.RawQuery = params.Encode()
+
+	body, err := get(ctx, u, "application/json", q.Headers, q.Timeout)
+	var statusErr statusError
+	if err != nil && !errors.As(err, &statusErr) {
+		return nil, err
+	}
+
+	var res apiResponse
+	if jsonErr := json.Unmarshal(body, &res); jsonErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid query response: %w", jsonErr)
+	}
+	if res.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s: %s", res.ErrorType, res.Error)
+	}
+
+	return parseResult(res.Data.ResultType, res.Data.Result)
+}
+
+// parseResult converts a vector or scalar result into series
+func parseResult(resultType string, result json.RawMessage) ([]Series, error) {
+	switch resultType {
+	case "vector":
+		var samples []vectorSample
+		if err := json.Unmarshal(result, &samples); err != nil {
+			return nil, fmt.Errorf("invalid vector result: %w", err)
+		}
+		series := make([]Series, 0, len(samples))
+		for _, s := range samples {
+			value, ts, err := parseSample(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			labels := make(map[string]string, len(s.Metric))
+			for k, v := range s.Metric {
+				if k != "__name__" {
+					labels[k] = v
+				}
+			}
+			series = append(series, Series{Metric: s.Metric["__name__"], Labels: labels, Value: value, Timestamp: ts})
+		}
+		return series, nil
+	case "scalar":
+		var sample [2]interface{}
+		if err := json.Unmarshal(result, &sample); err != nil {
+			return nil, fmt.Errorf("invalid scalar result: %w", err)
+		}
+		value, ts, err := parseSample(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []Series{{Labels: map[string]string{}, Value: value, Timestamp: ts}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q, expected an instant vector or scalar", resultType)
+	}
+}
+
+// parseSample parses a [<unix seconds>, "<value>"] pair
+func parseSample(sample [2]interface{}) (interface{}, string, error) {
+	seconds, ok := sample[0].(float64)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid sample timestamp %v", sample[0])
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid sample value %s: %w", raw, err)
+	}
+	return sampleValue(value), timestamp(int64(math.Round(seconds * 1000))), nil
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const textFormat = "text/plain;version=0.0.4;q=1,*/*;q=0.1"
+
+// scrape fetches the endpoint and flattens the metric families into series
+func scrape(ctx context.Context, s Scrape) ([]Series, error) {
+	u, err := parseURL(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	body, err := get(ctx, u, textFormat, s.Headers, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return parseText(body, s.Metrics)
+}
+
+// parseText parses the Prometheus text format, optionally keeping only the named families.
+// Histograms and summaries are expanded into their _bucket, _sum and _count series, as they
+// would be stored by Prometheus.
+func parseText(body []byte, keep []string) ([]Series, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		if len(keep) == 0 || slices.Contains(keep, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	series := make([]Series, 0)
+	for _, name := range names {
+		family := families[name]
+		familyType := strings.ToLower(family.GetType().String())
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			// add appends a series, with an additional le or quantile label when one is given
+			add := func(metric string, value float64, extra ...string) {
+				l := labels
+				if len(extra) == 2 {
+					l = maps.Clone(labels)
+					l[extra[0]] = extra[1]
+				}
+				series = append(series, Series{
+					Metric:    metric,
+					Type:      familyType,
+					Labels:    l,
+					Value:     sampleValue(value),
+					Timestamp: timestamp(m.GetTimestampMs()),
+				})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_SUMMARY:
+				for _, q := range m.GetSummary().GetQuantile() {
+					add(name, q.GetValue(), "quantile", formatFloat(q.GetQuantile()))
+				}
+				add(name+"_sum", m.GetSummary().GetSampleSum())
+				add(name+"_count", float64(m.GetSummary().GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				for _, b := range m.GetHistogram().GetBucket() {
+					add(name+"_bucket", float64(b.GetCumulativeCount()), "le", formatFloat(b.GetUpperBound()))
+				}
+				add(name+"_sum", m.GetHistogram().GetSampleSum())
+				add(name+"_count", float64(m.GetHistogram().GetSampleCount()))
+			default:
+				add(name, m.GetUntyped().GetValue())
+			}
+		}
+	}
+	return series, nil
+}
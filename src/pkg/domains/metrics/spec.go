@@ -0,0 +1,49 @@
+package metrics
+
+// Spec is the user-defined list of metrics endpoints to scrape and PromQL queries to run
+type Spec struct {
+	Scrapes []Scrape `json:"scrapes,omitempty" yaml:"scrapes,omitempty"`
+	Queries []Query  `json:"queries,omitempty" yaml:"queries,omitempty"`
+}
+
+// Scrape is a single endpoint exposing metrics in the Prometheus text format
+type Scrape struct {
+	// Name is the key under which the series are returned
+	Name string `json:"name" yaml:"name"`
+	// URL is the metrics endpoint, e.g. http://localhost:9090/metrics
+	URL string `json:"url" yaml:"url"`
+	// Metrics limits the returned series to the given metric family names
+	Metrics []string `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+	// Headers are added to the request, e.g. for authorization
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Timeout is the duration allowed for the request, defaults to 10s
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// Query is a single PromQL instant query against a Prometheus-compatible API
+type Query struct {
+	// Name is the key under which the series are returned
+	Name string `json:"name" yaml:"name"`
+	// URL is the base URL of the API, e.g. http://prometheus:9090
+	URL string `json:"url" yaml:"url"`
+	// Query is the PromQL expression to evaluate
+	Query string `json:"query" yaml:"query"`
+	// Headers are added to the request, e.g. for authorization
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Timeout is the duration allowed for the request, defaults to 10s
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// Series is a single sample with its labels
+type Series struct {
+	// Metric is the series name, which is empty for PromQL results that dropped it
+	Metric string `json:"metric"`
+	// Type is the metric family type for scraped series: counter, gauge, histogram, summary or untyped
+	Type string `json:"type,omitempty"`
+	// Labels are the series labels, excluding the metric name
+	Labels map[string]string `json:"labels"`
+	// Value is the sample value; NaN and infinities are returned as strings
+	Value interface{} `json:"value"`
+	// Timestamp is the sample time in RFC 3339 format, if known
+	Timestamp string `json:"timestamp,omitempty"`
+}
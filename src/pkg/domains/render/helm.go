@@ -0,0 +1,112 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+)
+
+// renderHelm renders the chart client-side, including CRDs and hooks, as `helm template` would
+func renderHelm(ctx context.Context, spec *HelmSpec, workDir string) ([]map[string]interface{}, error) {
+	dst, err := os.MkdirTemp("", "lula-render-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dst)
+
+	chartPath := localPath(spec.Chart, workDir)
+	if !isDir(chartPath) {
+		// packaged charts may be remote
+		chartPath, err = network.DownloadFile(ctx, filepath.Join(dst, filepath.Base(spec.Chart)), spec.Chart, workDir)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading chart %s: %w", spec.Chart, err)
+		}
+	}
+
+	chart, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading chart %s: %w", spec.Chart, err)
+	}
+	if chart.Metadata.Dependencies != nil {
+		if err := action.CheckDependencies(chart, chart.Metadata.Dependencies); err != nil {
+			return nil, fmt.Errorf("chart %s is missing dependencies, run `helm dependency build`: %w", spec.Chart, err)
+		}
+	}
+
+	vals := make(map[string]interface{})
+	for i, file := range spec.ValuesFiles {
+		path, err := network.DownloadFile(ctx, filepath.Join(dst, fmt.Sprintf("values-%d.yaml", i)), file, workDir)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading values file %s: %w", file, err)
+		}
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		fileVals := make(map[string]interface{})
+		if err := yaml.Unmarshal(data, &fileVals); err != nil {
+			return nil, fmt.Errorf("error parsing values file %s: %w", file, err)
+		}
+		vals = mergeValues(vals, fileVals)
+	}
+	vals = mergeValues(vals, spec.Values)
+
+	client := action.NewInstall(&action.Configuration{Log: func(string, ...interface{}) {}})
+	client.DryRun = true
+	client.DryRunOption = "client"
+	client.ClientOnly = true
+	client.Replace = true
+	client.IncludeCRDs = true
+	client.ReleaseName = defaultReleaseName
+	if spec.ReleaseName != "" {
+		client.ReleaseName = spec.ReleaseName
+	}
+	client.Namespace = defaultNamespace
+	if spec.Namespace != "" {
+		client.Namespace = spec.Namespace
+	}
+	if spec.KubeVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(spec.KubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kube-version %s: %w", spec.KubeVersion, err)
+		}
+		client.KubeVersion = kubeVersion
+	}
+
+	rel, err := client.RunWithContext(ctx, chart, vals)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering chart %s: %w", spec.Chart, err)
+	}
+
+	var manifest strings.Builder
+	manifest.WriteString(rel.Manifest)
+	for _, hook := range rel.Hooks {
+		fmt.Fprintf(&manifest, "\n---\n%s\n", hook.Manifest)
+	}
+
+	return decodeManifests([]byte(manifest.String()))
+}
+
+// mergeValues deep merges src into dst, with src taking precedence, following Helm's
+// behavior for multiple values files
+func mergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := dst[k].(map[string]interface{}); ok {
+				dst[k] = mergeValues(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+	return dst
+}
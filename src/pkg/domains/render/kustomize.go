@@ -0,0 +1,23 @@
+package render
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// renderKustomize builds the kustomization directory with the default kustomize options
+func renderKustomize(spec *KustomizeSpec, workDir string) ([]map[string]interface{}, error) {
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := k.Run(filesys.MakeFsOnDisk(), localPath(spec.Path, workDir))
+	if err != nil {
+		return nil, fmt.Errorf("error running kustomize build on %s: %w", spec.Path, err)
+	}
+
+	data, err := resMap.AsYaml()
+	if err != nil {
+		return nil, err
+	}
+	return decodeManifests(data)
+}
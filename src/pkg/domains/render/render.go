@@ -0,0 +1,133 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a render domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+	if (spec.Helm == nil) == (spec.Kustomize == nil) {
+		return nil, fmt.Errorf("exactly one of helm or kustomize must be specified")
+	}
+	if spec.Helm != nil && spec.Helm.Chart == "" {
+		return nil, fmt.Errorf("helm chart cannot be empty")
+	}
+	if spec.Kustomize != nil && spec.Kustomize.Path == "" {
+		return nil, fmt.Errorf("kustomize path cannot be empty")
+	}
+
+	for _, resource := range spec.Resources {
+		if resource.Name == "" {
+			return nil, fmt.Errorf("resource name cannot be empty")
+		}
+		if resource.ResourceRule == nil {
+			return nil, fmt.Errorf("resource rule cannot be nil")
+		}
+		if resource.ResourceRule.Resource == "" {
+			return nil, fmt.Errorf("resource rule resource cannot be empty")
+		}
+		if resource.ResourceRule.Version == "" {
+			return nil, fmt.Errorf("resource rule version cannot be empty")
+		}
+		if resource.ResourceRule.Name != "" && len(resource.ResourceRule.Namespaces) > 1 {
+			return nil, fmt.Errorf("named resource requested cannot be returned from multiple namespaces")
+		}
+		if resource.ResourceRule.Field != nil {
+			if resource.ResourceRule.Field.Type == "" {
+				resource.ResourceRule.Field.Type = kube.DefaultFieldType
+			}
+			if err := resource.ResourceRule.Field.Validate(); err != nil {
+				return nil, err
+			}
+			if resource.ResourceRule.Name == "" {
+				return nil, fmt.Errorf("field cannot be specified without resource name")
+			}
+		}
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources renders the chart or kustomization and returns the manifests grouped by kind,
+// or selected by the spec resources.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok {
+		// if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	var manifests []map[string]interface{}
+	var err error
+	namespace := defaultNamespace
+	if d.Spec.Helm != nil {
+		if d.Spec.Helm.Namespace != "" {
+			namespace = d.Spec.Helm.Namespace
+		}
+		manifests, err = renderHelm(ctx, d.Spec.Helm, workDir)
+	} else {
+		manifests, err = renderKustomize(d.Spec.Kustomize, workDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d.Spec.Resources) == 0 {
+		return groupByKind(manifests), nil
+	}
+	return selectResources(manifests, d.Spec.Resources, namespace)
+}
+
+// IsExecutable returns false; rendering does not run external commands or change a cluster.
+func (d Domain) IsExecutable() bool { return false }
+
+// decodeManifests splits a multi-document YAML stream into objects, skipping empty documents
+func decodeManifests(data []byte) ([]map[string]interface{}, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	manifests := make([]map[string]interface{}, 0)
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		manifests = append(manifests, obj)
+	}
+	return manifests, nil
+}
+
+// localPath resolves a relative local path against the working directory
+func localPath(path, workDir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(workDir, path)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
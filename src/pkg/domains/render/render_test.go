@@ -0,0 +1,221 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var _ types.Domain = (*Domain)(nil)
+
+func TestCreateDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *Spec
+		wantErr bool
+	}{
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "no source",
+			spec:    &Spec{},
+			wantErr: true,
+		},
+		{
+			name:    "both sources",
+			spec:    &Spec{Helm: &HelmSpec{Chart: "chart"}, Kustomize: &KustomizeSpec{Path: "kustomize"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing chart",
+			spec:    &Spec{Helm: &HelmSpec{}},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource rule",
+			spec: &Spec{
+				Kustomize: &KustomizeSpec{Path: "kustomize"},
+				Resources: []kube.Resource{{Name: "pods", ResourceRule: &kube.ResourceRule{Resource: "pods"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid spec",
+			spec: &Spec{
+				Helm:      &HelmSpec{Chart: "chart", ValuesFiles: []string{"values-prod.yaml"}},
+				Resources: []kube.Resource{{Name: "pods", ResourceRule: &kube.ResourceRule{Version: "v1", Resource: "pods"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDomain(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResources(t *testing.T) {
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+
+	t.Run("helm grouped by kind", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Helm: &HelmSpec{
+			Chart:       "chart",
+			ReleaseName: "demo",
+			Namespace:   "apps",
+			KubeVersion: "v1.31.0",
+		}}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"CustomResourceDefinition": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "apiextensions.k8s.io/v1",
+					"kind":       "CustomResourceDefinition",
+					"metadata":   map[string]interface{}{"name": "widgets.example.com"},
+					"spec": map[string]interface{}{
+						"group": "example.com",
+						"names": map[string]interface{}{"kind": "Widget", "plural": "widgets"},
+						"scope": "Namespaced",
+						"versions": []interface{}{
+							map[string]interface{}{
+								"name": "v1", "served": true, "storage": true,
+								"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{"type": "object"}},
+							},
+						},
+					},
+				},
+			},
+			"ConfigMap": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata":   map[string]interface{}{"name": "demo-config"},
+					"data":       map[string]interface{}{"config.json": `{"kubeVersion": "v1.31.0"}`},
+				},
+			},
+			"Service": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Service",
+					"metadata":   map[string]interface{}{"name": "demo-podinfo"},
+					"spec":       map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": 9898.0}}},
+				},
+			},
+			"Deployment": []interface{}{
+				deployment("demo-podinfo", "apps", 1, false),
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("helm values files with resource rules", func(t *testing.T) {
+		d := Domain{Spec: &Spec{
+			Helm: &HelmSpec{
+				Chart:       "chart",
+				ValuesFiles: []string{"values-prod.yaml"},
+				Values:      map[string]interface{}{"image": map[string]interface{}{"tag": "6.7.1"}},
+			},
+			Resources: []kube.Resource{
+				{Name: "deployments", ResourceRule: &kube.ResourceRule{Group: "apps", Version: "v1", Resource: "deployments", Namespaces: []string{"default"}}},
+				{Name: "services", ResourceRule: &kube.ResourceRule{Version: "v1", Resource: "services"}},
+				{Name: "config", ResourceRule: &kube.ResourceRule{
+					Name: "release-name-config", Version: "v1", Resource: "configmaps",
+					Field: &kube.Field{Jsonpath: ".data.config.json", Type: kube.FieldTypeJSON},
+				}},
+			},
+		}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		prod := deployment("release-name-podinfo", "default", 3, true)
+		prod["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})["image"] = "ghcr.io/stefanprodan/podinfo:6.7.1"
+		want := types.DomainResources{
+			"deployments": []map[string]interface{}{prod},
+			"services":    []map[string]interface{}{},
+			"config":      map[string]interface{}{"kubeVersion": "v1.20.0"},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("kustomize", func(t *testing.T) {
+		d := Domain{Spec: &Spec{
+			Kustomize: &KustomizeSpec{Path: "kustomize/overlay"},
+			Resources: []kube.Resource{
+				{Name: "podinfo", ResourceRule: &kube.ResourceRule{Name: "podinfo", Group: "apps", Version: "v1", Resource: "deployments", Namespaces: []string{"prod"}}},
+				{Name: "missing", ResourceRule: &kube.ResourceRule{Name: "missing", Group: "apps", Version: "v1", Resource: "deployments"}},
+				{Name: "namespaces", ResourceRule: &kube.ResourceRule{Version: "v1", Resource: "namespaces"}},
+			},
+		}}
+
+		resources, err := d.GetResources(ctx)
+		require.Error(t, err)
+
+		want := types.DomainResources{
+			"podinfo": map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"name": "podinfo", "namespace": "prod"},
+				"spec": map[string]interface{}{
+					"replicas": 2.0,
+					"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+						map[string]interface{}{"name": "podinfo", "image": "ghcr.io/stefanprodan/podinfo:6.7.0"},
+					}}},
+				},
+			},
+			"missing": map[string]interface{}{},
+			"namespaces": []map[string]interface{}{
+				{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]interface{}{"name": "prod"}},
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("invalid chart", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Helm: &HelmSpec{Chart: "kustomize/base"}}}
+
+		_, err := d.GetResources(ctx)
+		require.Error(t, err)
+	})
+}
+
+func deployment(name, namespace string, replicas float64, runAsNonRoot bool) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{
+					"name":            "podinfo",
+					"image":           "ghcr.io/stefanprodan/podinfo:6.7.0",
+					"securityContext": map[string]interface{}{"runAsNonRoot": runAsNonRoot},
+				},
+			}}},
+		},
+	}
+}
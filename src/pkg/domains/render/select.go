@@ -0,0 +1,99 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// groupByKind returns the manifests keyed by their kind
+func groupByKind(manifests []map[string]interface{}) types.DomainResources {
+	drs := make(types.DomainResources)
+	for _, m := range manifests {
+		kind, _ := m["kind"].(string)
+		collection, _ := drs[kind].([]interface{})
+		drs[kind] = append(collection, m)
+	}
+	return drs
+}
+
+// selectResources applies the resource rules to the manifests, producing the same shape as a
+// kubernetes domain query: a list for each rule, or a single object when the rule names a resource.
+// Manifests without a namespace are treated as being in the default namespace.
+func selectResources(manifests []map[string]interface{}, resources []kube.Resource, defaultNs string) (types.DomainResources, error) {
+	drs := make(types.DomainResources, len(resources))
+	var errs error
+
+	for _, resource := range resources {
+		rule := resource.ResourceRule
+		collection := make([]map[string]interface{}, 0)
+		for _, m := range manifests {
+			if matchesRule(m, rule, defaultNs) {
+				collection = append(collection, m)
+			}
+		}
+
+		if rule.Name == "" {
+			drs[resource.Name] = collection
+			continue
+		}
+
+		if len(collection) == 0 {
+			// Assign empty data value for reporting purposes
+			drs[resource.Name] = map[string]interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("%s %q not found in rendered manifests", rule.Resource, rule.Name))
+			continue
+		}
+		item := collection[0]
+		if rule.Field != nil && rule.Field.Jsonpath != "" {
+			var err error
+			item, err = kube.GetFieldValue(item, rule.Field)
+			if err != nil {
+				drs[resource.Name] = map[string]interface{}{}
+				errs = errors.Join(errs, err)
+				continue
+			}
+		}
+		drs[resource.Name] = item
+	}
+
+	return drs, errs
+}
+
+func matchesRule(m map[string]interface{}, rule *kube.ResourceRule, defaultNs string) bool {
+	apiVersion, _ := m["apiVersion"].(string)
+	kind, _ := m["kind"].(string)
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil || gv.Group != rule.Group || gv.Version != rule.Version {
+		return false
+	}
+
+	// without API discovery, the resource name is derived from the kind in the same way kubectl guesses it
+	plural, singular := meta.UnsafeGuessKindToResource(gv.WithKind(kind))
+	if rule.Resource != plural.Resource && rule.Resource != singular.Resource {
+		return false
+	}
+
+	metadata, _ := m["metadata"].(map[string]interface{})
+	if rule.Name != "" {
+		if name, _ := metadata["name"].(string); name != rule.Name {
+			return false
+		}
+	}
+	if len(rule.Namespaces) > 0 {
+		namespace, _ := metadata["namespace"].(string)
+		if namespace == "" {
+			namespace = defaultNs
+		}
+		if !slices.Contains(rule.Namespaces, namespace) {
+			return false
+		}
+	}
+	return true
+}
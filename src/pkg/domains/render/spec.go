@@ -0,0 +1,42 @@
+package render
+
+import (
+	kube "github.com/defenseunicorns/lula/src/pkg/domains/kubernetes"
+)
+
+// Spec is the user-defined specification for the render domain; exactly one of helm or kustomize
+// must be set
+type Spec struct {
+	Helm      *HelmSpec      `json:"helm,omitempty" yaml:"helm,omitempty"`
+	Kustomize *KustomizeSpec `json:"kustomize,omitempty" yaml:"kustomize,omitempty"`
+	// Resources optionally selects rendered manifests using the same resource rules as the
+	// kubernetes domain. When empty, all manifests are returned grouped by kind.
+	Resources []kube.Resource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// HelmSpec renders a local Helm chart, as `helm template` would
+type HelmSpec struct {
+	// Chart is the path of a chart directory, or the local path or URL of a packaged chart
+	Chart string `json:"chart" yaml:"chart"`
+	// ReleaseName defaults to "release-name"
+	ReleaseName string `json:"release-name,omitempty" yaml:"release-name,omitempty"`
+	// Namespace defaults to "default"
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// ValuesFiles are merged in order, later files taking precedence
+	ValuesFiles []string `json:"values-files,omitempty" yaml:"values-files,omitempty"`
+	// Values are merged after the values files
+	Values map[string]interface{} `json:"values,omitempty" yaml:"values,omitempty"`
+	// KubeVersion is the Kubernetes version used for Capabilities.KubeVersion
+	KubeVersion string `json:"kube-version,omitempty" yaml:"kube-version,omitempty"`
+}
+
+// KustomizeSpec runs a kustomize build, as `kustomize build` would
+type KustomizeSpec struct {
+	// Path is the directory containing the kustomization file
+	Path string `json:"path" yaml:"path"`
+}
+
+const (
+	defaultReleaseName = "release-name"
+	defaultNamespace   = "default"
+)
@@ -0,0 +1,28 @@
+package sql
+
+// Driver is a supported database driver
+type Driver string
+
+const (
+	DriverSQLite   Driver = "sqlite"
+	DriverPostgres Driver = "postgres"
+)
+
+// Spec is the user-defined database connection and the queries to run against it
+type Spec struct {
+	// Driver is the database driver: sqlite or postgres
+	Driver Driver `json:"driver" yaml:"driver"`
+	// DSN is the data source name. For sqlite this is the path to the database file; for postgres
+	// it is a connection string or URL, which should be supplied through a sensitive variable.
+	DSN string `json:"dsn" yaml:"dsn"`
+	// Queries are run in order, each in its own read-only transaction
+	Queries []Query `json:"queries" yaml:"queries"`
+}
+
+// Query is a single named query
+type Query struct {
+	// Name is the key under which the rows are returned
+	Name string `json:"name" yaml:"name"`
+	// Query is the SQL statement to run, which must be a single statement
+	Query string `json:"query" yaml:"query"`
+}
@@ -0,0 +1,269 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a SQL domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+	switch spec.Driver {
+	case DriverSQLite, DriverPostgres:
+	default:
+		return nil, fmt.Errorf("unsupported driver %q, must be one of sqlite or postgres", spec.Driver)
+	}
+	if spec.DSN == "" {
+		return nil, fmt.Errorf("dsn cannot be empty")
+	}
+	if len(spec.Queries) == 0 {
+		return nil, fmt.Errorf("sql-spec must contain at least one query")
+	}
+
+	names := make(map[string]bool, len(spec.Queries))
+	for _, q := range spec.Queries {
+		if q.Name == "" {
+			return nil, fmt.Errorf("query name cannot be empty")
+		}
+		if names[q.Name] {
+			return nil, fmt.Errorf("query name %s must be unique", q.Name)
+		}
+		names[q.Name] = true
+		if strings.TrimSpace(q.Query) == "" {
+			return nil, fmt.Errorf("query %s cannot be empty", q.Name)
+		}
+		if multipleStatements(q.Query) {
+			return nil, fmt.Errorf("query %s must be a single statement", q.Name)
+		}
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources connects to the database and runs each query, returning the rows keyed by query name.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok {
+		workDir = "."
+	}
+
+	db, err := open(d.Spec.Driver, d.Spec.DSN, workDir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// The DSN may contain credentials, so it is deliberately left out of connection errors
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("error connecting to %s database: %w", d.Spec.Driver, err)
+	}
+
+	var errs error
+	drs := make(types.DomainResources, len(d.Spec.Queries))
+	for _, q := range d.Spec.Queries {
+		rows, err := query(ctx, db, q.Query)
+		if err != nil {
+			// Assign empty data value for reporting purposes
+			drs[q.Name] = []interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("error running query %s: %w", q.Name, err))
+			continue
+		}
+		drs[q.Name] = rows
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns false; queries are run in read-only transactions and cannot modify the database.
+func (d Domain) IsExecutable() bool { return false }
+
+// open returns a handle for the driver. SQLite databases are opened read-only with writes disabled
+// for the connection, since the driver does not enforce read-only transactions itself. Postgres
+// sessions default to read-only transactions, so a statement run outside the transaction of the
+// query cannot write either.
+func open(driver Driver, dsn, workDir string) (*dbsql.DB, error) {
+	switch driver {
+	case DriverSQLite:
+		dsn = sqliteDSN(dsn, workDir)
+	case DriverPostgres:
+		dsn = postgresDSN(dsn)
+	}
+	db, err := dbsql.Open(string(driver), dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s database: %w", driver, err)
+	}
+	return db, nil
+}
+
+// sqliteDSN builds a read-only URI for a database path, resolving relative paths against the work dir
+func sqliteDSN(dsn, workDir string) string {
+	path, params, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+	readOnly := "mode=ro&_pragma=query_only(1)"
+	if params != "" {
+		readOnly = params + "&" + readOnly
+	}
+	return "file:" + path + "?" + readOnly
+}
+
+// postgresDSN sets default_transaction_read_only for the session, as a URL query parameter or a
+// key/value setting depending on the form of the connection string
+func postgresDSN(dsn string) string {
+	const readOnly = "default_transaction_read_only"
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			// Left for the driver to report, without echoing the DSN
+			return dsn
+		}
+		q := u.Query()
+		q.Set(readOnly, "on")
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	// Later settings take precedence over earlier ones
+	return dsn + " " + readOnly + "=on"
+}
+
+// multipleStatements reports whether the query contains more than one statement, ignoring
+// semicolons in string literals, quoted identifiers, dollar-quoted strings and comments. A
+// single trailing semicolon is allowed.
+func multipleStatements(query string) bool {
+	ended := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if ended && !strings.ContainsRune(" \t\r\n;", rune(c)) && !strings.HasPrefix(query[i:], "--") && !strings.HasPrefix(query[i:], "/*") {
+			return true
+		}
+		switch {
+		case c == ';':
+			ended = true
+		case c == '\'' || c == '"':
+			// A doubled quote is an escaped quote, and is skipped as two adjacent quoted strings
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				return false
+			}
+			i += end + 1
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return false
+			}
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return false
+			}
+			i += end + 3
+		case c == '$':
+			tag := dollarTag(query[i:])
+			if tag == "" {
+				continue
+			}
+			end := strings.Index(query[i+len(tag):], tag)
+			if end < 0 {
+				return false
+			}
+			i += end + 2*len(tag) - 1
+		}
+	}
+	return false
+}
+
+// dollarTag returns the opening tag of a dollar-quoted string, such as $$ or $body$, at the start of s
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '$':
+			return s[:i+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// query runs the statement in a read-only transaction, which is always rolled back,
+// and returns the rows as a list of column name to value maps
+func query(ctx context.Context, db *dbsql.DB, statement string) ([]interface{}, error) {
+	tx, err := db.BeginTx(ctx, &dbsql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, statement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			// Text columns are commonly returned as bytes by the drivers
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+				continue
+			}
+			row[column] = values[i]
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return toResource(results)
+}
+
+// toResource converts the rows into plain JSON values so that numbers and times are
+// represented consistently across drivers
+func toResource(rows []map[string]interface{}) ([]interface{}, error) {
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	var resource []interface{}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
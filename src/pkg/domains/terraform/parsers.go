@@ -0,0 +1,241 @@
+package terraform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnknownFormat = errors.New("file is not a Terraform state or plan")
+
+// parseDocument detects whether the data is a raw state file, `terraform show -json` state
+// output or `terraform show -json` plan output, and normalizes it
+func parseDocument(data []byte) (*Document, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("file is not a JSON object: %w", err)
+	}
+
+	_, hasChanges := probe["resource_changes"]
+	_, hasPlanned := probe["planned_values"]
+	_, hasFormat := probe["format_version"]
+	_, hasVersion := probe["version"]
+
+	switch {
+	case hasChanges || hasPlanned:
+		return parsePlan(data)
+	case hasFormat:
+		return parseShowState(data)
+	case hasVersion:
+		return parseRawState(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func newDocument(kind Kind, version string) *Document {
+	return &Document{
+		Kind:             kind,
+		TerraformVersion: version,
+		Resources:        make(map[string][]Resource),
+		Outputs:          make(map[string]Output),
+	}
+}
+
+func (d *Document) add(r Resource) {
+	d.Resources[r.Type] = append(d.Resources[r.Type], r)
+}
+
+type rawState struct {
+	Version          int    `json:"version"`
+	TerraformVersion string `json:"terraform_version"`
+	Resources        []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Provider  string `json:"provider"`
+		Instances []struct {
+			IndexKey   interface{}            `json:"index_key"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+	Outputs map[string]Output `json:"outputs"`
+}
+
+// parseRawState reads a terraform.tfstate file (state format version 4)
+func parseRawState(data []byte) (*Document, error) {
+	var state rawState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error parsing state: %w", err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported state version %d, only version 4 is supported", state.Version)
+	}
+
+	doc := newDocument(KindState, state.TerraformVersion)
+	for _, r := range state.Resources {
+		for _, inst := range r.Instances {
+			doc.add(Resource{
+				Address:  resourceAddress(r.Module, r.Mode, r.Type, r.Name, inst.IndexKey),
+				Mode:     r.Mode,
+				Type:     r.Type,
+				Name:     r.Name,
+				Module:   r.Module,
+				Provider: r.Provider,
+				Index:    inst.IndexKey,
+				Values:   nonNil(inst.Attributes),
+			})
+		}
+	}
+	for name, o := range state.Outputs {
+		doc.Outputs[name] = o
+	}
+	return doc, nil
+}
+
+type showModule struct {
+	Address   string `json:"address"`
+	Resources []struct {
+		Address      string                 `json:"address"`
+		Mode         string                 `json:"mode"`
+		Type         string                 `json:"type"`
+		Name         string                 `json:"name"`
+		Index        interface{}            `json:"index"`
+		ProviderName string                 `json:"provider_name"`
+		Values       map[string]interface{} `json:"values"`
+	} `json:"resources"`
+	ChildModules []showModule `json:"child_modules"`
+}
+
+type showValues struct {
+	Outputs    map[string]Output `json:"outputs"`
+	RootModule showModule        `json:"root_module"`
+}
+
+type showState struct {
+	TerraformVersion string     `json:"terraform_version"`
+	Values           showValues `json:"values"`
+}
+
+// parseShowState reads `terraform show -json` output for a state
+func parseShowState(data []byte) (*Document, error) {
+	var state showState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error parsing state: %w", err)
+	}
+
+	doc := newDocument(KindState, state.TerraformVersion)
+	addModule(doc, state.Values.RootModule)
+	for name, o := range state.Values.Outputs {
+		doc.Outputs[name] = o
+	}
+	return doc, nil
+}
+
+func addModule(doc *Document, m showModule) {
+	for _, r := range m.Resources {
+		doc.add(Resource{
+			Address:  r.Address,
+			Mode:     r.Mode,
+			Type:     r.Type,
+			Name:     r.Name,
+			Module:   m.Address,
+			Provider: r.ProviderName,
+			Index:    r.Index,
+			Values:   nonNil(r.Values),
+		})
+	}
+	for _, child := range m.ChildModules {
+		addModule(doc, child)
+	}
+}
+
+type plan struct {
+	TerraformVersion string     `json:"terraform_version"`
+	PlannedValues    showValues `json:"planned_values"`
+	ResourceChanges  []struct {
+		Address       string      `json:"address"`
+		ModuleAddress string      `json:"module_address"`
+		Mode          string      `json:"mode"`
+		Type          string      `json:"type"`
+		Name          string      `json:"name"`
+		Index         interface{} `json:"index"`
+		ProviderName  string      `json:"provider_name"`
+		Change        struct {
+			Actions      []string               `json:"actions"`
+			Before       map[string]interface{} `json:"before"`
+			After        map[string]interface{} `json:"after"`
+			AfterUnknown map[string]interface{} `json:"after_unknown"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// parsePlan reads `terraform show -json` output for a saved plan. Resources are taken from
+// resource_changes so that every resource, including those being deleted, carries its actions.
+func parsePlan(data []byte) (*Document, error) {
+	var p plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("error parsing plan: %w", err)
+	}
+
+	doc := newDocument(KindPlan, p.TerraformVersion)
+	for _, rc := range p.ResourceChanges {
+		values := rc.Change.After
+		if isDelete(rc.Change.Actions) {
+			// the resource will not exist after apply, report its current values
+			values = rc.Change.Before
+		}
+		doc.add(Resource{
+			Address:      rc.Address,
+			Mode:         rc.Mode,
+			Type:         rc.Type,
+			Name:         rc.Name,
+			Module:       rc.ModuleAddress,
+			Provider:     rc.ProviderName,
+			Index:        rc.Index,
+			Values:       nonNil(values),
+			Actions:      rc.Change.Actions,
+			Before:       rc.Change.Before,
+			AfterUnknown: rc.Change.AfterUnknown,
+		})
+	}
+	for name, o := range p.PlannedValues.Outputs {
+		doc.Outputs[name] = o
+	}
+	return doc, nil
+}
+
+func isDelete(actions []string) bool {
+	return len(actions) == 1 && actions[0] == "delete"
+}
+
+// resourceAddress builds the resource instance address, e.g. module.vpc.aws_subnet.private["a"]
+func resourceAddress(module, mode, typ, name string, index interface{}) string {
+	var b strings.Builder
+	if module != "" {
+		b.WriteString(module + ".")
+	}
+	if mode == "data" {
+		b.WriteString("data.")
+	}
+	b.WriteString(typ + "." + name)
+	switch i := index.(type) {
+	case nil:
+	case string:
+		fmt.Fprintf(&b, "[%q]", i)
+	case float64:
+		fmt.Fprintf(&b, "[%d]", int(i))
+	default:
+		fmt.Fprintf(&b, "[%v]", i)
+	}
+	return b.String()
+}
+
+func nonNil(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
@@ -0,0 +1,52 @@
+package terraform
+
+// Spec is the user-defined list of Terraform state and plan files to load
+type Spec struct {
+	Files []File `json:"files" yaml:"files"`
+}
+
+// File is a single Terraform state file or `terraform show -json` output
+type File struct {
+	// Name is the key under which the file contents are returned
+	Name string `json:"name" yaml:"name"`
+	// Path is the local path or URL of the file
+	Path string `json:"path" yaml:"path"`
+}
+
+// Kind is the kind of Terraform document that was loaded
+type Kind string
+
+const (
+	KindState Kind = "state"
+	KindPlan  Kind = "plan"
+)
+
+// Resource is a single flattened resource instance
+type Resource struct {
+	Address  string                 `json:"address"`
+	Mode     string                 `json:"mode"`
+	Type     string                 `json:"type"`
+	Name     string                 `json:"name"`
+	Module   string                 `json:"module,omitempty"`
+	Provider string                 `json:"provider,omitempty"`
+	Index    interface{}            `json:"index,omitempty"`
+	Values   map[string]interface{} `json:"values"`
+	// Actions, Before and AfterUnknown are only set for plans
+	Actions      []string               `json:"actions,omitempty"`
+	Before       map[string]interface{} `json:"before,omitempty"`
+	AfterUnknown map[string]interface{} `json:"after-unknown,omitempty"`
+}
+
+// Output is a root module output value
+type Output struct {
+	Value     interface{} `json:"value"`
+	Sensitive bool        `json:"sensitive"`
+}
+
+// Document is the normalized contents of a state or plan file
+type Document struct {
+	Kind             Kind                  `json:"kind"`
+	TerraformVersion string                `json:"terraform-version"`
+	Resources        map[string][]Resource `json:"resources"`
+	Outputs          map[string]Output     `json:"outputs"`
+}
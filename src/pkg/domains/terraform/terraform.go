@@ -0,0 +1,101 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a terraform domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+	if len(spec.Files) == 0 {
+		return nil, fmt.Errorf("terraform-spec must contain at least one file")
+	}
+
+	names := make(map[string]bool, len(spec.Files))
+	for _, f := range spec.Files {
+		if f.Name == "" {
+			return nil, fmt.Errorf("file name cannot be empty")
+		}
+		if f.Path == "" {
+			return nil, fmt.Errorf("file %s path cannot be empty", f.Name)
+		}
+		if names[f.Name] {
+			return nil, fmt.Errorf("file name %s must be unique", f.Name)
+		}
+		names[f.Name] = true
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources loads each state or plan file and returns its flattened resources, keyed by file name.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok {
+		// if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	dst, err := os.MkdirTemp("", "lula-terraform-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dst)
+
+	var errs error
+	drs := make(types.DomainResources, len(d.Spec.Files))
+	for i, file := range d.Spec.Files {
+		resource, err := loadFile(ctx, file, filepath.Join(dst, fmt.Sprintf("%d-%s", i, filepath.Base(file.Path))), workDir)
+		if err != nil {
+			// Assign empty data value for reporting purposes
+			drs[file.Name] = map[string]interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("error loading file %s: %w", file.Name, err))
+			continue
+		}
+		drs[file.Name] = resource
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns false; the terraform domain only reads existing state and plan files.
+func (d Domain) IsExecutable() bool { return false }
+
+func loadFile(ctx context.Context, file File, dst, workDir string) (map[string]interface{}, error) {
+	path, err := network.DownloadFile(ctx, dst, file.Path, workDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// round-trip through JSON so providers and tests receive plain maps and slices
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var resource map[string]interface{}
+	err = json.Unmarshal(b, &resource)
+	return resource, err
+}
@@ -0,0 +1,200 @@
+package terraform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var _ types.Domain = (*Domain)(nil)
+
+func TestCreateDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *Spec
+		wantErr bool
+	}{
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "no files",
+			spec:    &Spec{},
+			wantErr: true,
+		},
+		{
+			name:    "missing path",
+			spec:    &Spec{Files: []File{{Name: "state"}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate names",
+			spec:    &Spec{Files: []File{{Name: "tf", Path: "a.json"}, {Name: "tf", Path: "b.json"}}},
+			wantErr: true,
+		},
+		{
+			name: "valid spec",
+			spec: &Spec{Files: []File{{Name: "state", Path: "terraform.tfstate"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDomain(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResources(t *testing.T) {
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+	const aws = "registry.terraform.io/hashicorp/aws"
+	const awsState = `provider["registry.terraform.io/hashicorp/aws"]`
+
+	t.Run("raw state", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Files: []File{{Name: "state", Path: "terraform.tfstate"}}}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"state": map[string]interface{}{
+				"kind":              "state",
+				"terraform-version": "1.9.5",
+				"resources": map[string]interface{}{
+					"aws_s3_bucket": []interface{}{
+						map[string]interface{}{
+							"address": "aws_s3_bucket.logs", "mode": "managed", "type": "aws_s3_bucket", "name": "logs", "provider": awsState,
+							"values": map[string]interface{}{"bucket": "lula-logs", "force_destroy": false, "tags": map[string]interface{}{"env": "prod"}},
+						},
+					},
+					"aws_subnet": []interface{}{
+						map[string]interface{}{
+							"address": `module.network.aws_subnet.private["a"]`, "mode": "managed", "type": "aws_subnet", "name": "private",
+							"module": "module.network", "provider": awsState, "index": "a",
+							"values": map[string]interface{}{"cidr_block": "10.0.1.0/24", "map_public_ip_on_launch": false},
+						},
+						map[string]interface{}{
+							"address": `module.network.aws_subnet.private["b"]`, "mode": "managed", "type": "aws_subnet", "name": "private",
+							"module": "module.network", "provider": awsState, "index": "b",
+							"values": map[string]interface{}{"cidr_block": "10.0.2.0/24", "map_public_ip_on_launch": true},
+						},
+					},
+					"aws_caller_identity": []interface{}{
+						map[string]interface{}{
+							"address": "data.aws_caller_identity.current", "mode": "data", "type": "aws_caller_identity", "name": "current", "provider": awsState,
+							"values": map[string]interface{}{"account_id": "123456789012"},
+						},
+					},
+				},
+				"outputs": map[string]interface{}{
+					"bucket_arn": map[string]interface{}{"value": "arn:aws:s3:::lula-logs", "sensitive": false},
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("show state", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Files: []File{{Name: "state", Path: "state.json"}}}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"state": map[string]interface{}{
+				"kind":              "state",
+				"terraform-version": "1.9.5",
+				"resources": map[string]interface{}{
+					"aws_db_instance": []interface{}{
+						map[string]interface{}{
+							"address": "aws_db_instance.main", "mode": "managed", "type": "aws_db_instance", "name": "main", "provider": aws,
+							"values": map[string]interface{}{"engine": "postgres", "storage_encrypted": true},
+						},
+					},
+					"aws_security_group": []interface{}{
+						map[string]interface{}{
+							"address": "module.network.aws_security_group.web[0]", "mode": "managed", "type": "aws_security_group", "name": "web",
+							"module": "module.network", "provider": aws, "index": 0.0,
+							"values": map[string]interface{}{"ingress": []interface{}{
+								map[string]interface{}{"cidr_blocks": []interface{}{"0.0.0.0/0"}, "from_port": 443.0, "to_port": 443.0},
+							}},
+						},
+					},
+				},
+				"outputs": map[string]interface{}{
+					"db_password": map[string]interface{}{"value": "hunter2", "sensitive": true},
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Files: []File{{Name: "plan", Path: "plan.json"}}}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"plan": map[string]interface{}{
+				"kind":              "plan",
+				"terraform-version": "1.9.5",
+				"resources": map[string]interface{}{
+					"aws_s3_bucket": []interface{}{
+						map[string]interface{}{
+							"address": "aws_s3_bucket.artifacts", "mode": "managed", "type": "aws_s3_bucket", "name": "artifacts", "provider": aws,
+							"values":        map[string]interface{}{"bucket": "lula-artifacts", "force_destroy": false},
+							"actions":       []interface{}{"create"},
+							"after-unknown": map[string]interface{}{"arn": true, "id": true},
+						},
+						map[string]interface{}{
+							"address": "aws_s3_bucket.legacy", "mode": "managed", "type": "aws_s3_bucket", "name": "legacy", "provider": aws,
+							"values":  map[string]interface{}{"bucket": "lula-legacy"},
+							"actions": []interface{}{"delete"},
+							"before":  map[string]interface{}{"bucket": "lula-legacy"},
+						},
+					},
+					"aws_db_instance": []interface{}{
+						map[string]interface{}{
+							"address": "module.db.aws_db_instance.main", "mode": "managed", "type": "aws_db_instance", "name": "main",
+							"module": "module.db", "provider": aws,
+							"values":  map[string]interface{}{"storage_encrypted": true, "publicly_accessible": true},
+							"actions": []interface{}{"update"},
+							"before":  map[string]interface{}{"storage_encrypted": true, "publicly_accessible": false},
+						},
+					},
+				},
+				"outputs": map[string]interface{}{
+					"bucket_name": map[string]interface{}{"value": "lula-artifacts", "sensitive": false},
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Files: []File{{Name: "unknown", Path: "unknown.json"}}}}
+
+		resources, err := d.GetResources(ctx)
+		require.ErrorIs(t, err, ErrUnknownFormat)
+		require.Equal(t, map[string]interface{}{}, resources["unknown"])
+	})
+}
@@ -0,0 +1,103 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+func certificate(cert *x509.Certificate) Certificate {
+	c := Certificate{
+		Subject:            cert.Subject.String(),
+		Issuer:             cert.Issuer.String(),
+		SerialNumber:       cert.SerialNumber.String(),
+		DNSNames:           nonNil(cert.DNSNames),
+		IPAddresses:        make([]string, 0, len(cert.IPAddresses)),
+		EmailAddresses:     nonNil(cert.EmailAddresses),
+		URIs:               make([]string, 0, len(cert.URIs)),
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		NotBefore:          cert.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:           cert.NotAfter.UTC().Format(time.RFC3339),
+		IsCA:               cert.IsCA,
+	}
+	for _, ip := range cert.IPAddresses {
+		c.IPAddresses = append(c.IPAddresses, net.IP.String(ip))
+	}
+	for _, uri := range cert.URIs {
+		c.URIs = append(c.URIs, uri.String())
+	}
+
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		c.KeyType = "RSA"
+		c.KeySize = key.N.BitLen()
+	case *ecdsa.PublicKey:
+		c.KeyType = "ECDSA"
+		c.KeySize = key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		c.KeyType = "Ed25519"
+		c.KeySize = 256
+	default:
+		c.KeyType = cert.PublicKeyAlgorithm.String()
+	}
+	return c
+}
+
+// ocspStatus parses the stapled OCSP response against the issuer from the presented chain
+func ocspStatus(state cryptotls.ConnectionState) OCSP {
+	if len(state.OCSPResponse) == 0 {
+		return OCSP{Stapled: false}
+	}
+
+	var issuer *x509.Certificate
+	if len(state.PeerCertificates) > 1 {
+		issuer = state.PeerCertificates[1]
+	}
+	resp, err := ocsp.ParseResponse(state.OCSPResponse, issuer)
+	if err != nil {
+		return OCSP{Stapled: true, Error: err.Error()}
+	}
+
+	o := OCSP{
+		Stapled:    true,
+		ProducedAt: resp.ProducedAt.UTC().Format(time.RFC3339),
+	}
+	switch resp.Status {
+	case ocsp.Good:
+		o.Status = "good"
+	case ocsp.Revoked:
+		o.Status = "revoked"
+	default:
+		o.Status = "unknown"
+	}
+	if !resp.NextUpdate.IsZero() {
+		o.NextUpdate = resp.NextUpdate.UTC().Format(time.RFC3339)
+	}
+	return o
+}
+
+// toResource round-trips the inspection through JSON so providers and tests receive
+// plain maps and slices
+func toResource(inspection *Inspection) (map[string]interface{}, error) {
+	b, err := json.Marshal(inspection)
+	if err != nil {
+		return nil, err
+	}
+	var resource map[string]interface{}
+	err = json.Unmarshal(b, &resource)
+	return resource, err
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package tls
+
+// StartTLS is a plaintext protocol that is upgraded to TLS after connecting
+type StartTLS string
+
+const (
+	StartTLSSMTP     StartTLS = "smtp"
+	StartTLSLDAP     StartTLS = "ldap"
+	StartTLSPostgres StartTLS = "postgres"
+)
+
+// Spec is the user-defined list of TLS endpoints to inspect
+type Spec struct {
+	Targets []Target `json:"targets" yaml:"targets"`
+}
+
+// Target is a single TLS endpoint
+type Target struct {
+	// Name is the key under which the inspection results are returned
+	Name string `json:"name" yaml:"name"`
+	// Address is the host:port to connect to
+	Address string `json:"address" yaml:"address"`
+	// ServerName is the SNI server name, defaults to the host of the address
+	ServerName string `json:"server-name,omitempty" yaml:"server-name,omitempty"`
+	// StartTLS upgrades a plaintext connection before the handshake: smtp, ldap or postgres
+	StartTLS StartTLS `json:"starttls,omitempty" yaml:"starttls,omitempty"`
+	// Timeout is the duration allowed for each connection and handshake, defaults to 10s
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// Inspection is the result of inspecting a single target
+type Inspection struct {
+	Address           string          `json:"address"`
+	ServerName        string          `json:"server-name"`
+	Protocol          string          `json:"protocol"`
+	CipherSuite       string          `json:"cipher-suite"`
+	Verified          bool            `json:"verified"`
+	VerificationError string          `json:"verification-error,omitempty"`
+	OCSP              OCSP            `json:"ocsp"`
+	Certificates      []Certificate   `json:"certificates"`
+	Versions          map[string]bool `json:"versions"`
+}
+
+// OCSP is the stapled OCSP response, if any
+type OCSP struct {
+	Stapled    bool   `json:"stapled"`
+	Status     string `json:"status,omitempty"`
+	ProducedAt string `json:"produced-at,omitempty"`
+	NextUpdate string `json:"next-update,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Certificate is a certificate from the presented chain, leaf first
+type Certificate struct {
+	Subject            string   `json:"subject"`
+	Issuer             string   `json:"issuer"`
+	SerialNumber       string   `json:"serial-number"`
+	DNSNames           []string `json:"dns-names"`
+	IPAddresses        []string `json:"ip-addresses"`
+	EmailAddresses     []string `json:"email-addresses"`
+	URIs               []string `json:"uris"`
+	KeyType            string   `json:"key-type"`
+	KeySize            int      `json:"key-size"`
+	SignatureAlgorithm string   `json:"signature-algorithm"`
+	NotBefore          string   `json:"not-before"`
+	NotAfter           string   `json:"not-after"`
+	IsCA               bool     `json:"is-ca"`
+}
@@ -0,0 +1,180 @@
+package tls
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+)
+
+// ldapStartTLSOID is the LDAP extended operation that upgrades the connection (RFC 4511)
+const ldapStartTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// maxBERLength caps the length of a BER element read from the server, a StartTLS response being
+// far smaller, so a malicious length cannot allocate gigabytes
+const maxBERLength = 64 * 1024
+
+// postgresSSLRequestCode is the protocol version sent in a Postgres SSLRequest message
+const postgresSSLRequestCode = 80877103
+
+// startTLS performs the plaintext exchange that precedes the TLS handshake
+func startTLS(conn net.Conn, protocol StartTLS) error {
+	switch protocol {
+	case "":
+		return nil
+	case StartTLSSMTP:
+		return startTLSSMTP(conn)
+	case StartTLSLDAP:
+		return startTLSLDAP(conn)
+	case StartTLSPostgres:
+		return startTLSPostgres(conn)
+	default:
+		return fmt.Errorf("unsupported starttls protocol %q", protocol)
+	}
+}
+
+// startTLSSMTP issues EHLO and STARTTLS (RFC 3207). Buffered reads are safe here as the
+// server sends nothing further until it receives the client hello.
+func startTLSSMTP(conn net.Conn) error {
+	tp := textproto.NewConn(conn)
+	if _, _, err := tp.ReadResponse(220); err != nil {
+		return err
+	}
+	if err := tp.PrintfLine("EHLO lula"); err != nil {
+		return err
+	}
+	if _, _, err := tp.ReadResponse(250); err != nil {
+		return err
+	}
+	if err := tp.PrintfLine("STARTTLS"); err != nil {
+		return err
+	}
+	_, _, err := tp.ReadResponse(220)
+	return err
+}
+
+// startTLSLDAP sends a StartTLS extended request and checks the result code of the response
+func startTLSLDAP(conn net.Conn) error {
+	oid := []byte(ldapStartTLSOID)
+	// ExtendedRequest ::= [APPLICATION 23] SEQUENCE { requestName [0] LDAPOID }
+	request := append([]byte{0x80, byte(len(oid))}, oid...)
+	request = append([]byte{0x77, byte(len(request))}, request...)
+	// LDAPMessage ::= SEQUENCE { messageID INTEGER, protocolOp }
+	message := append([]byte{0x02, 0x01, 0x01}, request...)
+	message = append([]byte{0x30, byte(len(message))}, message...)
+	if _, err := conn.Write(message); err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(conn)
+	body, err := readBER(r, 0x30)
+	if err != nil {
+		return err
+	}
+	// skip the messageID
+	if len(body) < 3 || body[0] != 0x02 {
+		return fmt.Errorf("malformed LDAP response")
+	}
+	if _, body, err = parseBERLength(body[1:]); err != nil {
+		return fmt.Errorf("malformed LDAP response: %v", err)
+	}
+	// ExtendedResponse ::= [APPLICATION 24] SEQUENCE { resultCode ENUMERATED, ... }
+	if len(body) < 2 || body[0] != 0x78 {
+		return fmt.Errorf("unexpected LDAP response")
+	}
+	op, _, err := parseBERLength(body[1:])
+	if err != nil {
+		return err
+	}
+	if len(op) < 3 || op[0] != 0x0a || op[1] != 0x01 {
+		return fmt.Errorf("malformed LDAP extended response")
+	}
+	if op[2] != 0 {
+		return fmt.Errorf("LDAP server refused StartTLS with result code %d", op[2])
+	}
+	return nil
+}
+
+// readBER reads a single BER element with the expected tag and returns its contents
+func readBER(r io.ByteReader, tag byte) ([]byte, error) {
+	t, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if t != tag {
+		return nil, fmt.Errorf("unexpected BER tag 0x%x", t)
+	}
+	l, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	length := int(l)
+	if l&0x80 != 0 {
+		n := int(l & 0x7f)
+		if n == 0 || n > 4 {
+			return nil, fmt.Errorf("unsupported BER length")
+		}
+		length = 0
+		for i := 0; i < n; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxBERLength {
+		return nil, fmt.Errorf("BER length %d exceeds the maximum of %d bytes", length, maxBERLength)
+	}
+	body := make([]byte, length)
+	for i := range body {
+		if body[i], err = r.ReadByte(); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// parseBERLength decodes the length prefix of b and returns the contents and the remainder
+func parseBERLength(b []byte) ([]byte, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, fmt.Errorf("truncated BER length")
+	}
+	length, offset := int(b[0]), 1
+	if b[0]&0x80 != 0 {
+		n := int(b[0] & 0x7f)
+		if n == 0 || n > 4 || len(b) < 1+n {
+			return nil, nil, fmt.Errorf("unsupported BER length")
+		}
+		length = 0
+		for _, c := range b[1 : 1+n] {
+			length = length<<8 | int(c)
+		}
+		offset += n
+	}
+	if len(b) < offset+length {
+		return nil, nil, fmt.Errorf("truncated BER element")
+	}
+	return b[offset : offset+length], b[offset+length:], nil
+}
+
+// startTLSPostgres sends an SSLRequest and expects the server to answer 'S'
+func startTLSPostgres(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return err
+	}
+	if response[0] != 'S' {
+		return fmt.Errorf("postgres server does not support SSL")
+	}
+	return nil
+}
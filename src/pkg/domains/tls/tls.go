@@ -0,0 +1,206 @@
+package tls
+
+import (
+	"context"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var defaultTimeout = 10 * time.Second
+
+// probeVersions are the protocol versions that are individually tested for acceptance
+var probeVersions = []uint16{
+	cryptotls.VersionTLS10,
+	cryptotls.VersionTLS11,
+	cryptotls.VersionTLS12,
+	cryptotls.VersionTLS13,
+}
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a TLS domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+	if len(spec.Targets) == 0 {
+		return nil, fmt.Errorf("tls-spec must contain at least one target")
+	}
+
+	names := make(map[string]bool, len(spec.Targets))
+	for _, t := range spec.Targets {
+		if t.Name == "" {
+			return nil, fmt.Errorf("target name cannot be empty")
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("target name %s must be unique", t.Name)
+		}
+		names[t.Name] = true
+
+		if _, _, err := net.SplitHostPort(t.Address); err != nil {
+			return nil, fmt.Errorf("target %s address must be host:port: %w", t.Name, err)
+		}
+		switch t.StartTLS {
+		case "", StartTLSSMTP, StartTLSLDAP, StartTLSPostgres:
+		default:
+			return nil, fmt.Errorf("target %s has unsupported starttls protocol %q", t.Name, t.StartTLS)
+		}
+		if t.Timeout != "" {
+			if _, err := time.ParseDuration(t.Timeout); err != nil {
+				return nil, fmt.Errorf("target %s has invalid timeout %s: %w", t.Name, t.Timeout, err)
+			}
+		}
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources connects to each target and returns the inspection results, keyed by target name.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	var errs error
+	drs := make(types.DomainResources, len(d.Spec.Targets))
+	for _, target := range d.Spec.Targets {
+		inspection, err := inspect(ctx, target)
+		var resource map[string]interface{}
+		if err == nil {
+			resource, err = toResource(inspection)
+		}
+		if err != nil {
+			// Assign empty data value for reporting purposes
+			drs[target.Name] = map[string]interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("error inspecting target %s: %w", target.Name, err))
+			continue
+		}
+		drs[target.Name] = resource
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns false; the tls domain only performs handshakes and sends no application data.
+func (d Domain) IsExecutable() bool { return false }
+
+func inspect(ctx context.Context, target Target) (*Inspection, error) {
+	timeout := defaultTimeout
+	if target.Timeout != "" {
+		timeout, _ = time.ParseDuration(target.Timeout)
+	}
+	serverName := target.ServerName
+	if serverName == "" {
+		serverName, _, _ = net.SplitHostPort(target.Address)
+	}
+
+	state, err := handshake(ctx, target, serverName, timeout, cryptotls.VersionTLS10, cryptotls.VersionTLS13)
+	if err != nil {
+		var tlsErr handshakeError
+		if errors.As(err, &tlsErr) {
+			return nil, fmt.Errorf("handshake failed: %w", tlsErr.err)
+		}
+		return nil, err
+	}
+
+	inspection := &Inspection{
+		Address:      target.Address,
+		ServerName:   serverName,
+		Protocol:     cryptotls.VersionName(state.Version),
+		CipherSuite:  cryptotls.CipherSuiteName(state.CipherSuite),
+		Certificates: make([]Certificate, 0, len(state.PeerCertificates)),
+		OCSP:         ocspStatus(state),
+		Versions:     make(map[string]bool, len(probeVersions)),
+	}
+	for _, cert := range state.PeerCertificates {
+		inspection.Certificates = append(inspection.Certificates, certificate(cert))
+	}
+	if err := verify(state, serverName); err != nil {
+		inspection.VerificationError = err.Error()
+	} else {
+		inspection.Verified = true
+	}
+
+	for _, version := range probeVersions {
+		_, err := handshake(ctx, target, serverName, timeout, version, version)
+		var tlsErr handshakeError
+		if err != nil && !errors.As(err, &tlsErr) {
+			return nil, fmt.Errorf("error probing %s: %w", cryptotls.VersionName(version), err)
+		}
+		inspection.Versions[cryptotls.VersionName(version)] = err == nil
+	}
+
+	return inspection, nil
+}
+
+// handshakeError is returned when the connection succeeded but the TLS handshake did not,
+// which for a version probe means the version was not accepted
+type handshakeError struct {
+	err error
+}
+
+func (e handshakeError) Error() string { return e.err.Error() }
+
+// handshake connects to the target, performs any STARTTLS upgrade and completes a TLS handshake
+// limited to the given versions. Certificates are not verified here so that endpoints with
+// invalid certificates can still be inspected.
+func handshake(ctx context.Context, target Target, serverName string, timeout time.Duration, minVersion, maxVersion uint16) (cryptotls.ConnectionState, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return cryptotls.ConnectionState{}, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return cryptotls.ConnectionState{}, err
+	}
+
+	if err := startTLS(conn, target.StartTLS); err != nil {
+		return cryptotls.ConnectionState{}, fmt.Errorf("starttls %s failed: %w", target.StartTLS, err)
+	}
+
+	tlsConn := cryptotls.Client(conn, &cryptotls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true, // #nosec G402
+		MinVersion:         minVersion,
+		MaxVersion:         maxVersion,
+		CipherSuites:       allCipherSuites(),
+	})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return cryptotls.ConnectionState{}, handshakeError{err: err}
+	}
+	return tlsConn.ConnectionState(), nil
+}
+
+// allCipherSuites offers every suite Go implements, including insecure ones, so that endpoints
+// which only accept legacy suites are still detected
+func allCipherSuites() []uint16 {
+	var ids []uint16
+	for _, s := range cryptotls.CipherSuites() {
+		ids = append(ids, s.ID)
+	}
+	for _, s := range cryptotls.InsecureCipherSuites() {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+// verify validates the presented chain against the system roots and the server name
+func verify(state cryptotls.ConnectionState, serverName string) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificates presented")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Intermediates: intermediates,
+	})
+	return err
+}
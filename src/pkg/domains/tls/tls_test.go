@@ -0,0 +1,322 @@
+package tls
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var _ types.Domain = (*Domain)(nil)
+
+func TestCreateDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *Spec
+		wantErr bool
+	}{
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "no targets",
+			spec:    &Spec{},
+			wantErr: true,
+		},
+		{
+			name:    "missing port",
+			spec:    &Spec{Targets: []Target{{Name: "web", Address: "example.com"}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate names",
+			spec:    &Spec{Targets: []Target{{Name: "web", Address: "a:443"}, {Name: "web", Address: "b:443"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid starttls",
+			spec:    &Spec{Targets: []Target{{Name: "mail", Address: "mail:143", StartTLS: "imap"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout",
+			spec:    &Spec{Targets: []Target{{Name: "web", Address: "a:443", Timeout: "ten"}}},
+			wantErr: true,
+		},
+		{
+			name: "valid spec",
+			spec: &Spec{Targets: []Target{{Name: "db", Address: "db:5432", StartTLS: StartTLSPostgres, ServerName: "db.internal", Timeout: "5s"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDomain(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResources(t *testing.T) {
+	serverConfig := testServerConfig(t)
+
+	tests := []struct {
+		name     string
+		starttls StartTLS
+		preamble func(net.Conn) error
+	}{
+		{
+			name: "direct",
+		},
+		{
+			name:     "smtp",
+			starttls: StartTLSSMTP,
+			preamble: func(conn net.Conn) error {
+				tp := textproto.NewConn(conn)
+				if err := tp.PrintfLine("220 mail.example.com ESMTP"); err != nil {
+					return err
+				}
+				if _, err := tp.ReadLine(); err != nil {
+					return err
+				}
+				if err := tp.PrintfLine("250-mail.example.com\r\n250 STARTTLS"); err != nil {
+					return err
+				}
+				if _, err := tp.ReadLine(); err != nil {
+					return err
+				}
+				return tp.PrintfLine("220 Ready to start TLS")
+			},
+		},
+		{
+			name:     "ldap",
+			starttls: StartTLSLDAP,
+			preamble: func(conn net.Conn) error {
+				if _, err := readBER(bufio.NewReader(conn), 0x30); err != nil {
+					return err
+				}
+				// messageID 1, ExtendedResponse with resultCode success
+				_, err := conn.Write([]byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00})
+				return err
+			},
+		},
+		{
+			name:     "postgres",
+			starttls: StartTLSPostgres,
+			preamble: func(conn net.Conn) error {
+				if _, err := io.ReadFull(conn, make([]byte, 8)); err != nil {
+					return err
+				}
+				_, err := conn.Write([]byte{'S'})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := serve(t, serverConfig, tt.preamble)
+			d := Domain{Spec: &Spec{Targets: []Target{{
+				Name:       "target",
+				Address:    addr,
+				ServerName: "lula.example.com",
+				StartTLS:   tt.starttls,
+			}}}}
+
+			resources, err := d.GetResources(context.Background())
+			require.NoError(t, err)
+
+			target := resources["target"].(map[string]interface{})
+			require.Equal(t, "TLS 1.3", target["protocol"])
+			require.NotEmpty(t, target["cipher-suite"])
+			require.Equal(t, "lula.example.com", target["server-name"])
+			// the test CA is not a system root
+			require.Equal(t, false, target["verified"])
+			require.Contains(t, target["verification-error"], "unknown authority")
+			require.Equal(t, map[string]interface{}{
+				"TLS 1.0": false,
+				"TLS 1.1": false,
+				"TLS 1.2": true,
+				"TLS 1.3": true,
+			}, target["versions"])
+
+			ocspStatus := target["ocsp"].(map[string]interface{})
+			require.Equal(t, true, ocspStatus["stapled"])
+			require.Equal(t, "good", ocspStatus["status"])
+
+			certs := target["certificates"].([]interface{})
+			require.Len(t, certs, 2)
+			leaf := certs[0].(map[string]interface{})
+			require.Equal(t, "CN=lula.example.com,O=Lula", leaf["subject"])
+			require.Equal(t, "CN=Lula Test CA", leaf["issuer"])
+			require.Equal(t, []interface{}{"lula.example.com"}, leaf["dns-names"])
+			require.Equal(t, []interface{}{"127.0.0.1"}, leaf["ip-addresses"])
+			require.Equal(t, "ECDSA", leaf["key-type"])
+			require.Equal(t, 256.0, leaf["key-size"])
+			require.Equal(t, "2024-01-01T00:00:00Z", leaf["not-before"])
+			require.Equal(t, "2099-01-01T00:00:00Z", leaf["not-after"])
+			require.Equal(t, false, leaf["is-ca"])
+			require.Equal(t, true, certs[1].(map[string]interface{})["is-ca"])
+		})
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		d := Domain{Spec: &Spec{Targets: []Target{{Name: "closed", Address: addr, Timeout: "1s"}}}}
+		resources, err := d.GetResources(context.Background())
+		require.Error(t, err)
+		require.Equal(t, map[string]interface{}{}, resources["closed"])
+	})
+}
+
+func TestStartTLSLDAPMalformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		response []byte
+		err      string
+	}{
+		{
+			name:     "messageID longer than the response",
+			response: []byte{0x30, 0x04, 0x02, 0x7f, 0x01, 0x78},
+			err:      "malformed LDAP response",
+		},
+		{
+			name:     "missing extended response",
+			response: []byte{0x30, 0x03, 0x02, 0x01, 0x01},
+			err:      "unexpected LDAP response",
+		},
+		{
+			name:     "refused",
+			response: []byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x02, 0x04, 0x00, 0x04, 0x00},
+			err:      "result code 2",
+		},
+		{
+			name:     "length of 4 GiB",
+			response: []byte{0x30, 0x84, 0xff, 0xff, 0xff, 0xff},
+			err:      "exceeds the maximum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			go func() {
+				defer server.Close()
+				if _, err := readBER(bufio.NewReader(server), 0x30); err != nil {
+					return
+				}
+				_, _ = server.Write(tt.response)
+			}()
+
+			err := startTLSLDAP(client)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+// serve accepts connections until the test ends, running the plaintext preamble before the handshake
+func serve(t *testing.T, config *cryptotls.Config, preamble func(net.Conn) error) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+				if preamble != nil {
+					if err := preamble(conn); err != nil {
+						return
+					}
+				}
+				_ = cryptotls.Server(conn, config).Handshake()
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// testServerConfig builds a CA-signed leaf certificate with a stapled OCSP response
+func testServerConfig(t *testing.T) *cryptotls.Config {
+	t.Helper()
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Lula Test CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "lula.example.com", Organization: []string{"Lula"}},
+		DNSNames:     []string{"lula.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	staple, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: leafTemplate.SerialNumber,
+		ThisUpdate:   notBefore,
+		NextUpdate:   notAfter,
+	}, caKey)
+	require.NoError(t, err)
+
+	return &cryptotls.Config{
+		MinVersion: cryptotls.VersionTLS12,
+		Certificates: []cryptotls.Certificate{{
+			Certificate: [][]byte{leafDER, caDER},
+			PrivateKey:  leafKey,
+			OCSPStaple:  staple,
+		}},
+	}
+}
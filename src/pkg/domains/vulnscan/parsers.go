@@ -0,0 +1,290 @@
+package vulnscan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownFormat = errors.New("unable to determine report format")
+
+// detectFormat infers the report format from the top-level keys of the report
+func detectFormat(data []byte) (Format, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", fmt.Errorf("report is not a JSON object: %w", err)
+	}
+
+	if _, ok := probe["runs"]; ok {
+		return FormatSarif, nil
+	}
+	if _, ok := probe["matches"]; ok {
+		return FormatGrype, nil
+	}
+	if _, ok := probe["Results"]; ok {
+		return FormatTrivy, nil
+	}
+	if _, ok := probe["SchemaVersion"]; ok {
+		return FormatTrivy, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// parseReport converts the raw report into the common findings schema
+func parseReport(format Format, data []byte) ([]Finding, error) {
+	switch format {
+	case FormatGrype:
+		return parseGrype(data)
+	case FormatTrivy:
+		return parseTrivy(data)
+	case FormatSarif:
+		return parseSarif(data)
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+				State    string   `json:"state"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name      string `json:"name"`
+			Version   string `json:"version"`
+			Type      string `json:"type"`
+			Locations []struct {
+				Path string `json:"path"`
+			} `json:"locations"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+func parseGrype(data []byte) ([]Finding, error) {
+	var report grypeReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error parsing grype report: %w", err)
+	}
+
+	findings := make([]Finding, 0, len(report.Matches))
+	for _, m := range report.Matches {
+		var location string
+		if len(m.Artifact.Locations) > 0 {
+			location = m.Artifact.Locations[0].Path
+		}
+		findings = append(findings, Finding{
+			ID:       m.Vulnerability.ID,
+			Severity: normalizeSeverity(m.Vulnerability.Severity),
+			Package: Package{
+				Name:    m.Artifact.Name,
+				Version: m.Artifact.Version,
+				Type:    m.Artifact.Type,
+			},
+			FixAvailable:  m.Vulnerability.Fix.State == "fixed" || len(m.Vulnerability.Fix.Versions) > 0,
+			FixedVersions: m.Vulnerability.Fix.Versions,
+			Location:      location,
+			Description:   m.Vulnerability.Description,
+		})
+	}
+	return findings, nil
+}
+
+type trivyReport struct {
+	ArtifactName string `json:"ArtifactName"`
+	Results      []struct {
+		Target          string `json:"Target"`
+		Type            string `json:"Type"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			PkgPath          string `json:"PkgPath"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func parseTrivy(data []byte) ([]Finding, error) {
+	var report trivyReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error parsing trivy report: %w", err)
+	}
+
+	findings := make([]Finding, 0)
+	for _, r := range report.Results {
+		for _, v := range r.Vulnerabilities {
+			location := v.PkgPath
+			if location == "" {
+				location = r.Target
+			}
+			var fixed []string
+			if v.FixedVersion != "" {
+				for _, f := range strings.Split(v.FixedVersion, ",") {
+					fixed = append(fixed, strings.TrimSpace(f))
+				}
+			}
+			findings = append(findings, Finding{
+				ID:       v.VulnerabilityID,
+				Severity: normalizeSeverity(v.Severity),
+				Package: Package{
+					Name:    v.PkgName,
+					Version: v.InstalledVersion,
+					Type:    r.Type,
+				},
+				FixAvailable:  len(fixed) > 0,
+				FixedVersions: fixed,
+				Location:      location,
+				Description:   v.Title,
+			})
+		}
+	}
+	return findings, nil
+}
+
+type sarifReport struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Name  string      `json:"name"`
+				Rules []sarifRule `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex *int   `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+			Fixes []json.RawMessage `json:"fixes"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+type sarifRule struct {
+	ID                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func parseSarif(data []byte) ([]Finding, error) {
+	var report sarifReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error parsing sarif report: %w", err)
+	}
+
+	findings := make([]Finding, 0)
+	for _, run := range report.Runs {
+		rules := make(map[string]sarifRule, len(run.Tool.Driver.Rules))
+		for _, rule := range run.Tool.Driver.Rules {
+			rules[rule.ID] = rule
+		}
+
+		for _, r := range run.Results {
+			rule, ok := rules[r.RuleID]
+			// ruleIndex defaults to -1 when the result does not reference a rule by index
+			if !ok && r.RuleIndex != nil && *r.RuleIndex >= 0 && *r.RuleIndex < len(run.Tool.Driver.Rules) {
+				rule = run.Tool.Driver.Rules[*r.RuleIndex]
+			}
+			id := r.RuleID
+			if id == "" {
+				id = rule.ID
+			}
+
+			var location string
+			if len(r.Locations) > 0 {
+				pl := r.Locations[0].PhysicalLocation
+				location = pl.ArtifactLocation.URI
+				if pl.Region.StartLine > 0 {
+					location = fmt.Sprintf("%s:%d", location, pl.Region.StartLine)
+				}
+			}
+
+			findings = append(findings, Finding{
+				ID:           id,
+				Severity:     sarifSeverity(r.Level, rule),
+				Package:      Package{Type: run.Tool.Driver.Name},
+				FixAvailable: len(r.Fixes) > 0,
+				Location:     location,
+				Description:  r.Message.Text,
+			})
+		}
+	}
+	return findings, nil
+}
+
+// sarifSeverity prefers the numeric "security-severity" rule property (as emitted by
+// code scanning tools) and falls back to the SARIF result level
+func sarifSeverity(level string, rule sarifRule) string {
+	if raw, ok := rule.Properties["security-severity"]; ok {
+		var score float64
+		var err error
+		switch s := raw.(type) {
+		case string:
+			score, err = strconv.ParseFloat(s, 64)
+		case float64:
+			score = s
+		}
+		if err == nil {
+			switch {
+			case score >= 9.0:
+				return SeverityCritical
+			case score >= 7.0:
+				return SeverityHigh
+			case score >= 4.0:
+				return SeverityMedium
+			case score > 0:
+				return SeverityLow
+			}
+		}
+	}
+
+	if level == "" {
+		level = rule.DefaultConfiguration.Level
+	}
+	switch level {
+	case "error":
+		return SeverityHigh
+	case "warning", "":
+		// warning is the SARIF default level
+		return SeverityMedium
+	case "note":
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// normalizeSeverity maps scanner severities onto the lower-case common severities
+func normalizeSeverity(severity string) string {
+	switch s := strings.ToLower(strings.TrimSpace(severity)); s {
+	case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityNegligible:
+		return s
+	case "moderate":
+		return SeverityMedium
+	default:
+		return SeverityUnknown
+	}
+}
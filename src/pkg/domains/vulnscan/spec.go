@@ -0,0 +1,53 @@
+package vulnscan
+
+// Format is the format of a vulnerability scan report
+type Format string
+
+const (
+	FormatGrype Format = "grype"
+	FormatTrivy Format = "trivy"
+	FormatSarif Format = "sarif"
+)
+
+// Spec is the user-defined list of scan reports to load
+type Spec struct {
+	Reports []Report `json:"reports" yaml:"reports"`
+}
+
+// Report is a single vulnerability scan report
+type Report struct {
+	// Name is the key under which the report findings are returned
+	Name string `json:"name" yaml:"name"`
+	// Path is the local path or URL of the report
+	Path string `json:"path" yaml:"path"`
+	// Format is optional; when empty the format is inferred from the report contents
+	Format Format `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+// Finding is the common representation of a single scanner finding
+type Finding struct {
+	ID            string   `json:"id"`
+	Severity      string   `json:"severity"`
+	Package       Package  `json:"package"`
+	FixAvailable  bool     `json:"fix-available"`
+	FixedVersions []string `json:"fixed-versions,omitempty"`
+	Location      string   `json:"location"`
+	Description   string   `json:"description,omitempty"`
+}
+
+// Package is the package affected by a finding
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+}
+
+// Severity values for the normalized findings
+const (
+	SeverityCritical   = "critical"
+	SeverityHigh       = "high"
+	SeverityMedium     = "medium"
+	SeverityLow        = "low"
+	SeverityNegligible = "negligible"
+	SeverityUnknown    = "unknown"
+)
@@ -0,0 +1,142 @@
+package vulnscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+type Domain struct {
+	Spec *Spec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+// CreateDomain validates the spec and returns a vulnerability scan domain
+func CreateDomain(spec *Spec) (types.Domain, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is nil")
+	}
+	if len(spec.Reports) == 0 {
+		return nil, fmt.Errorf("vuln-scan-spec must contain at least one report")
+	}
+
+	names := make(map[string]bool, len(spec.Reports))
+	for _, r := range spec.Reports {
+		if r.Name == "" {
+			return nil, fmt.Errorf("report name cannot be empty")
+		}
+		if r.Path == "" {
+			return nil, fmt.Errorf("report %s path cannot be empty", r.Name)
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("report name %s must be unique", r.Name)
+		}
+		names[r.Name] = true
+
+		switch r.Format {
+		case "", FormatGrype, FormatTrivy, FormatSarif:
+		default:
+			return nil, fmt.Errorf("report %s has unsupported format %q", r.Name, r.Format)
+		}
+	}
+
+	return Domain{Spec: spec}, nil
+}
+
+// GetResources loads each report and returns its findings in the common schema, keyed by report name.
+func (d Domain) GetResources(ctx context.Context) (types.DomainResources, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok {
+		// if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	dst, err := os.MkdirTemp("", "lula-vuln-scan-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dst)
+
+	var errs error
+	drs := make(types.DomainResources, len(d.Spec.Reports))
+	for i, report := range d.Spec.Reports {
+		resource, err := loadReport(ctx, report, filepath.Join(dst, fmt.Sprintf("%d-%s", i, filepath.Base(report.Path))), workDir)
+		if err != nil {
+			// Assign empty data value for reporting purposes
+			drs[report.Name] = map[string]interface{}{}
+			errs = errors.Join(errs, fmt.Errorf("error loading report %s: %w", report.Name, err))
+			continue
+		}
+		drs[report.Name] = resource
+	}
+
+	return drs, errs
+}
+
+// IsExecutable returns false; the vuln-scan domain only reads existing reports.
+func (d Domain) IsExecutable() bool { return false }
+
+func loadReport(ctx context.Context, report Report, dst, workDir string) (map[string]interface{}, error) {
+	path, err := network.DownloadFile(ctx, dst, report.Path, workDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	format := report.Format
+	if format == "" {
+		format, err = detectFormat(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	findings, err := parseReport(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResource(format, findings)
+}
+
+// toResource builds the domain resource for a report; the findings are round-tripped through
+// JSON so providers and tests receive plain maps and slices.
+func toResource(format Format, findings []Finding) (map[string]interface{}, error) {
+	summary := map[string]int{
+		"total":            len(findings),
+		SeverityCritical:   0,
+		SeverityHigh:       0,
+		SeverityMedium:     0,
+		SeverityLow:        0,
+		SeverityNegligible: 0,
+		SeverityUnknown:    0,
+		"fix-available":    0,
+	}
+	for _, f := range findings {
+		summary[f.Severity]++
+		if f.FixAvailable {
+			summary["fix-available"]++
+		}
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"format":   format,
+		"findings": findings,
+		"summary":  summary,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resource map[string]interface{}
+	err = json.Unmarshal(b, &resource)
+	return resource, err
+}
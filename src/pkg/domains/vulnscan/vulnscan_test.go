@@ -0,0 +1,180 @@
+package vulnscan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var _ types.Domain = (*Domain)(nil)
+
+func TestCreateDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *Spec
+		wantErr bool
+	}{
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "no reports",
+			spec:    &Spec{},
+			wantErr: true,
+		},
+		{
+			name:    "missing path",
+			spec:    &Spec{Reports: []Report{{Name: "grype"}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate names",
+			spec:    &Spec{Reports: []Report{{Name: "scan", Path: "a.json"}, {Name: "scan", Path: "b.json"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			spec:    &Spec{Reports: []Report{{Name: "scan", Path: "a.json", Format: "snyk"}}},
+			wantErr: true,
+		},
+		{
+			name: "valid spec",
+			spec: &Spec{Reports: []Report{{Name: "scan", Path: "a.json", Format: FormatTrivy}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDomain(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResources(t *testing.T) {
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+
+	t.Run("all formats", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Reports: []Report{
+			{Name: "grype", Path: "grype.json"},
+			{Name: "trivy", Path: "trivy.json"},
+			{Name: "sarif", Path: "results.sarif", Format: FormatSarif},
+		}}}
+
+		resources, err := d.GetResources(ctx)
+		require.NoError(t, err)
+
+		want := types.DomainResources{
+			"grype": map[string]interface{}{
+				"format": "grype",
+				"findings": []interface{}{
+					map[string]interface{}{
+						"id":             "CVE-2023-44487",
+						"severity":       "high",
+						"package":        map[string]interface{}{"name": "golang.org/x/net", "version": "0.15.0", "type": "go-module"},
+						"fix-available":  true,
+						"fixed-versions": []interface{}{"0.17.0"},
+						"location":       "/usr/local/bin/app",
+						"description":    "HTTP/2 rapid reset",
+					},
+					map[string]interface{}{
+						"id":            "CVE-2022-0001",
+						"severity":      "negligible",
+						"package":       map[string]interface{}{"name": "libc6", "version": "2.36-9", "type": "deb"},
+						"fix-available": false,
+						"location":      "/var/lib/dpkg/status",
+					},
+				},
+				"summary": map[string]interface{}{
+					"total": 2.0, "critical": 0.0, "high": 1.0, "medium": 0.0, "low": 0.0,
+					"negligible": 1.0, "unknown": 0.0, "fix-available": 1.0,
+				},
+			},
+			"trivy": map[string]interface{}{
+				"format": "trivy",
+				"findings": []interface{}{
+					map[string]interface{}{
+						"id":             "CVE-2023-5363",
+						"severity":       "critical",
+						"package":        map[string]interface{}{"name": "libcrypto3", "version": "3.1.0-r4", "type": "alpine"},
+						"fix-available":  true,
+						"fixed-versions": []interface{}{"3.1.4-r0"},
+						"location":       "alpine:3.18 (alpine 3.18.0)",
+						"description":    "openssl: Incorrect cipher key and IV length processing",
+					},
+				},
+				"summary": map[string]interface{}{
+					"total": 1.0, "critical": 1.0, "high": 0.0, "medium": 0.0, "low": 0.0,
+					"negligible": 0.0, "unknown": 0.0, "fix-available": 1.0,
+				},
+			},
+			"sarif": map[string]interface{}{
+				"format": "sarif",
+				"findings": []interface{}{
+					map[string]interface{}{
+						"id":            "G401",
+						"severity":      "high",
+						"package":       map[string]interface{}{"name": "", "version": "", "type": "gosec"},
+						"fix-available": false,
+						"location":      "pkg/crypto/hash.go:12",
+						"description":   "Use of weak cryptographic primitive",
+					},
+					map[string]interface{}{
+						"id":            "G104",
+						"severity":      "low",
+						"package":       map[string]interface{}{"name": "", "version": "", "type": "gosec"},
+						"fix-available": false,
+						"location":      "main.go",
+						"description":   "Errors unhandled",
+					},
+				},
+				"summary": map[string]interface{}{
+					"total": 2.0, "critical": 0.0, "high": 1.0, "medium": 0.0, "low": 1.0,
+					"negligible": 0.0, "unknown": 0.0, "fix-available": 0.0,
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, resources); diff != "" {
+			t.Fatalf("wrong result:\n%s\n", diff)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		d := Domain{Spec: &Spec{Reports: []Report{
+			{Name: "unknown", Path: "unknown.json"},
+		}}}
+
+		resources, err := d.GetResources(ctx)
+		require.ErrorIs(t, err, ErrUnknownFormat)
+		require.Equal(t, map[string]interface{}{}, resources["unknown"])
+	})
+}
+
+func TestParseSarifRuleIndex(t *testing.T) {
+	report := []byte(`{
+		"runs": [{
+			"tool": {"driver": {"name": "scanner", "rules": [
+				{"id": "RULE-1", "defaultConfiguration": {"level": "error"}}
+			]}},
+			"results": [
+				{"ruleIndex": 0, "level": "error", "message": {"text": "indexed"}},
+				{"ruleId": "RULE-2", "ruleIndex": -1, "level": "note", "message": {"text": "unindexed"}}
+			]
+		}]
+	}`)
+
+	findings, err := parseSarif(report)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Equal(t, "RULE-1", findings[0].ID)
+	require.Equal(t, "RULE-2", findings[1].ID)
+}
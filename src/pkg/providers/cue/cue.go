@@ -0,0 +1,229 @@
+package cue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	cuelang "cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// inlineFileName is the file name used in errors for the inline definitions of the spec.cue field
+const inlineFileName = "validate.cue"
+
+// GetValidatedAssets unifies the domain resources with the CUE definitions. Each unification error
+// is a failing observation and violation keyed by its path in the resources.
+func GetValidatedAssets(ctx context.Context, spec *CueSpec, resources map[string]interface{}) (types.Result, error) {
+	var matchResult types.Result
+
+	if len(resources) == 0 {
+		return matchResult, ErrNoDomainInput
+	}
+
+	cueCtx := cuecontext.New()
+	schema, err := loadDefinitions(ctx, cueCtx, spec)
+	if err != nil {
+		return matchResult, err
+	}
+
+	// Errors are reported relative to the definition at the path
+	var prefix int
+	if spec.Path != "" {
+		path := cuelang.ParsePath(spec.Path)
+		schema = schema.LookupPath(path)
+		if !schema.Exists() {
+			return matchResult, fmt.Errorf("%w: %s", ErrPathNotFound, spec.Path)
+		}
+		prefix = len(path.Selectors())
+	}
+
+	// The resources are compiled as JSON so integers remain integers in CUE, while the decoded
+	// data guides the collection of errors
+	b, err := json.Marshal(resources)
+	if err != nil {
+		return matchResult, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return matchResult, err
+	}
+
+	unified := schema.Unify(cueCtx.CompileBytes(b))
+
+	observations := make(map[string]string)
+	for _, e := range collectErrors(unified, data, nil, prefix) {
+		path := strings.Join(errorPath(e, prefix), ".")
+		if path == "" {
+			path = "(root)"
+		}
+		format, args := e.Msg()
+		msg := fmt.Sprintf(format, args...)
+
+		matchResult.Failing += 1
+		matchResult.Violations = append(matchResult.Violations, types.Violation{
+			Resource: path,
+			Message:  msg,
+		})
+		if existing, ok := observations[path]; ok {
+			observations[path] = fmt.Sprintf("%s; %s", existing, msg)
+		} else {
+			observations[path] = fmt.Sprintf("FAIL: %s", msg)
+		}
+	}
+
+	if matchResult.Failing == 0 {
+		matchResult.Passing += 1
+	}
+	matchResult.Observations = observations
+
+	return matchResult, nil
+}
+
+// loadDefinitions compiles the inline definitions, files and module of the spec and unifies them
+func loadDefinitions(ctx context.Context, cueCtx *cuelang.Context, spec *CueSpec) (cuelang.Value, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok { // if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	values := make([]cuelang.Value, 0, len(spec.Files)+2)
+	if spec.Cue != "" {
+		values = append(values, cueCtx.CompileString(spec.Cue, cuelang.Filename(inlineFileName)))
+	}
+
+	if len(spec.Files) > 0 {
+		dst, err := os.MkdirTemp("", "lula-cue-")
+		if err != nil {
+			return cuelang.Value{}, err
+		}
+		defer os.RemoveAll(dst)
+
+		for i, src := range spec.Files {
+			// Each file gets its own directory, so files with the same name do not collide
+			dir := filepath.Join(dst, strconv.Itoa(i))
+			if err := os.Mkdir(dir, 0o700); err != nil {
+				return cuelang.Value{}, err
+			}
+			tmp, err := network.DownloadFile(ctx, filepath.Join(dir, filepath.Base(src)), src, workDir)
+			if err != nil {
+				return cuelang.Value{}, fmt.Errorf("%w %s: %w", ErrDownloadFile, src, err)
+			}
+			content, err := os.ReadFile(filepath.Clean(tmp))
+			if err != nil {
+				return cuelang.Value{}, fmt.Errorf("%w %s: %w", ErrReadFile, src, err)
+			}
+			values = append(values, cueCtx.CompileBytes(content, cuelang.Filename(filepath.Base(src))))
+		}
+	}
+
+	if spec.Module != "" {
+		dir := spec.Module
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workDir, dir)
+		}
+		instances := load.Instances([]string{"."}, &load.Config{Dir: dir})
+		if len(instances) != 1 || instances[0].Err != nil {
+			var err error
+			if len(instances) > 0 {
+				err = instances[0].Err
+			}
+			return cuelang.Value{}, fmt.Errorf("%w %s: %w", ErrLoadModule, spec.Module, err)
+		}
+		values = append(values, cueCtx.BuildInstance(instances[0]))
+	}
+
+	schema := cueCtx.CompileString("_")
+	for _, v := range values {
+		if err := v.Err(); err != nil {
+			message.Debugf("failed to compile cue: %s", cueerrors.Details(err, nil))
+			return cuelang.Value{}, fmt.Errorf("%w: %w", ErrCompileCue, err)
+		}
+		schema = schema.Unify(v)
+	}
+	if err := schema.Err(); err != nil {
+		return cuelang.Value{}, fmt.Errorf("%w: %w", ErrCompileCue, err)
+	}
+
+	return schema, nil
+}
+
+// collectErrors returns the errors of the unified value at the path, recursing into the fields
+// and elements of the data. CUE stops validating a struct or list at its first error, so the
+// children are validated individually to report every error.
+func collectErrors(unified cuelang.Value, data interface{}, path []cuelang.Selector, prefix int) []cueerrors.Error {
+	v := unified.LookupPath(cuelang.MakePath(path...))
+	err := v.Validate(cuelang.Concrete(true), cuelang.All())
+	if err == nil {
+		return nil
+	}
+
+	children := make(map[string]bool)
+	errs := make([]cueerrors.Error, 0)
+	switch d := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			children[key] = true
+			errs = append(errs, collectErrors(unified, d[key], append(path[:len(path):len(path)], cuelang.Str(key)), prefix)...)
+		}
+		// Fields of the definitions missing from the data are validated too, as an error in another
+		// field hides them from the errors of the struct
+		if iter, err := v.Fields(); err == nil {
+			for iter.Next() {
+				key := iter.Selector().Unquoted()
+				if children[key] {
+					continue
+				}
+				children[key] = true
+				errs = append(errs, collectErrors(unified, nil, append(path[:len(path):len(path)], iter.Selector()), prefix)...)
+			}
+		}
+	case []interface{}:
+		for i, element := range d {
+			children[strconv.Itoa(i)] = true
+			errs = append(errs, collectErrors(unified, element, append(path[:len(path):len(path)], cuelang.Index(i)), prefix)...)
+		}
+	}
+
+	// Keep the errors of this value that are not within a child already validated, e.g. required
+	// fields missing from the data
+	seen := make(map[string]bool)
+	for _, e := range cueerrors.Errors(err) {
+		errPath := errorPath(e, prefix)
+		if len(errPath) > len(path) && children[errPath[len(path)]] {
+			continue
+		}
+		key := strings.Join(errPath, ".") + ":" + e.Error()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// errorPath returns the path of the error without the selectors of the spec path
+func errorPath(e cueerrors.Error, prefix int) []string {
+	path := e.Path()
+	if len(path) < prefix {
+		return nil
+	}
+	return path[prefix:]
+}
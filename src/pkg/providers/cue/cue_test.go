@@ -0,0 +1,144 @@
+package cue_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/cue"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestGetValidatedAssets(t *testing.T) {
+	t.Parallel()
+
+	resources := types.DomainResources{
+		"pods": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "nginx-1", "namespace": "apps"},
+				"spec":     map[string]interface{}{"replicas": 2},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "nginx-2", "namespace": "default"},
+				"spec":     map[string]interface{}{"replicas": 1},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"namespace": "apps"},
+				"spec":     map[string]interface{}{"replicas": 3},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		spec           *cue.CueSpec
+		wantPassing    int
+		wantFailing    int
+		wantViolations []string
+		wantErr        error
+	}{
+		{
+			name: "inline definitions pass",
+			spec: &cue.CueSpec{
+				Cue:  "#Resources: pods: [...{metadata: {namespace: string, ...}, ...}]",
+				Path: "#Resources",
+			},
+			wantPassing: 1,
+		},
+		{
+			name: "inline definitions report every error",
+			spec: &cue.CueSpec{
+				Cue: `
+#Pod: {
+	metadata: {
+		name:      string
+		namespace: !="default"
+	}
+	spec: replicas: >=2
+}
+pods: [...#Pod]
+`,
+			},
+			wantFailing:    3,
+			wantViolations: []string{"pods.1.metadata.namespace", "pods.1.spec.replicas", "pods.2.metadata.name"},
+		},
+		{
+			name: "files",
+			spec: &cue.CueSpec{
+				Files: []string{"replicas.cue"},
+			},
+			wantFailing:    1,
+			wantViolations: []string{"pods.1.spec.replicas"},
+		},
+		{
+			name: "module with imports",
+			spec: &cue.CueSpec{
+				Module: "module",
+				Path:   "#Resources",
+			},
+			wantFailing:    3,
+			wantViolations: []string{"pods.1.metadata.namespace", "pods.1.spec.replicas", "pods.2.metadata.name"},
+		},
+		{
+			name: "path not found",
+			spec: &cue.CueSpec{
+				Cue:  "a: int",
+				Path: "#Resources",
+			},
+			wantErr: cue.ErrPathNotFound,
+		},
+		{
+			name: "missing file",
+			spec: &cue.CueSpec{
+				Files: []string{"missing.cue"},
+			},
+			wantErr: cue.ErrDownloadFile,
+		},
+		{
+			name: "missing module",
+			spec: &cue.CueSpec{
+				Module: "missing",
+			},
+			wantErr: cue.ErrLoadModule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+			provider, err := cue.CreateCueProvider(ctx, tt.spec)
+			if err != nil {
+				t.Fatalf("CreateCueProvider() error: %v", err)
+			}
+
+			result, err := provider.Evaluate(ctx, resources)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Passing != tt.wantPassing || result.Failing != tt.wantFailing {
+				t.Errorf("Passing = %d, Failing = %d, want %d and %d", result.Passing, result.Failing, tt.wantPassing, tt.wantFailing)
+			}
+
+			violations := make([]string, 0, len(result.Violations))
+			for _, v := range result.Violations {
+				violations = append(violations, v.Resource)
+				if result.Observations[v.Resource] == "" {
+					t.Errorf("missing observation for violation %s", v)
+				}
+			}
+			if len(tt.wantViolations) > 0 && !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("Violations = %v, want %v", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestGetValidatedAssetsNoResources(t *testing.T) {
+	t.Parallel()
+
+	_, err := cue.GetValidatedAssets(context.Background(), &cue.CueSpec{Cue: "a: int"}, nil)
+	if !errors.Is(err, cue.ErrNoDomainInput) {
+		t.Errorf("GetValidatedAssets() error = %v, want %v", err, cue.ErrNoDomainInput)
+	}
+}
@@ -0,0 +1,2 @@
+module: "example.com/lula"
+language: version: "v0.10.0"
@@ -0,0 +1,9 @@
+package pods
+
+#Pod: {
+	metadata: {
+		name:      string
+		namespace: string & !="default"
+	}
+	spec: replicas: int & >=2
+}
@@ -0,0 +1,7 @@
+package validate
+
+import k8s "example.com/lula/pods"
+
+#Resources: {
+	pods: [...k8s.#Pod]
+}
@@ -0,0 +1,3 @@
+pods: [...{
+	spec: replicas: int & >=2
+}]
@@ -0,0 +1,76 @@
+package cue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cuelang "cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var (
+	ErrNilSpec       = errors.New("spec is nil")
+	ErrEmptySource   = errors.New("one of cue, files or module must be specified")
+	ErrInvalidPath   = errors.New("path is not a valid CUE path")
+	ErrCompileCue    = errors.New("failed to compile cue")
+	ErrDownloadFile  = errors.New("error downloading file")
+	ErrReadFile      = errors.New("error reading file")
+	ErrLoadModule    = errors.New("error loading module")
+	ErrPathNotFound  = errors.New("path not found in cue definitions")
+	ErrNoDomainInput = errors.New("cue validation not performed - no resources to validate")
+)
+
+type CueProvider struct {
+	// Spec is the specification of the CUE definitions
+	Spec *CueSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+func CreateCueProvider(_ context.Context, spec *CueSpec) (types.Provider, error) {
+	// Check validity of spec
+	if spec == nil {
+		return nil, ErrNilSpec
+	}
+
+	if spec.Cue == "" && len(spec.Files) == 0 && spec.Module == "" {
+		return nil, ErrEmptySource
+	}
+
+	if spec.Path != "" {
+		if err := cuelang.ParsePath(spec.Path).Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+		}
+	}
+
+	// Inline definitions are compiled up front so syntax errors are reported with the validation
+	if spec.Cue != "" {
+		if err := cuecontext.New().CompileString(spec.Cue, cuelang.Filename(inlineFileName)).Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCompileCue, err)
+		}
+	}
+
+	return CueProvider{
+		Spec: spec,
+	}, nil
+}
+
+func (c CueProvider) Evaluate(ctx context.Context, resources types.DomainResources) (types.Result, error) {
+	return GetValidatedAssets(ctx, c.Spec, resources)
+}
+
+// CueSpec is the specification of the CUE definitions, required if the provider type is cue. The
+// inline definitions, files and module are unified together and then with the domain resources.
+type CueSpec struct {
+	// Optional: Cue is inline CUE
+	Cue string `json:"cue,omitempty" yaml:"cue,omitempty"`
+	// Optional: Files is a list of CUE files, each a local path or URL
+	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+	// Optional: Module is a local directory within a CUE module, i.e. a module with a cue.mod
+	// directory, whose package is loaded with its imports
+	Module string `json:"module,omitempty" yaml:"module,omitempty"`
+	// Optional: Path is the CUE path of the definition the domain resources are unified with, e.g.
+	// #Resources. Defaults to the top level of the definitions.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
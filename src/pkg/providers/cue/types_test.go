@@ -0,0 +1,63 @@
+package cue_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/cue"
+)
+
+func TestCreateCueProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    *cue.CueSpec
+		wantErr error
+	}{
+		{
+			name: "valid inline spec",
+			spec: &cue.CueSpec{
+				Cue:  "#Resources: pods: [...{metadata: name: string}]",
+				Path: "#Resources",
+			},
+		},
+		{
+			name: "valid files and module",
+			spec: &cue.CueSpec{
+				Files:  []string{"testdata/replicas.cue"},
+				Module: "testdata/module",
+			},
+		},
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: cue.ErrNilSpec,
+		},
+		{
+			name:    "empty spec",
+			spec:    &cue.CueSpec{Path: "#Resources"},
+			wantErr: cue.ErrEmptySource,
+		},
+		{
+			name:    "invalid path",
+			spec:    &cue.CueSpec{Cue: "a: int", Path: "a."},
+			wantErr: cue.ErrInvalidPath,
+		},
+		{
+			name:    "invalid cue",
+			spec:    &cue.CueSpec{Cue: "a: {"},
+			wantErr: cue.ErrCompileCue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cue.CreateCueProvider(context.Background(), tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateCueProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package expression
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// compile parses the expression's query and validates its operator and value
+func compile(expression Expression) (*compiledExpression, error) {
+	compiled := &compiledExpression{Expression: expression}
+
+	switch {
+	case expression.JQ != "" && expression.JSONPath == "":
+		query, err := gojq.Parse(expression.JQ)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+		}
+		code, err := gojq.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+		}
+		compiled.jq = code
+	case expression.JSONPath != "" && expression.JQ == "":
+		compiled.jsonPath = expression.JSONPath
+		// Kubernetes JSONPath expressions are templates, so wrap a bare path in braces
+		if !strings.Contains(compiled.jsonPath, "{") {
+			compiled.jsonPath = fmt.Sprintf("{%s}", compiled.jsonPath)
+		}
+		if err := jsonpath.New(expression.Name).Parse(compiled.jsonPath); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+		}
+	default:
+		return nil, ErrInvalidLanguage
+	}
+
+	if compiled.Operator == "" {
+		compiled.Operator = OperatorEquals
+	}
+	if compiled.Operator == OperatorExists && compiled.Value == nil {
+		compiled.Value = true
+	}
+
+	// Normalize the value so it compares equal to the results
+	value, err := normalize(compiled.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrInvalidValue, compiled.Operator, err)
+	}
+	compiled.Value = value
+
+	switch compiled.Operator {
+	case OperatorEquals:
+		if compiled.Value == nil {
+			return nil, fmt.Errorf("%w %s: value is required", ErrInvalidValue, compiled.Operator)
+		}
+	case OperatorIn:
+		if _, ok := compiled.Value.([]interface{}); !ok {
+			return nil, fmt.Errorf("%w %s: value must be a list", ErrInvalidValue, compiled.Operator)
+		}
+	case OperatorMatches:
+		pattern, ok := compiled.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w %s: value must be a regular expression", ErrInvalidValue, compiled.Operator)
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrInvalidValue, compiled.Operator, err)
+		}
+		compiled.regex = regex
+	case OperatorExists:
+		if _, ok := compiled.Value.(bool); !ok {
+			return nil, fmt.Errorf("%w %s: value must be a boolean", ErrInvalidValue, compiled.Operator)
+		}
+	case OperatorCountGTE:
+		count, ok := compiled.Value.(float64)
+		if !ok || count < 0 || count != float64(int(count)) {
+			return nil, fmt.Errorf("%w %s: value must be a non-negative integer", ErrInvalidValue, compiled.Operator)
+		}
+		compiled.count = int(count)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOperator, compiled.Operator)
+	}
+
+	return compiled, nil
+}
+
+// evaluate runs each expression against the resources, counting each as passing or failing with
+// an observation describing the result
+func evaluate(ctx context.Context, expressions []*compiledExpression, resources types.DomainResources) (types.Result, error) {
+	var result types.Result
+
+	data, err := normalize(map[string]interface{}(resources))
+	if err != nil {
+		return result, err
+	}
+
+	observations := make(map[string]string, len(expressions))
+	for _, expression := range expressions {
+		results, err := expression.results(ctx, data)
+		if err != nil {
+			message.Debugf("Error evaluating expression %s: %v", expression.Name, err)
+			result.Failing += 1
+			observations[expression.Name] = fmt.Sprintf("FAIL: %v", err)
+			continue
+		}
+
+		if reason, ok := expression.assert(results); ok {
+			result.Passing += 1
+			observations[expression.Name] = fmt.Sprintf("PASS: %s", reason)
+		} else {
+			result.Failing += 1
+			observations[expression.Name] = fmt.Sprintf("FAIL: %s", reason)
+		}
+	}
+
+	result.Observations = observations
+	return result, nil
+}
+
+// results returns the values produced by the expression
+func (e *compiledExpression) results(ctx context.Context, data interface{}) ([]interface{}, error) {
+	results := make([]interface{}, 0)
+
+	if e.jq != nil {
+		iter := e.jq.RunWithContext(ctx, data)
+		for {
+			v, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, ok := v.(error); ok {
+				var haltErr *gojq.HaltError
+				if errors.As(err, &haltErr) && haltErr.Value() == nil {
+					break
+				}
+				return nil, fmt.Errorf("%w: %w", ErrEvaluateExpression, err)
+			}
+			results = append(results, v)
+		}
+		return normalizeList(results)
+	}
+
+	jp := jsonpath.New(e.Name).AllowMissingKeys(true)
+	if err := jp.Parse(e.jsonPath); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEvaluateExpression, err)
+	}
+	found, err := jp.FindResults(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEvaluateExpression, err)
+	}
+	for _, values := range found {
+		for _, v := range values {
+			if v.IsValid() && v.CanInterface() {
+				results = append(results, v.Interface())
+			}
+		}
+	}
+	return normalizeList(results)
+}
+
+// assert applies the expression's operator to the results, returning whether the assertion holds
+// and a description of the outcome
+func (e *compiledExpression) assert(results []interface{}) (string, bool) {
+	switch e.Operator {
+	case OperatorExists:
+		exists := false
+		for _, r := range results {
+			if r != nil {
+				exists = true
+				break
+			}
+		}
+		if exists == e.Value.(bool) {
+			return fmt.Sprintf("exists is %t", exists), true
+		}
+		return fmt.Sprintf("expected exists to be %t", e.Value), false
+	case OperatorCountGTE:
+		count := len(results)
+		// A single list result, e.g. from `.pods`, is counted by its elements
+		if len(results) == 1 {
+			if list, ok := results[0].([]interface{}); ok {
+				count = len(list)
+			}
+		}
+		if count >= e.count {
+			return fmt.Sprintf("count %d >= %d", count, e.count), true
+		}
+		return fmt.Sprintf("expected count >= %d, got %d", e.count, count), false
+	}
+
+	if len(results) == 0 {
+		return "expression returned no results", false
+	}
+
+	for _, r := range results {
+		switch e.Operator {
+		case OperatorEquals:
+			if !reflect.DeepEqual(r, e.Value) {
+				return fmt.Sprintf("expected %s, got %s", format(e.Value), format(r)), false
+			}
+		case OperatorIn:
+			in := false
+			for _, v := range e.Value.([]interface{}) {
+				if reflect.DeepEqual(r, v) {
+					in = true
+					break
+				}
+			}
+			if !in {
+				return fmt.Sprintf("%s is not in %s", format(r), format(e.Value)), false
+			}
+		case OperatorMatches:
+			s, ok := r.(string)
+			if !ok {
+				s = format(r)
+			}
+			if !e.regex.MatchString(s) {
+				return fmt.Sprintf("%s does not match %s", format(r), e.regex), false
+			}
+		}
+	}
+	return fmt.Sprintf("%d result(s) %s %s", len(results), e.Operator, format(e.Value)), true
+}
+
+// normalize round trips a value through JSON so numbers and lists have the same types in the
+// resources, the results and the expected values
+func normalize(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func normalizeList(values []interface{}) ([]interface{}, error) {
+	normalized, err := normalize(values)
+	if err != nil {
+		return nil, err
+	}
+	return normalized.([]interface{}), nil
+}
+
+// format formats a value as JSON for observations
+func format(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// Query returns the values produced by the jq or JSONPath query of the expression against the
+// resources, ignoring its operator and value
+func Query(ctx context.Context, expression Expression, resources types.DomainResources) ([]interface{}, error) {
+	expression.Operator = OperatorExists
+	expression.Value = nil
+	compiled, err := compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := normalize(map[string]interface{}(resources))
+	if err != nil {
+		return nil, err
+	}
+	return compiled.results(ctx, data)
+}
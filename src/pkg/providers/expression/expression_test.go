@@ -0,0 +1,177 @@
+package expression_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/expression"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	resources := types.DomainResources{
+		"pods": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "nginx-1", "namespace": "default", "labels": map[string]interface{}{"app": "nginx"}},
+				"spec":     map[string]interface{}{"replicas": 2},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "nginx-2", "namespace": "apps", "labels": map[string]interface{}{"app": "nginx"}},
+				"spec":     map[string]interface{}{"replicas": 2, "hostNetwork": true},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression expression.Expression
+		wantPass   bool
+		wantReason string
+	}{
+		{
+			name:       "jq equals",
+			expression: expression.Expression{JQ: ".pods[].metadata.labels.app", Value: "nginx"},
+			wantPass:   true,
+		},
+		{
+			name:       "jq equals number",
+			expression: expression.Expression{JQ: ".pods[].spec.replicas", Value: 2},
+			wantPass:   true,
+		},
+		{
+			name:       "jq equals fails",
+			expression: expression.Expression{JQ: ".pods[].metadata.namespace", Value: "default"},
+			wantReason: `expected "default", got "apps"`,
+		},
+		{
+			name:       "jsonpath in",
+			expression: expression.Expression{JSONPath: "{.pods[*].metadata.namespace}", Operator: expression.OperatorIn, Value: []interface{}{"default", "apps"}},
+			wantPass:   true,
+		},
+		{
+			name:       "jsonpath in fails",
+			expression: expression.Expression{JSONPath: ".pods[*].metadata.namespace", Operator: expression.OperatorIn, Value: []interface{}{"default"}},
+			wantReason: `"apps" is not in ["default"]`,
+		},
+		{
+			name:       "jsonpath matches",
+			expression: expression.Expression{JSONPath: ".pods[*].metadata.name", Operator: expression.OperatorMatches, Value: "^nginx-[0-9]+$"},
+			wantPass:   true,
+		},
+		{
+			name:       "jq exists",
+			expression: expression.Expression{JQ: ".pods[].spec.hostNetwork", Operator: expression.OperatorExists},
+			wantPass:   true,
+		},
+		{
+			name:       "jq not exists fails",
+			expression: expression.Expression{JQ: ".pods[].spec.hostNetwork", Operator: expression.OperatorExists, Value: false},
+			wantReason: "expected exists to be false",
+		},
+		{
+			name:       "jsonpath not exists with missing key",
+			expression: expression.Expression{JSONPath: ".pods[*].spec.hostPID", Operator: expression.OperatorExists, Value: false},
+			wantPass:   true,
+		},
+		{
+			name:       "jq count of list",
+			expression: expression.Expression{JQ: ".pods", Operator: expression.OperatorCountGTE, Value: 2},
+			wantPass:   true,
+		},
+		{
+			name:       "jsonpath count fails",
+			expression: expression.Expression{JSONPath: "{.pods[?(@.spec.hostNetwork==true)]}", Operator: expression.OperatorCountGTE, Value: 2},
+			wantReason: "expected count >= 2, got 1",
+		},
+		{
+			name:       "no results",
+			expression: expression.Expression{JQ: ".deployments[]?", Value: "nginx"},
+			wantReason: "expression returned no results",
+		},
+		{
+			name:       "jq runtime error",
+			expression: expression.Expression{JQ: ".pods + 1", Value: 1},
+			wantReason: "failed to evaluate expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt.expression.Name = tt.name
+			provider, err := expression.CreateExpressionProvider(ctx, &expression.ExpressionSpec{
+				Expressions: []expression.Expression{tt.expression},
+			})
+			if err != nil {
+				t.Fatalf("CreateExpressionProvider() error: %v", err)
+			}
+
+			result, err := provider.Evaluate(ctx, resources)
+			if err != nil {
+				t.Fatalf("Evaluate() error: %v", err)
+			}
+
+			if tt.wantPass && (result.Passing != 1 || result.Failing != 0) {
+				t.Errorf("Passing = %d, Failing = %d, want passing: %s", result.Passing, result.Failing, result.Observations[tt.name])
+			}
+			if !tt.wantPass {
+				if result.Passing != 0 || result.Failing != 1 {
+					t.Errorf("Passing = %d, Failing = %d, want failing", result.Passing, result.Failing)
+				}
+				if !strings.Contains(result.Observations[tt.name], tt.wantReason) {
+					t.Errorf("Observation = %q, want it to contain %q", result.Observations[tt.name], tt.wantReason)
+				}
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	resources := types.DomainResources{
+		"pods": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "nginx-1"}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "nginx-2"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression expression.Expression
+		want       []interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "jq",
+			expression: expression.Expression{JQ: ".pods[].metadata.name", Operator: expression.OperatorIn},
+			want:       []interface{}{"nginx-1", "nginx-2"},
+		},
+		{
+			name:       "jsonpath",
+			expression: expression.Expression{JSONPath: ".pods[0].metadata.name"},
+			want:       []interface{}{"nginx-1"},
+		},
+		{
+			name:       "invalid query",
+			expression: expression.Expression{JQ: ".pods["},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := expression.Query(context.Background(), tt.expression, resources)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Query() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(results, tt.want) && !tt.wantErr {
+				t.Errorf("Query() = %v, want %v", results, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package expression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/itchyny/gojq"
+
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var (
+	ErrNilSpec            = errors.New("spec is nil")
+	ErrNoExpressions      = errors.New("at least one expression must be specified")
+	ErrInvalidName        = errors.New("expression name must be unique and not empty")
+	ErrInvalidLanguage    = errors.New("exactly one of jq or jsonpath must be specified")
+	ErrInvalidOperator    = errors.New("operator must be one of equals, in, matches, exists or count>=")
+	ErrInvalidValue       = errors.New("value is invalid for operator")
+	ErrInvalidExpression  = errors.New("expression is invalid")
+	ErrEvaluateExpression = errors.New("failed to evaluate expression")
+)
+
+// Operator is the assertion applied to the results of an expression
+type Operator string
+
+const (
+	// OperatorEquals asserts that every result equals the value
+	OperatorEquals Operator = "equals"
+	// OperatorIn asserts that every result is one of the values in the value list
+	OperatorIn Operator = "in"
+	// OperatorMatches asserts that every result matches the regular expression in the value
+	OperatorMatches Operator = "matches"
+	// OperatorExists asserts that a non-null result exists, or does not exist if the value is false
+	OperatorExists Operator = "exists"
+	// OperatorCountGTE asserts that the number of results is at least the value
+	OperatorCountGTE Operator = "count>="
+)
+
+type ExpressionProvider struct {
+	// Spec is the specification of the expressions
+	Spec *ExpressionSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+
+	expressions []*compiledExpression
+}
+
+func CreateExpressionProvider(_ context.Context, spec *ExpressionSpec) (types.Provider, error) {
+	// Check validity of spec
+	if spec == nil {
+		return nil, ErrNilSpec
+	}
+
+	if len(spec.Expressions) == 0 {
+		return nil, ErrNoExpressions
+	}
+
+	names := make(map[string]bool, len(spec.Expressions))
+	expressions := make([]*compiledExpression, 0, len(spec.Expressions))
+	for _, expression := range spec.Expressions {
+		if expression.Name == "" || names[expression.Name] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidName, expression.Name)
+		}
+		names[expression.Name] = true
+
+		compiled, err := compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("expression %s: %w", expression.Name, err)
+		}
+		expressions = append(expressions, compiled)
+	}
+
+	return ExpressionProvider{
+		Spec:        spec,
+		expressions: expressions,
+	}, nil
+}
+
+func (e ExpressionProvider) Evaluate(ctx context.Context, resources types.DomainResources) (types.Result, error) {
+	return evaluate(ctx, e.expressions, resources)
+}
+
+// ExpressionSpec is the specification of the expression provider, required if the provider type is expression
+type ExpressionSpec struct {
+	// Expressions is the list of expressions to evaluate against the domain resources, each one
+	// counted as passing or failing
+	Expressions []Expression `json:"expressions" yaml:"expressions"`
+}
+
+// Expression is a named jq or JSONPath expression with an assertion on its results
+type Expression struct {
+	// Name is the unique name of the expression, used as the key of its observation
+	Name string `json:"name" yaml:"name"`
+	// JQ is a jq expression, e.g. `.pods[].metadata.labels.app`. Each output of the expression is a result.
+	JQ string `json:"jq,omitempty" yaml:"jq,omitempty"`
+	// JSONPath is a Kubernetes JSONPath expression, e.g. `{.pods[*].metadata.labels.app}`. Each
+	// matched value is a result.
+	JSONPath string `json:"jsonpath,omitempty" yaml:"jsonpath,omitempty"`
+	// Optional: Operator is the assertion on the results, one of equals, in, matches, exists or
+	// count>=. Defaults to equals.
+	Operator Operator `json:"operator,omitempty" yaml:"operator,omitempty"`
+	// Value is the expected value of the assertion: the value for equals, a list for in, a regular
+	// expression for matches, a boolean for exists (defaults to true) and a number for count>=
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// compiledExpression is an expression with its query and assertion value parsed
+type compiledExpression struct {
+	Expression
+	jq       *gojq.Code
+	jsonPath string
+	regex    *regexp.Regexp
+	count    int
+}
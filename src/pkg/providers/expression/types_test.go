@@ -0,0 +1,122 @@
+package expression_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/expression"
+)
+
+func TestCreateExpressionProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    *expression.ExpressionSpec
+		wantErr error
+	}{
+		{
+			name: "valid spec",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".pods | length", Operator: expression.OperatorCountGTE, Value: 1},
+					{Name: "jsonpath", JSONPath: ".pods[*].metadata.name", Operator: expression.OperatorMatches, Value: "^nginx"},
+					{Name: "exists", JQ: ".pods[].spec.hostNetwork", Operator: expression.OperatorExists, Value: false},
+				},
+			},
+		},
+		{
+			name:    "nil spec",
+			spec:    nil,
+			wantErr: expression.ErrNilSpec,
+		},
+		{
+			name:    "no expressions",
+			spec:    &expression.ExpressionSpec{},
+			wantErr: expression.ErrNoExpressions,
+		},
+		{
+			name: "duplicate names",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".a", Value: 1},
+					{Name: "jq", JQ: ".b", Value: 1},
+				},
+			},
+			wantErr: expression.ErrInvalidName,
+		},
+		{
+			name: "jq and jsonpath",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "both", JQ: ".a", JSONPath: "{.a}", Value: 1},
+				},
+			},
+			wantErr: expression.ErrInvalidLanguage,
+		},
+		{
+			name: "invalid jq",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".a |", Value: 1},
+				},
+			},
+			wantErr: expression.ErrInvalidExpression,
+		},
+		{
+			name: "invalid jsonpath",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jsonpath", JSONPath: "{.a[}", Value: 1},
+				},
+			},
+			wantErr: expression.ErrInvalidExpression,
+		},
+		{
+			name: "invalid operator",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".a", Operator: "contains", Value: 1},
+				},
+			},
+			wantErr: expression.ErrInvalidOperator,
+		},
+		{
+			name: "missing equals value",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".a"},
+				},
+			},
+			wantErr: expression.ErrInvalidValue,
+		},
+		{
+			name: "invalid regular expression",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".a", Operator: expression.OperatorMatches, Value: "("},
+				},
+			},
+			wantErr: expression.ErrInvalidValue,
+		},
+		{
+			name: "invalid count",
+			spec: &expression.ExpressionSpec{
+				Expressions: []expression.Expression{
+					{Name: "jq", JQ: ".a", Operator: expression.OperatorCountGTE, Value: 1.5},
+				},
+			},
+			wantErr: expression.ErrInvalidValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expression.CreateExpressionProvider(context.Background(), tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateExpressionProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package kyverno
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// GetClusterPolicyValidatedAssets evaluates the validate rules of a Kyverno ClusterPolicy or Policy
+// offline against the Kubernetes resources found in the domain resources. Each rule is counted as
+// passing or failing, and each resource failing a rule is reported as a violation. A rule that
+// matches no resources is not applicable and is not counted. Errors evaluating a rule, e.g. from
+// JMESPath functions that are specific to Kyverno, are returned rather than counted as failures.
+func GetClusterPolicyValidatedAssets(_ context.Context, policy *ClusterPolicy, resources map[string]interface{}, output *KyvernoOutput) (types.Result, error) {
+	var matchResult types.Result
+
+	if len(resources) == 0 {
+		matchResult.Observations = map[string]string{"Kyverno validation not performed": "No resources to validate"}
+		return matchResult, nil
+	}
+
+	if policy == nil {
+		return matchResult, fmt.Errorf("kyverno policy is not provided")
+	}
+
+	if output == nil {
+		output = &KyvernoOutput{}
+	}
+
+	validationSet, observationSet := output.ruleSets()
+
+	objects, err := kubernetesResources(resources)
+	if err != nil {
+		return matchResult, err
+	}
+
+	observations := make(map[string]string)
+	for j, rule := range policy.Spec.Rules {
+		if rule.Validation == nil {
+			message.Debugf("Skipping rule %s: only validate rules are evaluated", rule.Name)
+			continue
+		}
+
+		matched := 0
+		violations := make([]types.Violation, 0)
+		for _, object := range objects {
+			if !policy.matches(rule, object) {
+				continue
+			}
+			matched++
+
+			failure, err := evaluateRule(rule, object)
+			if err != nil {
+				return types.Result{}, fmt.Errorf("error evaluating rule %s against %s: %w", rule.Name, resourceID(object), err)
+			}
+			if failure != "" {
+				violations = append(violations, types.Violation{
+					Resource: resourceID(object),
+					Rule:     fmt.Sprintf("%s.%s", policy.Name, rule.Name),
+					Message:  failure,
+					Severity: policy.Annotations[severityAnnotation],
+				})
+			}
+		}
+
+		if _, ok := validationSet[policy.Name][rule.Name]; (output.Validation == "" || ok) && matched > 0 {
+			if len(violations) > 0 {
+				matchResult.Failing += 1
+				matchResult.Violations = append(matchResult.Violations, violations...)
+			} else {
+				matchResult.Passing += 1
+			}
+		}
+
+		if _, ok := observationSet[policy.Name][rule.Name]; len(output.Observations) == 0 || ok {
+			key := fmt.Sprintf("%s,%s-%d,%d", policy.Name, rule.Name, 0, j)
+			switch {
+			case matched == 0:
+				observations[key] = "NOT APPLICABLE: no resources matched the rule"
+			case len(violations) > 0:
+				observations[key] = fmt.Sprintf("FAIL: %s", violations[0])
+			default:
+				observations[key] = "PASS"
+			}
+		}
+	}
+
+	matchResult.Observations = observations
+	return matchResult, nil
+}
+
+// kubernetesResources returns the objects with a kind and apiVersion found anywhere in the domain
+// resources, e.g. in the lists returned by the kubernetes domain
+func kubernetesResources(resources map[string]interface{}) ([]map[string]interface{}, error) {
+	// Round trip through JSON so values have the same types as the policy
+	b, err := json.Marshal(resources)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+
+	objects := make([]map[string]interface{}, 0)
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			_, hasKind := v["kind"].(string)
+			_, hasVersion := v["apiVersion"].(string)
+			if hasKind && hasVersion {
+				objects = append(objects, v)
+				return
+			}
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []interface{}:
+			for _, element := range v {
+				walk(element)
+			}
+		}
+	}
+	walk(data)
+	return objects, nil
+}
+
+// objectMeta returns the fields of the object's metadata used for matching
+func objectMeta(object map[string]interface{}) (name, namespace string, objectLabels map[string]string) {
+	metadata, _ := object["metadata"].(map[string]interface{})
+	name, _ = metadata["name"].(string)
+	namespace, _ = metadata["namespace"].(string)
+	objectLabels = make(map[string]string)
+	if l, ok := metadata["labels"].(map[string]interface{}); ok {
+		for k, v := range l {
+			if s, ok := v.(string); ok {
+				objectLabels[k] = s
+			}
+		}
+	}
+	return name, namespace, objectLabels
+}
+
+// resourceID identifies an object as kind/namespace/name, or kind/name if it is cluster scoped
+func resourceID(object map[string]interface{}) string {
+	kind, _ := object["kind"].(string)
+	name, namespace, _ := objectMeta(object)
+	if namespace == "" {
+		return fmt.Sprintf("%s/%s", kind, name)
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// matches reports whether the rule applies to the object. A namespaced Policy only applies to
+// objects in its namespace.
+func (p *ClusterPolicy) matches(rule Rule, object map[string]interface{}) bool {
+	if p.Kind == "Policy" && p.Namespace != "" {
+		if _, namespace, _ := objectMeta(object); namespace != p.Namespace {
+			return false
+		}
+	}
+	if !rule.MatchResources.matches(object) {
+		return false
+	}
+	return rule.ExcludeResources == nil || !rule.ExcludeResources.matches(object)
+}
+
+// matches reports whether the object matches any or all of the filters, or the legacy resource description
+func (m MatchResources) matches(object map[string]interface{}) bool {
+	switch {
+	case len(m.Any) > 0:
+		for _, filter := range m.Any {
+			if filter.Resources.matches(object) {
+				return true
+			}
+		}
+		return false
+	case len(m.All) > 0:
+		for _, filter := range m.All {
+			if !filter.Resources.matches(object) {
+				return false
+			}
+		}
+		return true
+	case m.Resources != nil:
+		return m.Resources.matches(object)
+	default:
+		return false
+	}
+}
+
+// matches reports whether the object matches the kinds, names, namespaces and selector of the description
+func (d ResourceDescription) matches(object map[string]interface{}) bool {
+	name, namespace, objectLabels := objectMeta(object)
+
+	if len(d.Kinds) > 0 && !matchesAny(d.Kinds, func(kind string) bool { return matchesKind(kind, object) }) {
+		return false
+	}
+	if d.Name != "" && !wildcardMatch(d.Name, name) {
+		return false
+	}
+	if len(d.Names) > 0 && !matchesAny(d.Names, func(n string) bool { return wildcardMatch(n, name) }) {
+		return false
+	}
+	if len(d.Namespaces) > 0 && !matchesAny(d.Namespaces, func(n string) bool { return namespace != "" && wildcardMatch(n, namespace) }) {
+		return false
+	}
+	if d.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(d.Selector)
+		if err != nil || !selector.Matches(labels.Set(objectLabels)) {
+			return false
+		}
+	}
+	// Resources are evaluated as if they were being created or updated
+	if len(d.Operations) > 0 && !matchesAny(d.Operations, func(op string) bool { return op == "CREATE" || op == "UPDATE" }) {
+		return false
+	}
+	return true
+}
+
+var version = regexp.MustCompile(`^(v\d|\*)`)
+
+// matchesKind matches an object against a kind of the form Kind, version/Kind or group/version/Kind.
+// Subresources, e.g. Pod/status, never match as they are not part of the domain resources.
+func matchesKind(kind string, object map[string]interface{}) bool {
+	objectKind, _ := object["kind"].(string)
+	apiVersion, _ := object["apiVersion"].(string)
+	group, objectVersion := "", apiVersion
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		group, objectVersion = apiVersion[:i], apiVersion[i+1:]
+	}
+
+	parts := strings.Split(kind, "/")
+	switch len(parts) {
+	case 1:
+		return wildcardMatch(parts[0], objectKind)
+	case 2:
+		if !version.MatchString(parts[0]) {
+			return false
+		}
+		return wildcardMatch(parts[0], objectVersion) && wildcardMatch(parts[1], objectKind)
+	case 3:
+		return wildcardMatch(parts[0], group) && wildcardMatch(parts[1], objectVersion) && wildcardMatch(parts[2], objectKind)
+	default:
+		return false
+	}
+}
+
+func matchesAny(values []string, match func(string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// check is a pattern, anyPattern or deny validation, of either a rule or a foreach entry
+type check struct {
+	pattern    interface{}
+	anyPattern []interface{}
+	deny       *Deny
+}
+
+// evaluateRule evaluates the rule's validation against the object, returning the failure message
+// if the object fails the validation
+func evaluateRule(rule Rule, object map[string]interface{}) (string, error) {
+	_, namespace, _ := objectMeta(object)
+	data := map[string]interface{}{
+		"request": map[string]interface{}{
+			"object":    object,
+			"operation": "CREATE",
+			"namespace": namespace,
+		},
+	}
+
+	if ok, err := evaluateConditions(rule.Preconditions, data); err != nil || !ok {
+		return "", err
+	}
+
+	v := rule.Validation
+	msg, err := substitute(v.Message, data)
+	if err != nil {
+		return "", err
+	}
+
+	failure, err := check{pattern: v.Pattern, anyPattern: v.AnyPattern, deny: v.Deny}.evaluate(object, data)
+	if err != nil {
+		return "", err
+	}
+
+	for _, foreach := range v.ForEach {
+		if failure != "" {
+			break
+		}
+		failure, err = evaluateForEach(foreach, data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if failure == "" {
+		return "", nil
+	}
+	if s, _ := msg.(string); s != "" {
+		return fmt.Sprintf("%s (%s)", s, failure), nil
+	}
+	return failure, nil
+}
+
+// evaluateForEach evaluates the foreach validation against each element of its list
+func evaluateForEach(foreach ForEach, data map[string]interface{}) (string, error) {
+	list, err := search(foreach.List, data)
+	if err != nil {
+		return "", err
+	}
+	elements, ok := list.([]interface{})
+	if !ok {
+		return "", nil
+	}
+
+	for i, element := range elements {
+		elementData := make(map[string]interface{}, len(data)+2)
+		for k, v := range data {
+			elementData[k] = v
+		}
+		elementData["element"] = element
+		elementData["elementIndex"] = i
+
+		if ok, err := evaluateConditions(foreach.Preconditions, elementData); err != nil || !ok {
+			if err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		failure, err := check{pattern: foreach.Pattern, anyPattern: foreach.AnyPattern, deny: foreach.Deny}.evaluate(element, elementData)
+		if err != nil {
+			return "", err
+		}
+		if failure != "" {
+			return fmt.Sprintf("element %d of %s: %s", i, foreach.List, failure), nil
+		}
+	}
+	return "", nil
+}
+
+// evaluate validates the target against the check, returning the failure message if it fails
+func (c check) evaluate(target interface{}, data map[string]interface{}) (string, error) {
+	if c.pattern != nil {
+		pattern, err := substitute(c.pattern, data)
+		if err != nil {
+			return "", err
+		}
+		if _, err := matchPattern(target, pattern); err != nil {
+			return err.Error(), nil
+		}
+	}
+
+	if len(c.anyPattern) > 0 {
+		failures := make([]string, 0, len(c.anyPattern))
+		for i, p := range c.anyPattern {
+			pattern, err := substitute(p, data)
+			if err != nil {
+				return "", err
+			}
+			_, err = matchPattern(target, pattern)
+			if err == nil {
+				failures = nil
+				break
+			}
+			failures = append(failures, fmt.Sprintf("anyPattern[%d]: %s", i, err))
+		}
+		if len(failures) > 0 {
+			return strings.Join(failures, "; "), nil
+		}
+	}
+
+	if c.deny != nil {
+		denied, err := evaluateConditions(c.deny.Conditions, data)
+		if err != nil {
+			return "", err
+		}
+		if denied {
+			return "denied by conditions", nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,586 @@
+package kyverno_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/kyverno"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestGetClusterPolicyValidatedAssets(t *testing.T) {
+	t.Parallel()
+
+	resources := map[string]interface{}{
+		"pods": []interface{}{
+			pod("default", "nginx", map[string]interface{}{"app": "nginx"}, container("nginx", "nginx:1.27", false, "100m")),
+			pod("default", "debug", map[string]interface{}{"app": "debug"}, container("debug", "busybox:latest", true, "")),
+			pod("kube-system", "coredns", map[string]interface{}{"k8s-app": "kube-dns"}, container("coredns", "coredns:1.11", false, "1")),
+		},
+		"namespaces": []interface{}{
+			map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]interface{}{"name": "default"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		policy         string
+		wantPassing    int
+		wantFailing    int
+		wantViolations []string
+		wantErr        bool
+	}{
+		{
+			name: "equality anchor",
+			policy: `
+metadata:
+  name: disallow-privileged
+spec:
+  rules:
+    - name: privileged
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      validate:
+        message: "{{ request.object.metadata.name }} is privileged"
+        pattern:
+          spec:
+            containers:
+              - =(securityContext):
+                  =(privileged): "false"
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Pod/default/debug"},
+		},
+		{
+			name: "wildcards and negation",
+			policy: `
+metadata:
+  name: images
+spec:
+  rules:
+    - name: no-latest
+      match:
+        resources:
+          kinds: [v1/Pod]
+      validate:
+        pattern:
+          spec:
+            containers:
+              - image: "!*:latest"
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Pod/default/debug"},
+		},
+		{
+			name: "namespace exclusion and kind mismatch",
+			policy: `
+metadata:
+  name: images
+spec:
+  rules:
+    - name: no-latest
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      exclude:
+        any:
+          - resources:
+              names: ["debug*"]
+      validate:
+        pattern:
+          spec:
+            containers:
+              - image: "!*:latest"
+    - name: deployments-only
+      match:
+        any:
+          - resources:
+              kinds: [apps/v1/Deployment]
+      validate:
+        pattern:
+          spec:
+            replicas: ">1"
+`,
+			// The deployments-only rule matches no resources, so it is not applicable
+			wantPassing: 1,
+		},
+		{
+			name: "no matching resources",
+			policy: `
+metadata:
+  name: replicas
+spec:
+  rules:
+    - name: deployments-only
+      match:
+        any:
+          - resources:
+              kinds: [Deployment]
+      validate:
+        pattern:
+          spec:
+            replicas: ">1"
+`,
+		},
+		{
+			name: "kyverno jmespath function",
+			policy: `
+metadata:
+  name: names
+spec:
+  rules:
+    - name: upper
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      validate:
+        deny:
+          conditions:
+            all:
+              - key: "{{ to_upper(request.object.metadata.name) }}"
+                operator: Equals
+                value: DEBUG
+`,
+			wantErr: true,
+		},
+		{
+			name: "quantity comparison and conditional anchor",
+			policy: `
+metadata:
+  name: limits
+spec:
+  rules:
+    - name: cpu-limits
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+              namespaces: [default]
+      validate:
+        pattern:
+          spec:
+            containers:
+              - (name): "nginx"
+                resources:
+                  limits:
+                    cpu: "<=500m"
+`,
+			wantPassing: 1,
+		},
+		{
+			name: "selector and missing field",
+			policy: `
+metadata:
+  name: limits
+spec:
+  rules:
+    - name: cpu-limits
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+              selector:
+                matchLabels:
+                  app: debug
+      validate:
+        pattern:
+          spec:
+            containers:
+              - resources:
+                  limits:
+                    cpu: "?*"
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Pod/default/debug"},
+		},
+		{
+			name: "any pattern",
+			policy: `
+metadata:
+  name: images
+spec:
+  rules:
+    - name: registries
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      validate:
+        anyPattern:
+          - spec:
+              containers:
+                - image: "nginx:*"
+          - spec:
+              containers:
+                - image: "coredns:*"
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Pod/default/debug"},
+		},
+		{
+			name: "deny conditions",
+			policy: `
+metadata:
+  name: namespaces
+spec:
+  rules:
+    - name: no-default-namespace
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      validate:
+        deny:
+          conditions:
+            any:
+              - key: "{{ request.object.metadata.namespace }}"
+                operator: Equals
+                value: default
+              - key: "{{ request.object.metadata.name }}"
+                operator: AnyIn
+                value: [coredns]
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Pod/default/nginx", "Pod/default/debug", "Pod/kube-system/coredns"},
+		},
+		{
+			name: "preconditions and foreach",
+			policy: `
+metadata:
+  name: images
+spec:
+  rules:
+    - name: no-latest
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      preconditions:
+        all:
+          - key: "{{ request.object.metadata.namespace }}"
+            operator: NotEquals
+            value: kube-system
+      validate:
+        foreach:
+          - list: request.object.spec.containers
+            deny:
+              conditions:
+                all:
+                  - key: "{{ element.image }}"
+                    operator: Equals
+                    value: "*:latest"
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Pod/default/debug"},
+		},
+		{
+			name: "cluster scoped resources",
+			policy: `
+metadata:
+  name: namespaces
+spec:
+  rules:
+    - name: labels
+      match:
+        any:
+          - resources:
+              kinds: [Namespace]
+      validate:
+        pattern:
+          metadata:
+            labels:
+              team: "?*"
+`,
+			wantFailing:    1,
+			wantViolations: []string{"Namespace/default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy kyverno.ClusterPolicy
+			if err := yaml.Unmarshal([]byte(tt.policy), &policy); err != nil {
+				t.Fatalf("failed to unmarshal policy: %v", err)
+			}
+
+			result, err := kyverno.GetClusterPolicyValidatedAssets(context.Background(), &policy, resources, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetClusterPolicyValidatedAssets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if result.Passing != tt.wantPassing || result.Failing != tt.wantFailing {
+				t.Errorf("Passing = %d, Failing = %d, want %d and %d", result.Passing, result.Failing, tt.wantPassing, tt.wantFailing)
+			}
+			if len(result.Violations) != len(tt.wantViolations) {
+				t.Fatalf("Violations = %v, want resources %v", result.Violations, tt.wantViolations)
+			}
+			for i, v := range result.Violations {
+				if v.Resource != tt.wantViolations[i] {
+					t.Errorf("Violations[%d].Resource = %s, want %s", i, v.Resource, tt.wantViolations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClusterPolicyUnsupportedFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{
+			name: "exclude cluster roles",
+			policy: `
+metadata:
+  name: disallow-privileged
+spec:
+  rules:
+    - name: privileged
+      match:
+        any:
+          - resources:
+              kinds: [Deployment]
+      exclude:
+        any:
+          - clusterRoles: [cluster-admin]
+      validate:
+        pattern:
+          spec:
+            replicas: ">1"
+`,
+			err: "exclude fields any[0].clusterRoles are not supported",
+		},
+		{
+			name: "match subjects in legacy form",
+			policy: `
+metadata:
+  name: disallow-privileged
+spec:
+  rules:
+    - name: privileged
+      match:
+        subjects:
+          - kind: User
+            name: dev
+        resources:
+          kinds: [Deployment]
+      validate:
+        pattern:
+          spec:
+            replicas: ">1"
+`,
+			err: "match fields subjects are not supported",
+		},
+		{
+			name: "match namespace selector",
+			policy: `
+metadata:
+  name: disallow-privileged
+spec:
+  rules:
+    - name: privileged
+      match:
+        all:
+          - resources:
+              kinds: [Deployment]
+              namespaceSelector:
+                matchLabels:
+                  env: prod
+      validate:
+        pattern:
+          spec:
+            replicas: ">1"
+`,
+			err: "match fields all[0].resources.namespaceSelector are not supported",
+		},
+		{
+			name: "exclude annotations",
+			policy: `
+metadata:
+  name: disallow-privileged
+spec:
+  rules:
+    - name: privileged
+      match:
+        resources:
+          kinds: [Deployment]
+      exclude:
+        resources:
+          annotations:
+            skip: "true"
+      validate:
+        pattern:
+          spec:
+            replicas: ">1"
+`,
+			err: "exclude fields resources.annotations are not supported",
+		},
+		{
+			name: "pod rule with autogen",
+			policy: `
+metadata:
+  name: disallow-privileged
+spec:
+  rules:
+    - name: privileged
+      match:
+        any:
+          - resources:
+              kinds: [v1/Pod]
+      validate:
+        pattern:
+          spec:
+            hostNetwork: false
+`,
+			err: "rules matching Pods are also applied to Pod controllers",
+		},
+		{
+			name: "pod rule without autogen",
+			policy: `
+metadata:
+  name: disallow-privileged
+  annotations:
+    pod-policies.kyverno.io/autogen-controllers: none
+spec:
+  rules:
+    - name: privileged
+      match:
+        any:
+          - resources:
+              kinds: [Pod]
+      validate:
+        pattern:
+          spec:
+            hostNetwork: false
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy kyverno.ClusterPolicy
+			if err := yaml.Unmarshal([]byte(tt.policy), &policy); err != nil {
+				t.Fatalf("failed to unmarshal policy: %v", err)
+			}
+			// The fields that are not evaluated are kept when the policy is composed into a component
+			data, err := yaml.Marshal(policy)
+			if err != nil {
+				t.Fatalf("failed to marshal policy: %v", err)
+			}
+			policy = kyverno.ClusterPolicy{}
+			if err := yaml.Unmarshal(data, &policy); err != nil {
+				t.Fatalf("failed to unmarshal policy: %v", err)
+			}
+
+			_, err = kyverno.CreateKyvernoProvider(context.Background(), &kyverno.KyvernoSpec{ClusterPolicy: &policy})
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("CreateKyvernoProvider() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("CreateKyvernoProvider() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestKyvernoProviderPolicyFile(t *testing.T) {
+	t.Parallel()
+
+	resources := types.DomainResources{
+		"pods": []interface{}{
+			pod("default", "nginx", map[string]interface{}{"foo": "bar"}, container("nginx", "nginx:1.27", false, "")),
+			pod("default", "debug", map[string]interface{}{"foo": "bar"}, container("debug", "busybox:latest", true, "")),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		policyFile  string
+		wantErr     bool
+		wantPassing int
+		wantFailing int
+	}{
+		{
+			name:        "cluster policy",
+			policyFile:  "disallow-privileged.yaml",
+			wantFailing: 1,
+		},
+		{
+			name:        "validating policy",
+			policyFile:  "validating-policy.yaml",
+			wantPassing: 1,
+		},
+		{
+			name:       "unsupported kind",
+			policyFile: "mutate.yaml",
+			wantErr:    true,
+		},
+		{
+			name:       "missing file",
+			policyFile: "missing.yaml",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, "testdata")
+			provider, err := kyverno.CreateKyvernoProvider(ctx, &kyverno.KyvernoSpec{PolicyFile: tt.policyFile})
+			if err != nil {
+				t.Fatalf("CreateKyvernoProvider() error: %v", err)
+			}
+
+			result, err := provider.Evaluate(ctx, resources)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Passing != tt.wantPassing || result.Failing != tt.wantFailing {
+				t.Errorf("Passing = %d, Failing = %d, want %d and %d", result.Passing, result.Failing, tt.wantPassing, tt.wantFailing)
+			}
+		})
+	}
+}
+
+func pod(namespace, name string, labels map[string]interface{}, containers ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{
+			"containers": containers,
+		},
+	}
+}
+
+func container(name, image string, privileged bool, cpu string) map[string]interface{} {
+	c := map[string]interface{}{
+		"name":  name,
+		"image": image,
+		"securityContext": map[string]interface{}{
+			"privileged": privileged,
+		},
+	}
+	if cpu != "" {
+		c["resources"] = map[string]interface{}{
+			"limits": map[string]interface{}{"cpu": cpu},
+		}
+	}
+	return c
+}
@@ -0,0 +1,187 @@
+package kyverno
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/jmespath-community/go-jmespath"
+)
+
+// variable matches a Kyverno variable, e.g. {{ request.object.metadata.name }}
+var variable = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+// substitute replaces the variables in a value with the results of their JMESPath expressions
+// evaluated against the data. A string that is a single variable is replaced by the typed result.
+func substitute(value interface{}, data map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if m := variable.FindStringSubmatch(v); m != nil && m[0] == strings.TrimSpace(v) {
+			return search(m[1], data)
+		}
+		var err error
+		s := variable.ReplaceAllStringFunc(v, func(match string) string {
+			result, searchErr := search(variable.FindStringSubmatch(match)[1], data)
+			if searchErr != nil {
+				err = searchErr
+				return match
+			}
+			if s, ok := result.(string); ok {
+				return s
+			}
+			if result == nil {
+				return ""
+			}
+			b, _ := json.Marshal(result)
+			return string(b)
+		})
+		return s, err
+	case map[string]interface{}:
+		substituted := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			s, err := substitute(element, data)
+			if err != nil {
+				return nil, err
+			}
+			substituted[key] = s
+		}
+		return substituted, nil
+	case []interface{}:
+		substituted := make([]interface{}, len(v))
+		for i, element := range v {
+			s, err := substitute(element, data)
+			if err != nil {
+				return nil, err
+			}
+			substituted[i] = s
+		}
+		return substituted, nil
+	default:
+		return value, nil
+	}
+}
+
+func search(expression string, data map[string]interface{}) (interface{}, error) {
+	result, err := jmespath.Search(expression, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variable %q: %w", expression, err)
+	}
+	return result, nil
+}
+
+// evaluateConditions reports whether any of the any conditions, and all of the all conditions, are met
+func evaluateConditions(conditions *Conditions, data map[string]interface{}) (bool, error) {
+	if conditions == nil {
+		return true, nil
+	}
+
+	if len(conditions.Any) > 0 {
+		met := false
+		for _, condition := range conditions.Any {
+			ok, err := evaluateCondition(condition, data)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				met = true
+				break
+			}
+		}
+		if !met {
+			return false, nil
+		}
+	}
+
+	for _, condition := range conditions.All {
+		ok, err := evaluateCondition(condition, data)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func evaluateCondition(condition Condition, data map[string]interface{}) (bool, error) {
+	key, err := substitute(condition.Key, data)
+	if err != nil {
+		return false, err
+	}
+	value, err := substitute(condition.Value, data)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(condition.Operator) {
+	case "equal", "equals":
+		return equals(key, value), nil
+	case "notequal", "notequals":
+		return !equals(key, value), nil
+	case "anyin":
+		return countIn(key, value) > 0, nil
+	case "allin", "in":
+		return countIn(key, value) == len(toList(key)), nil
+	case "anynotin":
+		return countIn(key, value) < len(toList(key)), nil
+	case "allnotin", "notin":
+		return countIn(key, value) == 0, nil
+	case "greaterthan", "durationgreaterthan":
+		c, ok := compare(valueString(key), valueString(value))
+		return ok && c > 0, nil
+	case "greaterthanorequals", "durationgreaterthanorequals":
+		c, ok := compare(valueString(key), valueString(value))
+		return ok && c >= 0, nil
+	case "lessthan", "durationlessthan":
+		c, ok := compare(valueString(key), valueString(value))
+		return ok && c < 0, nil
+	case "lessthanorequals", "durationlessthanorequals":
+		c, ok := compare(valueString(key), valueString(value))
+		return ok && c <= 0, nil
+	default:
+		return false, fmt.Errorf("unsupported condition operator %q", condition.Operator)
+	}
+}
+
+// equals compares two values, matching strings with wildcards in the value
+func equals(key, value interface{}) bool {
+	if v, ok := value.(string); ok {
+		if k, ok := key.(string); ok {
+			return wildcardMatch(v, k)
+		}
+	}
+	if k, ok := toFloat(key); ok {
+		if v, ok := toFloat(value); ok {
+			_, keyIsString := key.(string)
+			_, valueIsString := value.(string)
+			if !keyIsString || !valueIsString {
+				return k == v
+			}
+		}
+	}
+	return reflect.DeepEqual(key, value)
+}
+
+// countIn returns the number of elements of the key, a value or list, that are in the value list
+func countIn(key, value interface{}) int {
+	count := 0
+	for _, k := range toList(key) {
+		for _, v := range toList(value) {
+			if equals(k, v) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+func toList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{value}
+}
@@ -3,6 +3,7 @@ package kyverno
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/defenseunicorns/lula/src/pkg/message"
@@ -28,45 +29,7 @@ func GetValidatedAssets(ctx context.Context, kyvernoPolicies *kjson.ValidatingPo
 		output = &KyvernoOutput{}
 	}
 
-	validationSet := make(map[string]map[string]bool)
-	if output.Validation != "" {
-		validationPairs := strings.Split(output.Validation, ",")
-
-		for _, pair := range validationPairs {
-			pair := strings.Split(pair, ".")
-
-			if len(pair) != 2 {
-				message.Debugf("Invalid validation pair: %v", pair)
-				continue
-			}
-
-			validationPolicy := strings.TrimSpace(pair[0])
-			validationRule := strings.TrimSpace(pair[1])
-			if _, ok := validationSet[validationPolicy]; !ok {
-				validationSet[validationPolicy] = make(map[string]bool)
-			}
-			validationSet[validationPolicy][validationRule] = true
-		}
-	}
-
-	observationSet := make(map[string]map[string]bool)
-	if len(output.Observations) > 0 {
-		for _, observationPair := range output.Observations {
-			pair := strings.Split(observationPair, ".")
-
-			if len(pair) != 2 {
-				message.Debugf("Invalid validation pair: %v", pair)
-				continue
-			}
-
-			observationPolicy := strings.TrimSpace(pair[0])
-			observationRule := strings.TrimSpace(pair[1])
-			if _, ok := observationSet[observationPolicy]; !ok {
-				observationSet[observationPolicy] = make(map[string]bool)
-			}
-			observationSet[observationPolicy][observationRule] = true
-		}
-	}
+	validationSet, observationSet := output.ruleSets()
 
 	policyarr := []*kjson.ValidatingPolicy{kyvernoPolicies}
 	message.Debug(*policyarr[0])
@@ -88,6 +51,7 @@ func GetValidatedAssets(ctx context.Context, kyvernoPolicies *kjson.ValidatingPo
 			if _, ok := validationSet[policy.Policy.Name][rule.Rule.Name]; output.Validation == "" || ok {
 				if len(rule.Violations) > 0 {
 					matchResult.Failing += 1
+					matchResult.Violations = append(matchResult.Violations, toViolations(policy.Policy, rule)...)
 				} else {
 					matchResult.Passing += 1
 				}
@@ -106,3 +70,68 @@ func GetValidatedAssets(ctx context.Context, kyvernoPolicies *kjson.ValidatingPo
 	matchResult.Observations = observations
 	return matchResult, nil
 }
+
+// ruleSets returns the (Policy, Rule) pairs selected for validation and for observations
+func (output *KyvernoOutput) ruleSets() (validationSet, observationSet map[string]map[string]bool) {
+	validationSet = make(map[string]map[string]bool)
+	if output.Validation != "" {
+		addRulePairs(validationSet, strings.Split(output.Validation, ","))
+	}
+
+	observationSet = make(map[string]map[string]bool)
+	if len(output.Observations) > 0 {
+		addRulePairs(observationSet, output.Observations)
+	}
+	return validationSet, observationSet
+}
+
+// addRulePairs adds the policy-name.rule-name pairs to the set
+func addRulePairs(set map[string]map[string]bool, pairs []string) {
+	for _, p := range pairs {
+		pair := strings.Split(p, ".")
+
+		if len(pair) != 2 {
+			message.Debugf("Invalid validation pair: %v", pair)
+			continue
+		}
+
+		policy := strings.TrimSpace(pair[0])
+		rule := strings.TrimSpace(pair[1])
+		if _, ok := set[policy]; !ok {
+			set[policy] = make(map[string]bool)
+		}
+		set[policy][rule] = true
+	}
+}
+
+// severityAnnotation is the policy annotation used by Kyverno policies to declare their severity
+const severityAnnotation = "policies.kyverno.io/severity"
+
+// assertionPath matches the assertion prefix and projection markers of a field path, e.g. the
+// "all[0].check.~." of "all[0].check.~.pods[1].metadata.labels"
+var assertionPath = regexp.MustCompile(`^(all|any)\[\d+\]\.check\.|~\.`)
+
+// toViolations converts the violations of a rule to a violation per failed field, identifying the
+// resource by the rule identifier when one is set and by the path of the field otherwise
+func toViolations(policy *kjson.ValidatingPolicy, rule jsonengine.RuleResponse) []types.Violation {
+	violations := make([]types.Violation, 0, len(rule.Violations))
+	for _, result := range rule.Violations {
+		for _, err := range result.ErrorList {
+			resource := rule.Identifier
+			if resource == "" {
+				resource = assertionPath.ReplaceAllString(err.Field, "")
+			}
+			msg := result.Message
+			if msg == "" {
+				msg = err.ErrorBody()
+			}
+			violations = append(violations, types.Violation{
+				Resource: resource,
+				Rule:     fmt.Sprintf("%s.%s", policy.Name, rule.Rule.Name),
+				Message:  msg,
+				Severity: policy.Annotations[severityAnnotation],
+			})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,66 @@
+package kyverno_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	kjson "github.com/kyverno/kyverno-json/pkg/apis/policy/v1alpha1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/kyverno"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestGetValidatedAssets(t *testing.T) {
+	t.Parallel()
+
+	policy := []byte(`
+apiVersion: json.kyverno.io/v1alpha1
+kind: ValidatingPolicy
+metadata:
+  name: labels
+  annotations:
+    policies.kyverno.io/severity: medium
+spec:
+  rules:
+    - name: require-lula-label
+      assert:
+        all:
+          - check:
+              ~.pods:
+                metadata:
+                  labels:
+                    lula: "true"
+`)
+	var validatingPolicy kjson.ValidatingPolicy
+	if err := yaml.Unmarshal(policy, &validatingPolicy); err != nil {
+		t.Fatalf("failed to unmarshal policy: %v", err)
+	}
+
+	resources := map[string]interface{}{
+		"pods": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "labeled", "labels": map[string]interface{}{"lula": "true"}}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "unlabeled", "labels": map[string]interface{}{"lula": "false"}}},
+		},
+	}
+
+	result, err := kyverno.GetValidatedAssets(context.Background(), &validatingPolicy, resources, nil)
+	if err != nil {
+		t.Fatalf("GetValidatedAssets() error: %v", err)
+	}
+	want := []types.Violation{
+		{
+			Resource: "pods[1].metadata.labels.lula",
+			Rule:     "labels.require-lula-label",
+			Message:  `Invalid value: "false": Expected value: "true"`,
+			Severity: "medium",
+		},
+	}
+	if result.Passing != 0 || result.Failing != 1 {
+		t.Errorf("Passing = %d, Failing = %d, want 0 and 1", result.Passing, result.Failing)
+	}
+	if !reflect.DeepEqual(result.Violations, want) {
+		t.Errorf("Violations = %v, want %v", result.Violations, want)
+	}
+}
@@ -0,0 +1,363 @@
+package kyverno
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Errors for anchors whose condition is not met, in which case the pattern does not apply to the
+// resource (or, for a conditional anchor in a list of objects, to the element)
+var (
+	errConditionAnchor = errors.New("conditional anchor mismatch")
+	errGlobalAnchor    = errors.New("global anchor mismatch")
+)
+
+// patternError is a mismatch between a resource and a pattern at a path in the resource
+type patternError struct {
+	path string
+	msg  string
+}
+
+func (e *patternError) Error() string {
+	return fmt.Sprintf("%s at path %s", e.msg, e.path)
+}
+
+// matchPattern validates a resource against a Kyverno validate pattern, returning whether the
+// pattern applies to the resource and, if it does, the first mismatch. It reimplements the anchors
+// and operators of Kyverno patterns, not the full Kyverno engine, see ClusterPolicy.
+func matchPattern(resource, pattern interface{}) (applies bool, err error) {
+	err = matchElement(resource, pattern, "/")
+	if errors.Is(err, errConditionAnchor) || errors.Is(err, errGlobalAnchor) {
+		return false, nil
+	}
+	return true, err
+}
+
+func matchElement(resource, pattern interface{}, path string) error {
+	if resource == nil && pattern != nil {
+		if _, ok := pattern.(string); !ok {
+			return &patternError{path: path, msg: "field is missing"}
+		}
+	}
+
+	switch p := pattern.(type) {
+	case map[string]interface{}:
+		r, ok := resource.(map[string]interface{})
+		if !ok {
+			return &patternError{path: path, msg: "expected an object"}
+		}
+		return matchMap(r, p, path)
+	case []interface{}:
+		r, ok := resource.([]interface{})
+		if !ok {
+			return &patternError{path: path, msg: "expected a list"}
+		}
+		return matchArray(r, p, path)
+	default:
+		if !matchValue(resource, pattern) {
+			return &patternError{path: path, msg: fmt.Sprintf("value %s does not match %s", valueString(resource), valueString(pattern))}
+		}
+		return nil
+	}
+}
+
+// matchMap validates an object, checking conditional and global anchors before the other fields
+func matchMap(resource, pattern map[string]interface{}, path string) error {
+	keys := make([]string, 0, len(pattern))
+	for key := range pattern {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		anchor, field := parseAnchor(key)
+		if anchor != "(" && anchor != "<(" {
+			continue
+		}
+		value, ok := resource[field]
+		if !ok || matchElement(value, pattern[key], path+field+"/") != nil {
+			if anchor == "<(" {
+				return fmt.Errorf("%w at path %s%s/", errGlobalAnchor, path, field)
+			}
+			return fmt.Errorf("%w at path %s%s/", errConditionAnchor, path, field)
+		}
+	}
+
+	for _, key := range keys {
+		anchor, field := parseAnchor(key)
+		value, ok := resource[field]
+		fieldPath := path + field + "/"
+		switch anchor {
+		case "(", "<(", "+(":
+			// conditional and global anchors are already checked, add anchors only apply to mutations
+		case "X(":
+			if ok {
+				return &patternError{path: fieldPath, msg: "field is not allowed"}
+			}
+		case "=(":
+			if ok {
+				if err := matchElement(value, pattern[key], fieldPath); err != nil {
+					return err
+				}
+			}
+		case "^(":
+			if err := matchExistence(value, pattern[key], fieldPath); err != nil {
+				return err
+			}
+		default:
+			if err := matchElement(value, pattern[key], fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matchArray validates a list. A list of objects is validated element by element against the first
+// pattern, skipping elements whose conditional anchors are not met. A list of values is validated
+// against the pattern at the same index.
+func matchArray(resource, pattern []interface{}, path string) error {
+	if len(pattern) == 0 {
+		return &patternError{path: path, msg: "pattern list is empty"}
+	}
+
+	if elementPattern, ok := pattern[0].(map[string]interface{}); ok {
+		for i, element := range resource {
+			err := matchElement(element, elementPattern, path+strconv.Itoa(i)+"/")
+			if errors.Is(err, errConditionAnchor) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(resource) < len(pattern) {
+		return &patternError{path: path, msg: fmt.Sprintf("expected at least %d elements, got %d", len(pattern), len(resource))}
+	}
+	for i, elementPattern := range pattern {
+		if err := matchElement(resource[i], elementPattern, path+strconv.Itoa(i)+"/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchExistence validates that at least one element of a list matches the pattern
+func matchExistence(resource, pattern interface{}, path string) error {
+	elements, ok := resource.([]interface{})
+	if !ok {
+		return &patternError{path: path, msg: "existence anchor expects a list"}
+	}
+	patterns, ok := pattern.([]interface{})
+	if !ok || len(patterns) == 0 {
+		return &patternError{path: path, msg: "existence anchor expects a list pattern"}
+	}
+	for i, element := range elements {
+		if matchElement(element, patterns[0], path+strconv.Itoa(i)+"/") == nil {
+			return nil
+		}
+	}
+	return &patternError{path: path, msg: "no element matches the pattern"}
+}
+
+// parseAnchor splits a pattern key into its anchor, e.g. "=(", and the field name
+func parseAnchor(key string) (anchor, field string) {
+	if !strings.HasSuffix(key, ")") {
+		return "", key
+	}
+	for _, a := range []string{"<(", "=(", "X(", "^(", "+(", "("} {
+		if strings.HasPrefix(key, a) {
+			return a, key[len(a) : len(key)-1]
+		}
+	}
+	return "", key
+}
+
+// matchValue validates a scalar value against a scalar pattern
+func matchValue(value, pattern interface{}) bool {
+	switch p := pattern.(type) {
+	case nil:
+		return isEmpty(value)
+	case bool:
+		v, ok := value.(bool)
+		return ok && v == p
+	case float64, int64, int:
+		v, ok := toFloat(value)
+		pf, _ := toFloat(p)
+		return ok && v == pf
+	case string:
+		return matchStringPattern(value, p)
+	default:
+		return false
+	}
+}
+
+// matchStringPattern validates a value against a string pattern, which may combine operands with
+// "|" (or) and "&" (and), each with an optional operator: !, >, <, >=, <=, or a range a-b or a!-b
+func matchStringPattern(value interface{}, pattern string) bool {
+	for _, alternative := range strings.Split(pattern, "|") {
+		matched := true
+		for _, operand := range strings.Split(alternative, "&") {
+			if !matchOperand(value, strings.TrimSpace(operand)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+var rangeOperand = regexp.MustCompile(`^(-?[0-9.]+[A-Za-z]*)(!?-)(-?[0-9.]+[A-Za-z]*)$`)
+
+func matchOperand(value interface{}, operand string) bool {
+	if m := rangeOperand.FindStringSubmatch(operand); m != nil && value != nil {
+		s := valueString(value)
+		low, lowOk := compare(s, m[1])
+		high, highOk := compare(s, m[3])
+		if !lowOk || !highOk {
+			return false
+		}
+		inRange := low >= 0 && high <= 0
+		if m[2] == "!-" {
+			return !inRange
+		}
+		return inRange
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "!"} {
+		if !strings.HasPrefix(operand, op) {
+			continue
+		}
+		operand = strings.TrimSpace(strings.TrimPrefix(operand, op))
+		if op == "!" {
+			return value == nil || !wildcardMatch(operand, valueString(value))
+		}
+		if value == nil {
+			return false
+		}
+		c, ok := compare(valueString(value), operand)
+		if !ok {
+			return false
+		}
+		switch op {
+		case ">=":
+			return c >= 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		default:
+			return c < 0
+		}
+	}
+
+	if value == nil {
+		return false
+	}
+	return wildcardMatch(operand, valueString(value))
+}
+
+// compare compares two values as numbers, Kubernetes quantities or durations
+func compare(a, b string) (int, bool) {
+	if af, err := strconv.ParseFloat(a, 64); err == nil {
+		if bf, err := strconv.ParseFloat(b, 64); err == nil {
+			return cmpFloat(af, bf), true
+		}
+	}
+	if aq, err := resource.ParseQuantity(a); err == nil {
+		if bq, err := resource.ParseQuantity(b); err == nil {
+			return aq.Cmp(bq), true
+		}
+	}
+	if ad, err := time.ParseDuration(a); err == nil {
+		if bd, err := time.ParseDuration(b); err == nil {
+			return cmpFloat(float64(ad), float64(bd)), true
+		}
+	}
+	return 0, false
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// wildcardMatch matches a value against a pattern where * matches any characters and ? a single character
+func wildcardMatch(pattern, value string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == value
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, err := regexp.MatchString("^"+expr+"$", value)
+	return err == nil && matched
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// valueString formats a scalar value as it appears in a pattern
+func valueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,383 @@
+package kyverno
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	kjson "github.com/kyverno/kyverno-json/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+var (
+	ErrDownloadPolicy = errors.New("error downloading policy")
+	ErrReadPolicy     = errors.New("error reading policy")
+	ErrPolicyKind     = errors.New("policy kind must be ValidatingPolicy, ClusterPolicy or Policy")
+)
+
+// ClusterPolicy is a Kyverno ClusterPolicy or Policy. Only the fields used to evaluate validate
+// rules offline are defined, and the pattern and conditions engine of this package implements a
+// subset of Kyverno: features that need a cluster or an admission request, such as context entries,
+// podSecurity, cel, manifests and the user info and namespace selectors of match and exclude blocks,
+// are rejected by validate rather than evaluated.
+type ClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Spec              PolicySpec `json:"spec" yaml:"spec"`
+}
+
+// PolicySpec is the specification of a Kyverno policy
+type PolicySpec struct {
+	// Rules is the list of rules of the policy, only validate rules are evaluated
+	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// ValidationFailureAction is the admission action of the policy, it does not affect the evaluation
+	ValidationFailureAction string `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
+}
+
+// Rule is a Kyverno policy rule
+type Rule struct {
+	Name             string          `json:"name" yaml:"name"`
+	MatchResources   MatchResources  `json:"match" yaml:"match"`
+	ExcludeResources *MatchResources `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+	Preconditions    *Conditions     `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+	Validation       *Validation     `json:"validate,omitempty" yaml:"validate,omitempty"`
+	// Context is kept to reject rules that load variables from the cluster or external sources
+	Context interface{} `json:"context,omitempty" yaml:"context,omitempty"`
+	// Mutation, Generation and VerifyImages are kept to skip rules that are not validate rules
+	Mutation     interface{} `json:"mutate,omitempty" yaml:"mutate,omitempty"`
+	Generation   interface{} `json:"generate,omitempty" yaml:"generate,omitempty"`
+	VerifyImages interface{} `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+}
+
+// MatchResources selects the resources a rule applies to, either with any or all of a list of
+// filters or, in the legacy form, with a single resource description
+type MatchResources struct {
+	Any       []ResourceFilter     `json:"any,omitempty" yaml:"any,omitempty"`
+	All       []ResourceFilter     `json:"all,omitempty" yaml:"all,omitempty"`
+	Resources *ResourceDescription `json:"resources,omitempty" yaml:"resources,omitempty"`
+	// unknown are the fields that are not evaluated offline, e.g. subjects, roles and clusterRoles
+	unknown map[string]json.RawMessage
+}
+
+// UnmarshalJSON records the fields of the block that are not evaluated, so they can be rejected
+func (m *MatchResources) UnmarshalJSON(data []byte) error {
+	type matchResources MatchResources
+	if err := json.Unmarshal(data, (*matchResources)(m)); err != nil {
+		return err
+	}
+	var err error
+	m.unknown, err = unknownFields(data, *m)
+	return err
+}
+
+// MarshalJSON keeps the fields that are not evaluated, so they are still rejected once re-read
+func (m MatchResources) MarshalJSON() ([]byte, error) {
+	type matchResources MatchResources
+	return marshalWithUnknown(matchResources(m), m.unknown)
+}
+
+// unsupportedFields returns the paths of the fields of the block, and of its filters, that are not evaluated
+func (m MatchResources) unsupportedFields() []string {
+	fields := sortedKeys(m.unknown)
+	for _, filters := range []struct {
+		name    string
+		filters []ResourceFilter
+	}{{"any", m.Any}, {"all", m.All}} {
+		for i, filter := range filters.filters {
+			for _, field := range filter.unsupportedFields() {
+				fields = append(fields, fmt.Sprintf("%s[%d].%s", filters.name, i, field))
+			}
+		}
+	}
+	if m.Resources != nil {
+		for _, field := range sortedKeys(m.Resources.unknown) {
+			fields = append(fields, "resources."+field)
+		}
+	}
+	return fields
+}
+
+// ResourceFilter is a filter of a match or exclude block
+type ResourceFilter struct {
+	Resources ResourceDescription `json:"resources" yaml:"resources"`
+	// unknown are the fields that are not evaluated offline, e.g. subjects, roles and clusterRoles
+	unknown map[string]json.RawMessage
+}
+
+// UnmarshalJSON records the fields of the filter that are not evaluated, so they can be rejected
+func (f *ResourceFilter) UnmarshalJSON(data []byte) error {
+	type resourceFilter ResourceFilter
+	if err := json.Unmarshal(data, (*resourceFilter)(f)); err != nil {
+		return err
+	}
+	var err error
+	f.unknown, err = unknownFields(data, *f)
+	return err
+}
+
+// MarshalJSON keeps the fields that are not evaluated, so they are still rejected once re-read
+func (f ResourceFilter) MarshalJSON() ([]byte, error) {
+	type resourceFilter ResourceFilter
+	return marshalWithUnknown(resourceFilter(f), f.unknown)
+}
+
+// unsupportedFields returns the paths of the fields of the filter that are not evaluated
+func (f ResourceFilter) unsupportedFields() []string {
+	fields := sortedKeys(f.unknown)
+	for _, field := range sortedKeys(f.Resources.unknown) {
+		fields = append(fields, "resources."+field)
+	}
+	return fields
+}
+
+// ResourceDescription describes resources by kind, name, namespace and labels
+type ResourceDescription struct {
+	Kinds      []string              `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	Name       string                `json:"name,omitempty" yaml:"name,omitempty"`
+	Names      []string              `json:"names,omitempty" yaml:"names,omitempty"`
+	Namespaces []string              `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	Selector   *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Operations []string              `json:"operations,omitempty" yaml:"operations,omitempty"`
+	// unknown are the fields that are not evaluated offline, e.g. namespaceSelector and annotations
+	unknown map[string]json.RawMessage
+}
+
+// UnmarshalJSON records the fields of the description that are not evaluated, so they can be rejected
+func (d *ResourceDescription) UnmarshalJSON(data []byte) error {
+	type resourceDescription ResourceDescription
+	if err := json.Unmarshal(data, (*resourceDescription)(d)); err != nil {
+		return err
+	}
+	var err error
+	d.unknown, err = unknownFields(data, *d)
+	return err
+}
+
+// MarshalJSON keeps the fields that are not evaluated, so they are still rejected once re-read
+func (d ResourceDescription) MarshalJSON() ([]byte, error) {
+	type resourceDescription ResourceDescription
+	return marshalWithUnknown(resourceDescription(d), d.unknown)
+}
+
+// unknownFields returns the values of the keys of the JSON object that are not fields of the struct
+func unknownFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		delete(object, name)
+	}
+	if len(object) == 0 {
+		return nil, nil
+	}
+	return object, nil
+}
+
+// marshalWithUnknown marshals the value with the unknown fields it was unmarshalled with
+func marshalWithUnknown(v interface{}, unknown map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown) == 0 {
+		return data, err
+	}
+	object := make(map[string]json.RawMessage, len(unknown))
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for key, value := range unknown {
+		object[key] = value
+	}
+	return json.Marshal(object)
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validation is the validate block of a rule
+type Validation struct {
+	Message    string        `json:"message,omitempty" yaml:"message,omitempty"`
+	Pattern    interface{}   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	AnyPattern []interface{} `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
+	Deny       *Deny         `json:"deny,omitempty" yaml:"deny,omitempty"`
+	ForEach    []ForEach     `json:"foreach,omitempty" yaml:"foreach,omitempty"`
+	// PodSecurity, CEL and Manifests are kept to report validations that cannot be evaluated offline
+	PodSecurity interface{} `json:"podSecurity,omitempty" yaml:"podSecurity,omitempty"`
+	CEL         interface{} `json:"cel,omitempty" yaml:"cel,omitempty"`
+	Manifests   interface{} `json:"manifests,omitempty" yaml:"manifests,omitempty"`
+}
+
+// ForEach applies a validation to each element of a list in the resource
+type ForEach struct {
+	// List is a JMESPath expression resolving to the list of elements, e.g. request.object.spec.containers
+	List          string        `json:"list" yaml:"list"`
+	Preconditions *Conditions   `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+	Pattern       interface{}   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	AnyPattern    []interface{} `json:"anyPattern,omitempty" yaml:"anyPattern,omitempty"`
+	Deny          *Deny         `json:"deny,omitempty" yaml:"deny,omitempty"`
+	// Context is kept to reject foreach declarations that load variables
+	Context interface{} `json:"context,omitempty" yaml:"context,omitempty"`
+}
+
+// Deny fails the validation when its conditions are met
+type Deny struct {
+	Conditions *Conditions `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// Conditions is a set of conditions, of which any or all must be met
+type Conditions struct {
+	Any []Condition `json:"any,omitempty" yaml:"any,omitempty"`
+	All []Condition `json:"all,omitempty" yaml:"all,omitempty"`
+}
+
+// UnmarshalJSON also accepts the legacy form of conditions, a list of conditions that must all be met
+func (c *Conditions) UnmarshalJSON(data []byte) error {
+	var all []Condition
+	if err := json.Unmarshal(data, &all); err == nil {
+		c.All = all
+		return nil
+	}
+	type conditions Conditions
+	return json.Unmarshal(data, (*conditions)(c))
+}
+
+// Condition compares a key to a value with an operator, e.g. Equals, AnyIn or GreaterThan
+type Condition struct {
+	Key      interface{} `json:"key" yaml:"key"`
+	Operator string      `json:"operator" yaml:"operator"`
+	Value    interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	Message  string      `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// autogenAnnotation is the policy annotation that selects the Pod controllers Kyverno generates
+// rules for from the rules matching Pods
+const autogenAnnotation = "pod-policies.kyverno.io/autogen-controllers"
+
+// validate checks that the policy only uses validate rules that can be evaluated offline
+func (p *ClusterPolicy) validate() error {
+	if p.Kind != "" && p.Kind != "ClusterPolicy" && p.Kind != "Policy" {
+		return fmt.Errorf("%w: %s", ErrPolicyKind, p.Kind)
+	}
+	for _, rule := range p.Spec.Rules {
+		if rule.Validation == nil {
+			continue
+		}
+		if fields := rule.MatchResources.unsupportedFields(); len(fields) > 0 {
+			return fmt.Errorf("rule %s: match fields %s are not supported", rule.Name, strings.Join(fields, ", "))
+		}
+		if rule.ExcludeResources != nil {
+			if fields := rule.ExcludeResources.unsupportedFields(); len(fields) > 0 {
+				return fmt.Errorf("rule %s: exclude fields %s are not supported", rule.Name, strings.Join(fields, ", "))
+			}
+		}
+		if p.Annotations[autogenAnnotation] != "none" && rule.MatchResources.matchesPods() {
+			return fmt.Errorf("rule %s: rules matching Pods are also applied to Pod controllers by Kyverno, which is not supported; set the %s annotation to none and match the controllers explicitly", rule.Name, autogenAnnotation)
+		}
+		if rule.Context != nil {
+			return fmt.Errorf("rule %s: context entries are not supported", rule.Name)
+		}
+		v := rule.Validation
+		for _, fe := range v.ForEach {
+			if fe.Context != nil {
+				return fmt.Errorf("rule %s: foreach context entries are not supported", rule.Name)
+			}
+		}
+		if v.PodSecurity != nil || v.CEL != nil || v.Manifests != nil {
+			return fmt.Errorf("rule %s: only pattern, anyPattern, deny and foreach validations are supported", rule.Name)
+		}
+		if v.Pattern == nil && len(v.AnyPattern) == 0 && v.Deny == nil && len(v.ForEach) == 0 {
+			return fmt.Errorf("rule %s: validate must specify a pattern, anyPattern, deny or foreach", rule.Name)
+		}
+	}
+	return nil
+}
+
+// matchesPods reports whether any resource description of the block has the Pod kind
+func (m MatchResources) matchesPods() bool {
+	descriptions := make([]ResourceDescription, 0, len(m.Any)+len(m.All)+1)
+	for _, filter := range append(append([]ResourceFilter{}, m.Any...), m.All...) {
+		descriptions = append(descriptions, filter.Resources)
+	}
+	if m.Resources != nil {
+		descriptions = append(descriptions, *m.Resources)
+	}
+	for _, d := range descriptions {
+		for _, kind := range d.Kinds {
+			if kind == "Pod" || strings.HasSuffix(kind, "/Pod") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// policyKind is used to determine the kind of a policy document before unmarshalling it
+type policyKind struct {
+	Kind string `json:"kind"`
+}
+
+// loadPolicy downloads the policy file, a local path or URL, and returns either the kyverno-json
+// ValidatingPolicy or the Kyverno ClusterPolicy or Policy it contains
+func loadPolicy(ctx context.Context, src string) (*kjson.ValidatingPolicy, *ClusterPolicy, error) {
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok { // if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	dst, err := os.MkdirTemp("", "lula-policy-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dst)
+
+	tmp, err := network.DownloadFile(ctx, filepath.Join(dst, filepath.Base(src)), src, workDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w %s: %w", ErrDownloadPolicy, src, err)
+	}
+	content, err := os.ReadFile(filepath.Clean(tmp))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w %s: %w", ErrReadPolicy, src, err)
+	}
+
+	var kind policyKind
+	if err := yaml.Unmarshal(content, &kind); err != nil {
+		return nil, nil, fmt.Errorf("%w %s: %w", ErrReadPolicy, src, err)
+	}
+
+	switch kind.Kind {
+	case "ValidatingPolicy":
+		var policy kjson.ValidatingPolicy
+		if err := yaml.Unmarshal(content, &policy); err != nil {
+			return nil, nil, fmt.Errorf("%w %s: %w", ErrReadPolicy, src, err)
+		}
+		return &policy, nil, nil
+	case "ClusterPolicy", "Policy":
+		var policy ClusterPolicy
+		if err := yaml.Unmarshal(content, &policy); err != nil {
+			return nil, nil, fmt.Errorf("%w %s: %w", ErrReadPolicy, src, err)
+		}
+		if err := policy.validate(); err != nil {
+			return nil, nil, fmt.Errorf("%w %s: %w", ErrReadPolicy, src, err)
+		}
+		return nil, &policy, nil
+	default:
+		return nil, nil, fmt.Errorf("%w %s: %w, got %q", ErrReadPolicy, src, ErrPolicyKind, kind.Kind)
+	}
+}
@@ -19,9 +19,24 @@ func CreateKyvernoProvider(_ context.Context, spec *KyvernoSpec) (types.Provider
 		return nil, fmt.Errorf("spec is nil")
 	}
 
-	if spec.Policy == nil {
+	policies := 0
+	for _, set := range []bool{spec.Policy != nil, spec.ClusterPolicy != nil, spec.PolicyFile != ""} {
+		if set {
+			policies++
+		}
+	}
+	if policies == 0 {
 		return nil, fmt.Errorf("policy is nil")
 	}
+	if policies > 1 {
+		return nil, fmt.Errorf("only one of policy, cluster-policy or policy-file can be specified")
+	}
+
+	if spec.ClusterPolicy != nil {
+		if err := spec.ClusterPolicy.validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	return KyvernoProvider{
 		Spec: spec,
@@ -29,7 +44,20 @@ func CreateKyvernoProvider(_ context.Context, spec *KyvernoSpec) (types.Provider
 }
 
 func (k KyvernoProvider) Evaluate(ctx context.Context, resources types.DomainResources) (types.Result, error) {
-	results, err := GetValidatedAssets(ctx, k.Spec.Policy, resources, k.Spec.Output)
+	policy, clusterPolicy := k.Spec.Policy, k.Spec.ClusterPolicy
+	if k.Spec.PolicyFile != "" {
+		var err error
+		policy, clusterPolicy, err = loadPolicy(ctx, k.Spec.PolicyFile)
+		if err != nil {
+			return types.Result{}, err
+		}
+	}
+
+	if clusterPolicy != nil {
+		return GetClusterPolicyValidatedAssets(ctx, clusterPolicy, resources, k.Spec.Output)
+	}
+
+	results, err := GetValidatedAssets(ctx, policy, resources, k.Spec.Output)
 	if err != nil {
 		return types.Result{}, err
 	}
@@ -37,8 +65,13 @@ func (k KyvernoProvider) Evaluate(ctx context.Context, resources types.DomainRes
 }
 
 type KyvernoSpec struct {
-	Policy *kjson.ValidatingPolicy `json:"policy" yaml:"policy"`
-	Output *KyvernoOutput          `json:"output,omitempty" yaml:"output,omitempty"`
+	// Policy is a kyverno-json ValidatingPolicy
+	Policy *kjson.ValidatingPolicy `json:"policy,omitempty" yaml:"policy,omitempty"`
+	// ClusterPolicy is a Kyverno ClusterPolicy or Policy, whose validate rules are evaluated offline
+	ClusterPolicy *ClusterPolicy `json:"cluster-policy,omitempty" yaml:"cluster-policy,omitempty"`
+	// PolicyFile is a local path or URL to a file containing a ValidatingPolicy, ClusterPolicy or Policy
+	PolicyFile string         `json:"policy-file,omitempty" yaml:"policy-file,omitempty"`
+	Output     *KyvernoOutput `json:"output,omitempty" yaml:"output,omitempty"`
 }
 
 type KyvernoOutput struct {
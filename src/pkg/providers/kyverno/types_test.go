@@ -57,6 +57,82 @@ func TestCreateKyvernoProvider(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid cluster policy",
+			spec: &kyverno.KyvernoSpec{
+				ClusterPolicy: &kyverno.ClusterPolicy{
+					Spec: kyverno.PolicySpec{
+						Rules: []kyverno.Rule{
+							{Name: "labels", Validation: &kyverno.Validation{Pattern: map[string]interface{}{}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "cluster policy with unsupported validation",
+			spec: &kyverno.KyvernoSpec{
+				ClusterPolicy: &kyverno.ClusterPolicy{
+					Spec: kyverno.PolicySpec{
+						Rules: []kyverno.Rule{
+							{Name: "pss", Validation: &kyverno.Validation{PodSecurity: map[string]interface{}{"level": "baseline"}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster policy with context",
+			spec: &kyverno.KyvernoSpec{
+				ClusterPolicy: &kyverno.ClusterPolicy{
+					Spec: kyverno.PolicySpec{
+						Rules: []kyverno.Rule{
+							{
+								Name:       "registry",
+								Context:    []interface{}{map[string]interface{}{"name": "registries", "configMap": map[string]interface{}{"name": "allowed-registries"}}},
+								Validation: &kyverno.Validation{Pattern: map[string]interface{}{}},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cluster policy with foreach context",
+			spec: &kyverno.KyvernoSpec{
+				ClusterPolicy: &kyverno.ClusterPolicy{
+					Spec: kyverno.PolicySpec{
+						Rules: []kyverno.Rule{
+							{
+								Name: "images",
+								Validation: &kyverno.Validation{ForEach: []kyverno.ForEach{
+									{List: "request.object.spec.containers", Context: []interface{}{map[string]interface{}{"name": "image"}}},
+								}},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid policy file",
+			spec: &kyverno.KyvernoSpec{
+				PolicyFile: "testdata/disallow-privileged.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "multiple policies",
+			spec: &kyverno.KyvernoSpec{
+				Policy:     &kjson.ValidatingPolicy{},
+				PolicyFile: "testdata/disallow-privileged.yaml",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,73 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/loader"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// loadBundles loads each bundle and merges them into a single bundle. Local bundles may be
+// directories or tarballs, and remote bundles are downloaded as tarballs. Merging fails if the
+// roots declared in the bundle manifests overlap.
+func loadBundles(ctx context.Context, bundlePaths []string) (*bundle.Bundle, error) {
+	if len(bundlePaths) == 0 {
+		return nil, nil
+	}
+
+	workDir, ok := ctx.Value(types.LulaValidationWorkDir).(string)
+	if !ok { // if unset, assume lula is already working in the same directory the inputFile is in
+		workDir = "."
+	}
+
+	dst, err := os.MkdirTemp("", "lula-bundles-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dst)
+
+	bundles := make([]*bundle.Bundle, 0, len(bundlePaths))
+	for i, src := range bundlePaths {
+		path := strings.TrimPrefix(src, "file://")
+		if network.IsFileLocal(src) {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(workDir, path)
+			}
+		} else {
+			// go-getter would otherwise extract the tarball, which the bundle loader reads directly
+			remote, err := url.Parse(src)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", ErrDownloadBundle, src, err)
+			}
+			query := remote.Query()
+			query.Set("archive", "false")
+			remote.RawQuery = query.Encode()
+
+			path, err = network.DownloadFile(ctx, filepath.Join(dst, fmt.Sprintf("bundle-%d.tar.gz", i)), remote.String(), workDir)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", ErrDownloadBundle, src, err)
+			}
+		}
+
+		// there is no way to configure verification keys, so signed bundles are loaded unverified
+		b, err := loader.NewFileLoader().WithSkipBundleVerification(true).AsBundle(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrLoadBundle, src, err)
+		}
+		bundles = append(bundles, b)
+	}
+
+	merged, err := bundle.Merge(bundles)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadBundle, err)
+	}
+	return merged, nil
+}
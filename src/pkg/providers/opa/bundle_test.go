@@ -0,0 +1,149 @@
+package opa_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestOpaBundles(t *testing.T) {
+	t.Parallel()
+
+	// a tarball bundle containing only data, under a separate root
+	tarball := filepath.Join(t.TempDir(), "config.tar.gz")
+	writeTarball(t, tarball, map[string]string{
+		".manifest":         `{"roots": ["config"]}`,
+		"/config/data.json": `{"required_label": "lula"}`,
+	})
+	server := httptest.NewServer(http.FileServer(http.Dir(filepath.Dir(tarball))))
+	t.Cleanup(server.Close)
+
+	output := &opa.OpaOutput{
+		Validation:   "lula.k8s.validate",
+		Observations: []string{"lula.k8s.msg"},
+	}
+
+	tests := []struct {
+		name         string
+		spec         *opa.OpaSpec
+		resources    types.DomainResources
+		wantErr      error
+		wantPassing  int
+		wantFailing  int
+		observations map[string]string
+	}{
+		{
+			name:         "directory bundle entrypoint",
+			spec:         &opa.OpaSpec{Bundles: []string{"testdata/bundle"}, Output: output},
+			resources:    pod("registry.example.com/app:1.0"),
+			wantPassing:  1,
+			observations: map[string]string{"lula.k8s.msg": "disallowed images: set()"},
+		},
+		{
+			name:         "directory bundle failing",
+			spec:         &opa.OpaSpec{Bundles: []string{"testdata/bundle"}, Output: output},
+			resources:    pod("docker.io/library/nginx:latest"),
+			wantFailing:  1,
+			observations: map[string]string{"lula.k8s.msg": `disallowed images: {"docker.io/library/nginx:latest"}`},
+		},
+		{
+			name: "rego with local tarball data",
+			spec: &opa.OpaSpec{
+				Rego:    "package validate\n\nimport rego.v1\n\nvalidate if input.pod.metadata.labels[data.config.required_label]",
+				Bundles: []string{tarball},
+			},
+			resources:   pod("registry.example.com/app:1.0"),
+			wantPassing: 1,
+		},
+		{
+			name: "multiple bundles with remote tarball",
+			spec: &opa.OpaSpec{
+				Rego:    "package validate\n\nimport rego.v1\n\nvalidate if {\n\tdata.lula.k8s.validate\n\tinput.pod.metadata.labels[data.config.required_label]\n}",
+				Bundles: []string{"testdata/bundle", server.URL + "/config.tar.gz"},
+			},
+			resources:   pod("registry.example.com/app:1.0"),
+			wantPassing: 1,
+		},
+		{
+			name:      "overlapping roots",
+			spec:      &opa.OpaSpec{Bundles: []string{"testdata/bundle", "testdata/bundle-overlap"}, Output: output},
+			resources: pod("registry.example.com/app:1.0"),
+			wantErr:   opa.ErrLoadBundle,
+		},
+		{
+			name:      "missing bundle",
+			spec:      &opa.OpaSpec{Bundles: []string{"testdata/missing.tar.gz"}, Output: output},
+			resources: pod("registry.example.com/app:1.0"),
+			wantErr:   opa.ErrLoadBundle,
+		},
+		{
+			name:      "missing remote bundle",
+			spec:      &opa.OpaSpec{Bundles: []string{server.URL + "/missing.tar.gz"}, Output: output},
+			resources: pod("registry.example.com/app:1.0"),
+			wantErr:   opa.ErrDownloadBundle,
+		},
+		{
+			name:      "invalid bundle",
+			spec:      &opa.OpaSpec{Bundles: []string{"testdata/lula.rego"}, Output: output},
+			resources: pod("registry.example.com/app:1.0"),
+			wantErr:   opa.ErrLoadBundle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider, err := opa.CreateOpaProvider(ctx, tt.spec)
+			require.NoError(t, err)
+
+			result, err := provider.Evaluate(ctx, tt.resources)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			require.Equal(t, tt.wantPassing, result.Passing)
+			require.Equal(t, tt.wantFailing, result.Failing)
+			if tt.observations != nil {
+				require.Equal(t, tt.observations, result.Observations)
+			}
+		})
+	}
+}
+
+func pod(image string) types.DomainResources {
+	return types.DomainResources{
+		"pod": map[string]interface{}{
+			"metadata": map[string]interface{}{"labels": map[string]interface{}{"lula": "true"}},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{"name": "app", "image": image}},
+			},
+		},
+	}
+}
+
+func writeTarball(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+}
@@ -0,0 +1,129 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/cover"
+	"github.com/open-policy-agent/opa/topdown"
+
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// Coverage collects the line coverage of the Rego modules evaluated by the OPA provider across
+// evaluations, e.g. every test of a validation. It is enabled by adding it to the context with the
+// types.LulaProviderCoverage key. Evaluations are expected to run sequentially.
+type Coverage struct {
+	cover   *cover.Cover
+	modules map[string]*ast.Module
+}
+
+// NewCoverage returns an empty coverage collector
+func NewCoverage() *Coverage {
+	return &Coverage{
+		cover:   cover.New(),
+		modules: make(map[string]*ast.Module),
+	}
+}
+
+// coverageFromContext returns the coverage collector in the context, if any
+func coverageFromContext(ctx context.Context) *Coverage {
+	coverage, _ := ctx.Value(types.LulaProviderCoverage).(*Coverage)
+	return coverage
+}
+
+// tracer returns the tracer recording the expressions evaluated by a query
+func (c *Coverage) tracer() topdown.QueryTracer {
+	return c.cover
+}
+
+// addModules records the modules of an evaluation, so modules that are never evaluated are
+// reported as not covered
+func (c *Coverage) addModules(compiler *ast.Compiler) {
+	for name, module := range compiler.Modules {
+		c.modules[name] = module
+	}
+}
+
+// Report returns the coverage of each module and of all modules together
+func (c *Coverage) Report() *CoverageReport {
+	if len(c.modules) == 0 {
+		return nil
+	}
+
+	report := c.cover.Report(c.modules)
+	coverageReport := &CoverageReport{
+		Modules:         make([]ModuleCoverage, 0, len(c.modules)),
+		CoveredLines:    report.CoveredLines,
+		NotCoveredLines: report.NotCoveredLines,
+		Coverage:        report.Coverage,
+	}
+
+	names := make([]string, 0, len(c.modules))
+	for name := range c.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		module := ModuleCoverage{Name: name}
+		if file, ok := report.Files[name]; ok {
+			module.CoveredLines = file.CoveredLines
+			module.NotCoveredLines = file.NotCoveredLines
+			module.Coverage = file.Coverage
+			for _, r := range file.NotCovered {
+				if r.Start.Row == r.End.Row {
+					module.NotCovered = append(module.NotCovered, fmt.Sprintf("%d", r.Start.Row))
+				} else {
+					module.NotCovered = append(module.NotCovered, fmt.Sprintf("%d-%d", r.Start.Row, r.End.Row))
+				}
+			}
+		}
+		coverageReport.Modules = append(coverageReport.Modules, module)
+	}
+
+	return coverageReport
+}
+
+// CoverageReport is the line coverage of the Rego modules, as a percentage of the lines with
+// expressions that were evaluated
+type CoverageReport struct {
+	Modules         []ModuleCoverage `json:"modules" yaml:"modules"`
+	CoveredLines    int              `json:"covered-lines" yaml:"covered-lines"`
+	NotCoveredLines int              `json:"not-covered-lines" yaml:"not-covered-lines"`
+	Coverage        float64          `json:"coverage" yaml:"coverage"`
+}
+
+// ModuleCoverage is the line coverage of a single Rego module
+type ModuleCoverage struct {
+	Name            string   `json:"name" yaml:"name"`
+	CoveredLines    int      `json:"covered-lines" yaml:"covered-lines"`
+	NotCoveredLines int      `json:"not-covered-lines" yaml:"not-covered-lines"`
+	Coverage        float64  `json:"coverage" yaml:"coverage"`
+	NotCovered      []string `json:"not-covered,omitempty" yaml:"not-covered,omitempty"`
+}
+
+// PrintReport prints the coverage of each module followed by the summary
+func (r *CoverageReport) PrintReport() {
+	if r == nil {
+		message.HeaderInfof("No Rego coverage collected")
+		return
+	}
+	message.HeaderInfof("Rego coverage")
+	for _, module := range r.Modules {
+		message.Infof("%s: %.2f%% (%d/%d lines)", module.Name, module.Coverage, module.CoveredLines, module.CoveredLines+module.NotCoveredLines)
+		if len(module.NotCovered) > 0 {
+			message.Infof("--> not covered: %s", strings.Join(module.NotCovered, ", "))
+		}
+	}
+	message.Infof("Total: %.2f%% (%d/%d lines)", r.Coverage, r.CoveredLines, r.CoveredLines+r.NotCoveredLines)
+}
+
+// BelowThreshold returns whether the total coverage is below the threshold percentage
+func (r *CoverageReport) BelowThreshold(threshold float64) bool {
+	return r != nil && r.Coverage < threshold
+}
@@ -0,0 +1,69 @@
+package opa_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+func TestCoverage(t *testing.T) {
+	t.Parallel()
+
+	rego := `package validate
+
+import rego.v1
+
+default validate := false
+
+validate if {
+	input.pods[_].metadata.namespace == "apps"
+}
+
+validate if {
+	input.pods[_].metadata.labels.allowed == "true"
+}
+`
+	resources := func(namespace string) types.DomainResources {
+		return types.DomainResources{
+			"pods": []interface{}{
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "nginx", "namespace": namespace}},
+			},
+		}
+	}
+
+	coverage := opa.NewCoverage()
+	if report := coverage.Report(); report != nil {
+		t.Fatalf("Report() = %v, want nil before any evaluation", report)
+	}
+
+	ctx := context.WithValue(context.Background(), types.LulaProviderCoverage, coverage)
+	if _, err := opa.GetValidatedAssets(ctx, rego, nil, resources("default"), nil); err != nil {
+		t.Fatalf("GetValidatedAssets() error: %v", err)
+	}
+	first := coverage.Report()
+
+	if _, err := opa.GetValidatedAssets(ctx, rego, nil, resources("apps"), nil); err != nil {
+		t.Fatalf("GetValidatedAssets() error: %v", err)
+	}
+	second := coverage.Report()
+
+	if len(second.Modules) != 1 || second.Modules[0].Name != "validate.rego" {
+		t.Fatalf("Modules = %v, want validate.rego", second.Modules)
+	}
+	if second.Coverage <= first.Coverage {
+		t.Errorf("Coverage = %.2f after both evaluations, want more than %.2f after the first", second.Coverage, first.Coverage)
+	}
+	if second.Coverage >= 100 {
+		t.Errorf("Coverage = %.2f, want less than 100 as the second rule never succeeds", second.Coverage)
+	}
+	// The second rule is evaluated but never succeeds, so only its head is not covered
+	if want := []string{"11"}; !reflect.DeepEqual(second.Modules[0].NotCovered, want) {
+		t.Errorf("NotCovered = %v, want %v", second.Modules[0].NotCovered, want)
+	}
+	if !second.BelowThreshold(100) || second.BelowThreshold(second.Coverage) {
+		t.Errorf("BelowThreshold() is inconsistent with coverage %.2f", second.Coverage)
+	}
+}
@@ -0,0 +1,125 @@
+package opa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-policy-agent/opa/topdown"
+	"github.com/open-policy-agent/opa/topdown/lineage"
+	"github.com/open-policy-agent/opa/topdown/print"
+
+	"github.com/defenseunicorns/lula/src/pkg/message"
+	"github.com/defenseunicorns/lula/src/types"
+)
+
+// Explain modes of the trace of the validation query, matching those of `opa eval --explain`
+const (
+	ExplainOff   = "off"
+	ExplainNotes = "notes"
+	ExplainFails = "fails"
+	ExplainFull  = "full"
+)
+
+// Observation keys of the debugging output, added when it is attached to the result
+const (
+	PrintObservation   = "opa.print"
+	ExplainObservation = "opa.explain"
+)
+
+var ErrInvalidExplainMode = errors.New("explain mode must be one of off, notes, fails or full")
+
+// ValidateExplainMode returns an error if the explain mode is not supported
+func ValidateExplainMode(mode string) error {
+	switch mode {
+	case "", ExplainOff, ExplainNotes, ExplainFails, ExplainFull:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidExplainMode, mode)
+	}
+}
+
+// debugOutput collects the print() output of the policy and the trace of the validation query
+type debugOutput struct {
+	explain string
+	tracer  *topdown.BufferTracer
+	prints  []string
+	seen    map[string]bool
+}
+
+// newDebugOutput returns the debug output for the explain mode in the context
+func newDebugOutput(ctx context.Context) (*debugOutput, error) {
+	explain, _ := ctx.Value(types.LulaProviderExplain).(string)
+	if err := ValidateExplainMode(explain); err != nil {
+		return nil, err
+	}
+
+	d := &debugOutput{
+		explain: explain,
+		seen:    make(map[string]bool),
+	}
+	if explain != "" && explain != ExplainOff {
+		d.tracer = topdown.NewBufferTracer()
+	}
+	return d, nil
+}
+
+// Print implements print.Hook. The validation, observations and violations are separate queries
+// that may evaluate the same rules, so repeated output is only kept once.
+func (d *debugOutput) Print(pctx print.Context, msg string) error {
+	line := msg
+	if pctx.Location != nil {
+		line = fmt.Sprintf("%s:%d: %s", pctx.Location.File, pctx.Location.Row, msg)
+	}
+	if d.seen[line] {
+		return nil
+	}
+	d.seen[line] = true
+	d.prints = append(d.prints, line)
+	message.Debugf("opa print: %s", line)
+	return nil
+}
+
+// trace returns the trace of the validation query filtered by the explain mode
+func (d *debugOutput) trace() string {
+	if d.tracer == nil {
+		return ""
+	}
+
+	events := []*topdown.Event(*d.tracer)
+	switch d.explain {
+	case ExplainNotes:
+		events = lineage.Notes(events)
+	case ExplainFails:
+		events = lineage.Fails(events)
+	case ExplainFull:
+		events = lineage.Full(events)
+	}
+
+	var s strings.Builder
+	topdown.PrettyTraceWithLocation(&s, events)
+	return s.String()
+}
+
+// addTo logs the trace and, if requested in the context, attaches the debugging output to the
+// observations of the result
+func (d *debugOutput) addTo(ctx context.Context, result *types.Result) {
+	trace := d.trace()
+	if trace != "" {
+		message.Debugf("opa explain (%s):\n%s", d.explain, trace)
+	}
+
+	if attach, _ := ctx.Value(types.LulaAttachProviderOutput).(bool); !attach {
+		return
+	}
+	if result.Observations == nil {
+		result.Observations = make(map[string]string)
+	}
+	if len(d.prints) > 0 {
+		result.Observations[PrintObservation] = strings.Join(d.prints, "\n")
+	}
+	if trace != "" {
+		result.Observations[ExplainObservation] = trace
+	}
+}
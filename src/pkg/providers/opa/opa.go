@@ -9,7 +9,9 @@ import (
 	"github.com/defenseunicorns/lula/src/pkg/message"
 	"github.com/defenseunicorns/lula/src/types"
 	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/bundle"
 	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
 )
 
 var (
@@ -22,6 +24,11 @@ const mainPolicyModuleName = "validate.rego"
 
 // GetValidatedAssets performs the validation of the dataset against the given rego policy
 func GetValidatedAssets(ctx context.Context, regoPolicy string, regoModules map[string]string, dataset map[string]interface{}, output *OpaOutput) (types.Result, error) {
+	return getValidatedAssets(ctx, regoPolicy, regoModules, nil, dataset, output)
+}
+
+// getValidatedAssets performs the validation with the policies and data documents of an optional bundle
+func getValidatedAssets(ctx context.Context, regoPolicy string, regoModules map[string]string, policyBundle *bundle.Bundle, dataset map[string]interface{}, output *OpaOutput) (types.Result, error) {
 	var matchResult types.Result
 
 	if len(dataset) == 0 {
@@ -32,29 +39,55 @@ func GetValidatedAssets(ctx context.Context, regoPolicy string, regoModules map[
 		output = &OpaOutput{}
 	}
 
+	debug, err := newDebugOutput(ctx)
+	if err != nil {
+		return matchResult, err
+	}
+
 	modules := make(map[string]string, len(regoModules)+1)
 	for k, v := range regoModules {
 		modules[k] = v
 	}
-	modules[mainPolicyModuleName] = regoPolicy
+	if regoPolicy != "" || policyBundle == nil {
+		modules[mainPolicyModuleName] = regoPolicy
+	}
 
-	compiler, err := ast.CompileModules(modules)
+	compiler, err := compileModules(modules, policyBundle)
 	if err != nil {
 		message.Debugf("failed to compile rego policy: %s", err.Error())
 		return matchResult, fmt.Errorf("%w: %w", ErrCompileRego, err)
 	}
 
+	// Bundle data documents are served from an in-memory store
+	store := inmem.New()
+	if policyBundle != nil {
+		store = inmem.NewFromObject(policyBundle.Data)
+	}
+
+	// Options shared by each query, capturing the output of print() calls and the coverage
+	options := []func(*rego.Rego){
+		rego.Compiler(compiler),
+		rego.Input(dataset),
+		rego.Store(store),
+		rego.EnablePrintStatements(true),
+		rego.PrintHook(debug),
+	}
+	if coverage := coverageFromContext(ctx); coverage != nil {
+		coverage.addModules(compiler)
+		options = append(options, rego.QueryTracer(coverage.tracer()))
+	}
+
 	// Get validation decision
 	validation := "validate.validate"
 	if output.Validation != "" {
 		validation = output.Validation
 	}
 
-	regoCalcValid := rego.New(
-		rego.Query(fmt.Sprintf("data.%s", validation)),
-		rego.Compiler(compiler),
-		rego.Input(dataset),
-	)
+	validationOptions := append([]func(*rego.Rego){rego.Query(fmt.Sprintf("data.%s", validation))}, options...)
+	if debug.tracer != nil {
+		validationOptions = append(validationOptions, rego.QueryTracer(debug.tracer))
+	}
+	regoCalcValid := rego.New(validationOptions...)
 
 	resultValid, err := regoCalcValid.Eval(ctx)
 	if err != nil {
@@ -78,11 +111,7 @@ func GetValidatedAssets(ctx context.Context, regoPolicy string, regoModules map[
 	// Get additional observations, if they exist - only supports string output
 	observations := make(map[string]string)
 	for _, obv := range output.Observations {
-		regoCalcObv := rego.New(
-			rego.Query(fmt.Sprintf("data.%s", obv)),
-			rego.Compiler(compiler),
-			rego.Input(dataset),
-		)
+		regoCalcObv := rego.New(append([]func(*rego.Rego){rego.Query(fmt.Sprintf("data.%s", obv))}, options...)...)
 
 		resultObv, err := regoCalcObv.Eval(ctx)
 		if err != nil {
@@ -101,5 +130,85 @@ func GetValidatedAssets(ctx context.Context, regoPolicy string, regoModules map[
 	}
 	matchResult.Observations = observations
 
+	// Get per-resource violations, if requested
+	if output.Violations != "" {
+		regoCalcViolations := rego.New(append([]func(*rego.Rego){rego.Query(fmt.Sprintf("data.%s", output.Violations))}, options...)...)
+
+		resultViolations, err := regoCalcViolations.Eval(ctx)
+		if err != nil {
+			return matchResult, fmt.Errorf("%w: %w", ErrEvaluateRego, err)
+		}
+		if len(resultViolations) != 0 {
+			matchResult.Violations = toViolations(output.Violations, resultViolations[0].Expressions[0].Value)
+		} else {
+			message.Debugf("Violations field %s not output from rego", output.Violations)
+		}
+	}
+
+	debug.addTo(ctx, &matchResult)
+
 	return matchResult, nil
 }
+
+// toViolations converts the evaluated violations rule, a set or array of objects or strings, to violations
+func toViolations(path string, value interface{}) []types.Violation {
+	elements, ok := value.([]interface{})
+	if !ok {
+		message.Debugf("Violations field %s expected a set or array and got %s", path, reflect.TypeOf(value))
+		return nil
+	}
+
+	violations := make([]types.Violation, 0, len(elements))
+	for _, element := range elements {
+		switch v := element.(type) {
+		case string:
+			violations = append(violations, types.Violation{Message: v})
+		case map[string]interface{}:
+			violations = append(violations, types.Violation{
+				Resource: stringField(v, "resource"),
+				Rule:     stringField(v, "rule"),
+				Message:  stringField(v, "message"),
+				Severity: stringField(v, "severity"),
+			})
+		default:
+			message.Debugf("Violations field %s expected object or string elements and got %s", path, reflect.TypeOf(element))
+		}
+	}
+	return violations
+}
+
+// stringField returns the field of the object as a string, formatting non-string values
+func stringField(object map[string]interface{}, field string) string {
+	value, ok := object[field]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// compileModules parses the modules and compiles them together with the already parsed bundle modules
+func compileModules(modules map[string]string, policyBundle *bundle.Bundle) (*ast.Compiler, error) {
+	parsed := make(map[string]*ast.Module, len(modules))
+	for name, module := range modules {
+		m, err := ast.ParseModule(name, module)
+		if err != nil {
+			return nil, err
+		}
+		parsed[name] = m
+	}
+	if policyBundle != nil {
+		for _, m := range policyBundle.Modules {
+			parsed[m.URL] = m.Parsed
+		}
+	}
+
+	// Print statements are kept so their output can be captured when debugging
+	compiler := ast.NewCompiler().WithEnablePrintStatements(true)
+	if compiler.Compile(parsed); compiler.Failed() {
+		return nil, compiler.Errors
+	}
+	return compiler, nil
+}
@@ -3,9 +3,11 @@ package opa_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/defenseunicorns/lula/src/pkg/providers/opa"
+	"github.com/defenseunicorns/lula/src/types"
 )
 
 func TestOpaModules(t *testing.T) {
@@ -95,3 +97,166 @@ var dummyPod = map[string]interface{}{
 		},
 	},
 }
+
+func TestOpaViolations(t *testing.T) {
+	t.Parallel()
+
+	rego := `package validate
+import rego.v1
+
+default validate := false
+
+violations contains {"resource": sprintf("%s/%s", [pod.metadata.namespace, pod.metadata.name]), "rule": "require-lula-label", "message": "missing lula label", "severity": "high"} if {
+	some pod in input.pods
+	not pod.metadata.labels.lula
+}
+
+violations contains "cluster has no pods" if count(input.pods) == 0
+
+messages := ["first message", "second message"]
+`
+	resources := map[string]interface{}{
+		"pods": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "labeled", "namespace": "default", "labels": map[string]interface{}{"lula": "true"}}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "unlabeled", "namespace": "default"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		resources map[string]interface{}
+		want      []types.Violation
+	}{
+		{
+			name:      "object violations",
+			path:      "validate.violations",
+			resources: resources,
+			want: []types.Violation{
+				{Resource: "default/unlabeled", Rule: "require-lula-label", Message: "missing lula label", Severity: "high"},
+			},
+		},
+		{
+			name:      "string violations",
+			path:      "validate.violations",
+			resources: map[string]interface{}{"pods": []interface{}{}},
+			want: []types.Violation{
+				{Message: "cluster has no pods"},
+			},
+		},
+		{
+			name:      "undefined violations",
+			path:      "validate.undefined",
+			resources: resources,
+		},
+		{
+			name:      "array violations",
+			path:      "validate.messages",
+			resources: resources,
+			want: []types.Violation{
+				{Message: "first message"}, {Message: "second message"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			result, err := opa.GetValidatedAssets(ctx, rego, nil, tt.resources, &opa.OpaOutput{Violations: tt.path})
+			if err != nil {
+				t.Fatalf("GetValidatedAssets() error: %v", err)
+			}
+
+			if !reflect.DeepEqual(result.Violations, tt.want) {
+				t.Errorf("Violations = %v, want %v", result.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpaDebugOutput(t *testing.T) {
+	t.Parallel()
+
+	rego := `package validate
+
+import rego.v1
+
+default validate := false
+
+validate if {
+	print("pods:", count(input.pods))
+	every pod in input.pods {
+		pod.metadata.namespace != "default"
+	}
+}
+
+msg := "checked namespaces" if {
+	print("pods:", count(input.pods))
+}
+`
+	resources := types.DomainResources{
+		"pods": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "nginx", "namespace": "default"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		explain     string
+		attach      bool
+		wantErr     error
+		wantPrint   string
+		wantExplain bool
+	}{
+		{
+			name: "not attached",
+		},
+		{
+			name:      "print attached",
+			attach:    true,
+			wantPrint: "validate.rego:8: pods: 1\nvalidate.rego:15: pods: 1",
+		},
+		{
+			name:        "explain fails attached",
+			explain:     opa.ExplainFails,
+			attach:      true,
+			wantPrint:   "validate.rego:8: pods: 1\nvalidate.rego:15: pods: 1",
+			wantExplain: true,
+		},
+		{
+			name:    "explain not attached",
+			explain: opa.ExplainFull,
+		},
+		{
+			name:    "invalid explain mode",
+			explain: "verbose",
+			wantErr: opa.ErrInvalidExplainMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), types.LulaProviderExplain, tt.explain)
+			ctx = context.WithValue(ctx, types.LulaAttachProviderOutput, tt.attach)
+
+			output := &opa.OpaOutput{Observations: []string{"validate.msg"}}
+			result, err := opa.GetValidatedAssets(ctx, rego, nil, resources, output)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetValidatedAssets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Failing != 1 {
+				t.Errorf("Failing = %d, want 1", result.Failing)
+			}
+			if got := result.Observations[opa.PrintObservation]; got != tt.wantPrint {
+				t.Errorf("print observation = %q, want %q", got, tt.wantPrint)
+			}
+			if got := result.Observations[opa.ExplainObservation]; (got != "") != tt.wantExplain {
+				t.Errorf("explain observation = %q, want explain %t", got, tt.wantExplain)
+			}
+		})
+	}
+}
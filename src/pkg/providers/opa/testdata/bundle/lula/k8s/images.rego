@@ -0,0 +1,19 @@
+package lula.k8s
+
+import rego.v1
+
+default validate := false
+
+validate if count(disallowed) == 0
+
+disallowed contains container.image if {
+	some container in input.pod.spec.containers
+	not allowed(container.image)
+}
+
+allowed(image) if {
+	some registry in data.lula.k8s.allowed_registries
+	startswith(image, registry)
+}
+
+msg := sprintf("disallowed images: %v", [disallowed])
@@ -8,18 +8,23 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/open-policy-agent/opa/ast"
+
 	"github.com/defenseunicorns/lula/src/pkg/common/network"
 	"github.com/defenseunicorns/lula/src/types"
 )
 
 var (
 	ErrNilSpec                = errors.New("spec is nil")
-	ErrEmptyRego              = errors.New("rego policy cannot be empty")
+	ErrEmptyRego              = errors.New("rego policy cannot be empty unless bundles are specified")
 	ErrInvalidValidationPath  = errors.New("validation field must be a json path")
 	ErrInvalidObservationPath = errors.New("observation field must be a json path")
+	ErrInvalidViolationsPath  = errors.New("violations field must be a json path")
 	ErrDownloadModule         = errors.New("error downloading module")
 	ErrReadModule             = errors.New("error reading module")
 	ErrReservedModuleName     = errors.New("module name is reserved and cannot be used in custom modules")
+	ErrDownloadBundle         = errors.New("error downloading bundle")
+	ErrLoadBundle             = errors.New("error loading bundle")
 )
 
 type OpaProvider struct {
@@ -33,7 +38,7 @@ func CreateOpaProvider(_ context.Context, spec *OpaSpec) (types.Provider, error)
 		return nil, ErrNilSpec
 	}
 
-	if spec.Rego == "" {
+	if spec.Rego == "" && len(spec.Bundles) == 0 {
 		return nil, ErrEmptyRego
 	}
 
@@ -50,6 +55,11 @@ func CreateOpaProvider(_ context.Context, spec *OpaSpec) (types.Provider, error)
 				}
 			}
 		}
+		if spec.Output.Violations != "" {
+			if !strings.Contains(spec.Output.Violations, ".") {
+				return nil, ErrInvalidViolationsPath
+			}
+		}
 	}
 
 	return OpaProvider{
@@ -57,9 +67,10 @@ func CreateOpaProvider(_ context.Context, spec *OpaSpec) (types.Provider, error)
 	}, nil
 }
 
-// loadModules downloads the modules specified in the modulePaths map and returns
-// a map of the module name to the module content.
-func loadModules(ctx context.Context, modulePaths map[string]string) (map[string]string, error) {
+// LoadModules downloads the modules specified in the modulePaths map and returns
+// a map of the module name to the module content. It is shared by Evaluate and Compile,
+// which the lint package uses, so modules are loaded the same way when a policy is linted.
+func LoadModules(ctx context.Context, modulePaths map[string]string) (map[string]string, error) {
 	if len(modulePaths) == 0 {
 		return nil, nil
 	}
@@ -96,12 +107,43 @@ func loadModules(ctx context.Context, modulePaths map[string]string) (map[string
 	return loadedModules, nil
 }
 
+// Compile loads the modules and bundles of the spec and compiles them with its rego policy,
+// as when the policy is evaluated.
+func Compile(ctx context.Context, spec *OpaSpec) (*ast.Compiler, error) {
+	if spec == nil {
+		return nil, ErrNilSpec
+	}
+	modules, err := LoadModules(ctx, spec.Modules)
+	if err != nil {
+		return nil, err
+	}
+	policyBundle, err := loadBundles(ctx, spec.Bundles)
+	if err != nil {
+		return nil, err
+	}
+	if modules == nil {
+		modules = make(map[string]string, 1)
+	}
+	if spec.Rego != "" || policyBundle == nil {
+		modules[mainPolicyModuleName] = spec.Rego
+	}
+	compiler, err := compileModules(modules, policyBundle)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCompileRego, err)
+	}
+	return compiler, nil
+}
+
 func (o OpaProvider) Evaluate(ctx context.Context, resources types.DomainResources) (types.Result, error) {
-	modules, err := loadModules(ctx, o.Spec.Modules)
+	modules, err := LoadModules(ctx, o.Spec.Modules)
+	if err != nil {
+		return types.Result{}, err
+	}
+	policyBundle, err := loadBundles(ctx, o.Spec.Bundles)
 	if err != nil {
 		return types.Result{}, err
 	}
-	results, err := GetValidatedAssets(ctx, o.Spec.Rego, modules, resources, o.Spec.Output)
+	results, err := getValidatedAssets(ctx, o.Spec.Rego, modules, policyBundle, resources, o.Spec.Output)
 	if err != nil {
 		return types.Result{}, err
 	}
@@ -110,12 +152,16 @@ func (o OpaProvider) Evaluate(ctx context.Context, resources types.DomainResourc
 
 // OpaSpec is the specification of the OPA policy, required if the provider type is opa
 type OpaSpec struct {
-	// Required: Rego is the OPA policy
-	Rego string `json:"rego" yaml:"rego"`
+	// Rego is the OPA policy, required unless Bundles are specified
+	Rego string `json:"rego,omitempty" yaml:"rego,omitempty"`
 	// Optional: Modules is a map of additional OPA modules to include. The key is the name of the
 	// module and the value is the file with the contents of the module. The `validate.rego` module
 	// name is reserved and cannot be used in custom modules.
 	Modules map[string]string `json:"modules,omitempty" yaml:"modules,omitempty"`
+	// Optional: Bundles is a list of OPA bundles to include, each a directory or tarball given as a
+	// local path or URL. The policies and data documents of all bundles are loaded, and their
+	// manifest roots must not overlap.
+	Bundles []string `json:"bundles,omitempty" yaml:"bundles,omitempty"`
 	// Optional: Output is the output of the OPA policy
 	Output *OpaOutput `json:"output,omitempty" yaml:"output,omitempty"`
 }
@@ -126,4 +172,7 @@ type OpaOutput struct {
 	Validation string `json:"validation" yaml:"validation"`
 	// optional: any additional observations to include (fields must resolve to strings)
 	Observations []string `json:"observations" yaml:"observations"`
+	// optional: Specifies the JSON path to a set or array of per-resource violations. Each element is
+	// either an object with resource, rule, message and severity fields, or a string message.
+	Violations string `json:"violations,omitempty" yaml:"violations,omitempty"`
 }
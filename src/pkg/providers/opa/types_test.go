@@ -46,6 +46,15 @@ func TestCreateOpaProvider(t *testing.T) {
 			},
 			wantErr: opa.ErrEmptyRego,
 		},
+		{
+			name: "bundle without rego",
+			spec: &opa.OpaSpec{
+				Bundles: []string{"testdata/bundle"},
+				Output: &opa.OpaOutput{
+					Validation: "lula.k8s.validate",
+				},
+			},
+		},
 		{
 			name: "invalid validation path",
 			spec: &opa.OpaSpec{
@@ -66,6 +75,16 @@ func TestCreateOpaProvider(t *testing.T) {
 			},
 			wantErr: opa.ErrInvalidObservationPath,
 		},
+		{
+			name: "invalid violations path",
+			spec: &opa.OpaSpec{
+				Rego: "package validate\n\ndefault validate = false",
+				Output: &opa.OpaOutput{
+					Violations: "invalid-path",
+				},
+			},
+			wantErr: opa.ErrInvalidViolationsPath,
+		},
 	}
 
 	for _, tt := range tests {
@@ -8,7 +8,9 @@ import (
 
 	oscalValidation "github.com/defenseunicorns/go-oscal/src/pkg/validation"
 	"github.com/defenseunicorns/lula/src/cmd/dev"
+	"github.com/defenseunicorns/lula/src/pkg/common/lint"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
 )
 
 func TestDevLintCommand(t *testing.T) {
@@ -60,6 +62,19 @@ func TestDevLintCommand(t *testing.T) {
 		return result, err
 	}
 
+	parseFindings := func(t *testing.T, filePath string) ([]lint.Finding, error) {
+		t.Helper()
+		var report map[string][]lint.Finding
+
+		bytes, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(bytes, &report)
+		return report["findings"], err
+	}
+
 	t.Run("Valid multi validation file", func(t *testing.T) {
 		tempDir := t.TempDir()
 		outputFile := filepath.Join(tempDir, "output.json")
@@ -174,6 +189,58 @@ func TestDevLintCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("Lint rule warnings", func(t *testing.T) {
+		tempDir := t.TempDir()
+		findingsFile := filepath.Join(tempDir, "findings.json")
+
+		args := []string{
+			"--input-files", "./testdata/dev/lint/findings.validation.yaml",
+			"--findings-file", findingsFile,
+		}
+
+		err := test(t, args...)
+		require.NoError(t, err)
+
+		findings, err := parseFindings(t, findingsFile)
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+		require.Equal(t, "observation-undefined", findings[0].RuleID)
+		require.Equal(t, lint.SeverityWarning, findings[0].Severity)
+		require.Equal(t, "provider.opa-spec.output.observations[0]", findings[0].Field)
+		require.Equal(t, "./testdata/dev/lint/findings.validation.yaml", findings[0].DocumentPath)
+		require.Equal(t, "missing-uuid", findings[1].RuleID)
+
+		// Warnings fail the lint when failing on warnings
+		err = test(t, append(args, "--fail-on", "warning")...)
+		require.ErrorContains(t, err, "the following files failed linting: ./testdata/dev/lint/findings.validation.yaml")
+	})
+
+	t.Run("Lint rule errors", func(t *testing.T) {
+		tempDir := t.TempDir()
+		findingsFile := filepath.Join(tempDir, "findings.yaml")
+
+		args := []string{
+			"--input-files", "./testdata/dev/lint/undefined.validation.yaml",
+			"--findings-file", findingsFile,
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "the following files failed linting")
+
+		bytes, err := os.ReadFile(findingsFile)
+		require.NoError(t, err)
+		var report map[string][]lint.Finding
+		require.NoError(t, yaml.Unmarshal(bytes, &report))
+		require.Len(t, report["findings"], 1)
+		require.Equal(t, "output-validation-undefined", report["findings"][0].RuleID)
+		require.Equal(t, lint.SeverityError, report["findings"][0].Severity)
+	})
+
+	t.Run("Invalid fail on severity", func(t *testing.T) {
+		err := test(t, "--input-files", "./testdata/dev/lint/opa.validation.yaml", "--fail-on", "fatal")
+		require.ErrorContains(t, err, "invalid --fail-on")
+	})
+
 	t.Run("Test help", func(t *testing.T) {
 		err := testAgainstGolden(t, "help", "--help")
 		require.NoError(t, err)
@@ -0,0 +1,78 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/cmd/dev"
+	"github.com/defenseunicorns/lula/src/internal/mutate"
+)
+
+func TestDevMutateCommand(t *testing.T) {
+
+	test := func(t *testing.T, args ...string) error {
+		t.Helper()
+		rootCmd := dev.DevMutateCommand()
+
+		return runCmdTest(t, rootCmd, args...)
+	}
+
+	testAgainstGolden := func(t *testing.T, goldenFileName string, args ...string) error {
+		rootCmd := dev.DevMutateCommand()
+
+		return runCmdTestWithGolden(t, "dev/mutate/", goldenFileName, rootCmd, args...)
+	}
+
+	t.Run("Valid validation file", func(t *testing.T) {
+		tempDir := t.TempDir()
+		outputFile := filepath.Join(tempDir, "report.yaml")
+
+		args := []string{
+			"--input-file", "./testdata/dev/get-resources/opa.validation.yaml",
+			"--output-file", outputFile,
+		}
+
+		err := test(t, args...)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		var report mutate.MutationReport
+		require.NoError(t, yaml.Unmarshal(data, &report))
+
+		// Only deleting the metadata or the name of the pod changes the result, and deleting the
+		// pod leaves no resources to evaluate
+		require.Equal(t, "satisfied", report.Result)
+		require.Equal(t, 2, report.Killed)
+		require.Equal(t, 1, report.Errors)
+		require.Equal(t, len(report.Mutations)-3, report.Survived)
+		for _, mutation := range report.Mutations {
+			switch mutation.Status {
+			case mutate.MutationStatusKilled:
+				require.Contains(t, []string{"/pod/metadata", "/pod/metadata/name"}, mutation.Path)
+			case mutate.MutationStatusError:
+				require.Equal(t, "/pod", mutation.Path)
+			}
+		}
+	})
+
+	t.Run("Valid validation file with score below threshold", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/get-resources/opa.validation.yaml",
+			"--score-threshold", "50",
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "below the threshold")
+	})
+
+	t.Run("Test help", func(t *testing.T) {
+		err := testAgainstGolden(t, "help", "--help")
+		require.NoError(t, err)
+	})
+}
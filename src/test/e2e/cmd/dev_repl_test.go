@@ -0,0 +1,40 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/defenseunicorns/lula/src/cmd/dev"
+)
+
+func TestDevReplCommand(t *testing.T) {
+
+	test := func(t *testing.T, args ...string) error {
+		t.Helper()
+		rootCmd := dev.DevReplCommand()
+
+		return runCmdTest(t, rootCmd, args...)
+	}
+
+	testAgainstGolden := func(t *testing.T, goldenFileName string, args ...string) error {
+		rootCmd := dev.DevReplCommand()
+
+		return runCmdTestWithGolden(t, "dev/repl/", goldenFileName, rootCmd, args...)
+	}
+
+	t.Run("Without validation or resources", func(t *testing.T) {
+		err := test(t)
+		require.ErrorContains(t, err, "either --input-file or --resources-file is required")
+	})
+
+	t.Run("Invalid resources file", func(t *testing.T) {
+		err := test(t, "--resources-file", "./testdata/dev/get-resources/pod.yaml")
+		require.ErrorContains(t, err, "resource file must be a json file")
+	})
+
+	t.Run("Test help", func(t *testing.T) {
+		err := testAgainstGolden(t, "help", "--help")
+		require.NoError(t, err)
+	})
+}
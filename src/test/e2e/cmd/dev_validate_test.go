@@ -1,6 +1,8 @@
 package cmd_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -64,6 +66,139 @@ func TestDevValidateCommand(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("Valid validation file with passing tests and coverage", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/validate/opa.validation-passing-test.yaml",
+			"--run-tests",
+			"--coverage",
+		}
+
+		err := test(t, args...)
+		require.NoError(t, err)
+	})
+
+	t.Run("Valid validation file with coverage below threshold", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/validate/opa.validation-passing-test.yaml",
+			"--run-tests",
+			"--coverage-threshold", "100",
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "below the threshold")
+	})
+
+	t.Run("Coverage threshold without tests", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/get-resources/opa.validation.yaml",
+			"--run-tests",
+			"--coverage-threshold", "80",
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "the validation has no tests")
+	})
+
+	t.Run("Coverage threshold with a provider other than opa", func(t *testing.T) {
+		tempDir := t.TempDir()
+		validation := `domain:
+  type: file
+  file-spec:
+    filepaths:
+    - name: pods
+      path: pods.yaml
+provider:
+  type: cue
+  cue-spec:
+    cue: "pods: [...]"
+tests:
+  - name: fixture
+    fixture: pods.yaml
+    expected-result: satisfied
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "validation.yaml"), []byte(validation), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "pods.yaml"), []byte("pods: []\n"), 0600))
+
+		args := []string{
+			"--input-file", filepath.Join(tempDir, "validation.yaml"),
+			"--run-tests",
+			"--coverage-threshold", "80",
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "coverage requires the opa provider")
+	})
+
+	// Every test has a fixture or generators, so the kubernetes domain is not collected
+	t.Run("Valid validation file with fixtures and test suites", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/validate/opa.validation-fixture-test.yaml",
+			"--run-tests",
+		}
+
+		err := test(t, args...)
+		require.NoError(t, err)
+	})
+
+	t.Run("Valid validation file with a JUnit test report", func(t *testing.T) {
+		tempDir := t.TempDir()
+		require.NoError(t, os.CopyFS(tempDir, os.DirFS("./testdata/dev/validate")))
+
+		args := []string{
+			"--input-file", filepath.Join(tempDir, "opa.validation-fixture-test.yaml"),
+			"--run-tests",
+			"--test-report-format", "junit",
+		}
+
+		err := test(t, args...)
+		require.NoError(t, err)
+
+		reports, err := filepath.Glob(filepath.Join(tempDir, "test-results-*.xml"))
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+
+		data, err := os.ReadFile(reports[0])
+		require.NoError(t, err)
+		require.Contains(t, string(data), `<testsuite name="Validate pods have resource limits" id="Validate pods have resource limits" tests="3" failures="0" errors="0">`)
+	})
+
+	t.Run("Invalid test report format", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/validate/opa.validation-passing-test.yaml",
+			"--run-tests",
+			"--test-report-format", "html",
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "invalid test report format")
+	})
+
+	t.Run("Coverage without tests", func(t *testing.T) {
+
+		args := []string{
+			"--input-file", "./testdata/dev/validate/opa.validation-passing-test.yaml",
+			"--coverage",
+		}
+
+		err := test(t, args...)
+		require.Error(t, err)
+	})
+
+	t.Run("Watch without input file", func(t *testing.T) {
+
+		args := []string{
+			"--watch",
+		}
+
+		err := test(t, args...)
+		require.ErrorContains(t, err, "--watch requires --input-file")
+	})
+
 	t.Run("Test help", func(t *testing.T) {
 		err := testAgainstGolden(t, "help", "--help")
 		require.NoError(t, err)
@@ -6,10 +6,12 @@ import (
 	"strings"
 	"testing"
 
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/defenseunicorns/lula/src/cmd/evaluate"
 	"github.com/defenseunicorns/lula/src/cmd/validate"
 	"github.com/defenseunicorns/lula/src/pkg/common/oscal"
 	"github.com/defenseunicorns/lula/src/pkg/message"
@@ -118,6 +120,99 @@ func TestValidateCommand(t *testing.T) {
 		assert.True(t, testReport.TestResults[1].Pass)
 	})
 
+	t.Run("Validate run tests with a JUnit test report", func(t *testing.T) {
+		tempDir := t.TempDir()
+		outputFile := filepath.Join(tempDir, "output.yaml")
+
+		err := test(t, "-f", "./testdata/validate/component-composed.yaml", "-o", outputFile, "--run-tests", "--test-report-format", "junit")
+		require.NoError(t, err)
+
+		testResultsFiles, err := filepath.Glob(filepath.Join(tempDir, "test-results-*.xml"))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(testResultsFiles))
+
+		data, err := os.ReadFile(testResultsFiles[0])
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<testsuites name="lula" tests="2" failures="0" errors="0">`)
+		assert.Contains(t, string(data), `id="82099492-0601-4287-a2d1-cc94c49dca9b" tests="2"`)
+	})
+
+	// findings returns the findings of the single result of the assessment results, by control ID
+	findings := func(t *testing.T, outputFile string) map[string]oscalTypes.Finding {
+		t.Helper()
+		compiledBytes, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		compiledModel, err := oscal.NewOscalModel(compiledBytes)
+		require.NoError(t, err)
+		require.Len(t, compiledModel.AssessmentResults.Results, 1)
+
+		result := compiledModel.AssessmentResults.Results[0]
+		findings := make(map[string]oscalTypes.Finding)
+		for _, finding := range *result.Findings {
+			findings[finding.Target.TargetId] = finding
+		}
+		return findings
+	}
+
+	t.Run("Validate with excluded tags", func(t *testing.T) {
+		tempDir := t.TempDir()
+		outputFile := filepath.Join(tempDir, "output.yaml")
+
+		err := test(t, "-f", "./testdata/validate/component-tags.yaml", "-o", outputFile, "--exclude-tags", "slow")
+		require.NoError(t, err)
+
+		results := findings(t, outputFile)
+		require.Len(t, results, 2)
+		assert.Equal(t, "not-satisfied", results["s1.1.1"].Target.Status.State)
+		assert.Equal(t, "not-assessed", results["s1.1.1"].Target.Status.Reason)
+		assert.Nil(t, results["s1.1.1"].RelatedObservations)
+		assert.Equal(t, "satisfied", results["s2.1.1"].Target.Status.State)
+
+		// Only the tagged validations run when selecting tags
+		err = test(t, "-f", "./testdata/validate/component-tags.yaml", "-o", outputFile, "--tags", "slow")
+		require.NoError(t, err)
+	})
+
+	t.Run("Validate with selected controls", func(t *testing.T) {
+		tempDir := t.TempDir()
+		outputFile := filepath.Join(tempDir, "output.yaml")
+
+		err := test(t, "-f", "./testdata/validate/component-tags.yaml", "-o", outputFile, "--controls", "s1.1.1")
+		require.NoError(t, err)
+
+		results := findings(t, outputFile)
+		require.Len(t, results, 2)
+		assert.Equal(t, "satisfied", results["s1.1.1"].Target.Status.State)
+		assert.Equal(t, "not-satisfied", results["s2.1.1"].Target.Status.State)
+		assert.Equal(t, "not-assessed", results["s2.1.1"].Target.Status.Reason)
+		assert.Equal(t, "Control was excluded from the assessment", results["s2.1.1"].Target.Status.Remarks)
+	})
+
+	t.Run("Evaluate validation with selected controls", func(t *testing.T) {
+		tempDir := t.TempDir()
+		outputFile := filepath.Join(tempDir, "output.yaml")
+
+		err := test(t, "-f", "./testdata/validate/component-tags.yaml", "-o", outputFile)
+		require.NoError(t, err)
+		err = test(t, "-f", "./testdata/validate/component-tags.yaml", "-o", outputFile, "--controls", "s1.1.1")
+		require.NoError(t, err)
+
+		// Controls that were not assessed are not compared to the threshold
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		var assessment oscal.AssessmentResults
+		require.NoError(t, assessment.NewModel(data))
+		require.Len(t, assessment.Model.Results, 2)
+
+		err = evaluate.EvaluateAssessments(map[string]*oscal.AssessmentResults{outputFile: &assessment}, "", false, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("Validate with invalid test report format - error", func(t *testing.T) {
+		err := test(t, "-f", validInputFile, "--run-tests", "--test-report-format", "html")
+		require.ErrorContains(t, err, "invalid test report format")
+	})
+
 	t.Run("Test help", func(t *testing.T) {
 		err := testAgainstGolden(t, "help", "--help")
 		require.NoError(t, err)
@@ -4,4 +4,16 @@ type contextKey int
 
 const (
 	LulaValidationWorkDir contextKey = iota
+	// LulaProviderExplain is the explain mode of providers that support tracing their evaluation,
+	// e.g. "fails" for the OPA provider
+	LulaProviderExplain
+	// LulaAttachProviderOutput attaches the debugging output of providers, e.g. OPA print() output
+	// and traces, to the observations of the result
+	LulaAttachProviderOutput
+	// LulaProviderCoverage is the collector of the policy coverage of providers that support it,
+	// e.g. an *opa.Coverage
+	LulaProviderCoverage
+	// LulaUpdateGolden rewrites the golden files of validation tests with their observations
+	// instead of asserting on them
+	LulaUpdateGolden
 )
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/defenseunicorns/lula/src/pkg/message"
 )
@@ -30,6 +31,9 @@ type LulaValidation struct {
 	// UUID of the validation - tied to the component-definition.backmatter
 	UUID string
 
+	// Tags of the validation, used to select the validations that are run
+	Tags []string
+
 	// Provider is the provider that is evaluating the validation
 	Provider *Provider
 
@@ -48,6 +52,10 @@ type LulaValidation struct {
 	// ValidationTestData is a slice of test data corresponding to the lula validation
 	ValidationTestData []*LulaValidationTestData
 
+	// TestSuites are files of tests shared across validations, added to the test data when the
+	// tests are run
+	TestSuites []string
+
 	// Result is the result of the validation
 	Result *Result
 }
@@ -178,8 +186,27 @@ func (v *LulaValidation) Validate(ctx context.Context, opts ...LulaValidationOpt
 
 // RunTests executes any tests defined in the validation and returns a report of the results
 func (v *LulaValidation) RunTests(ctx context.Context, saveResources bool) (*LulaValidationTestReport, error) {
+	// Load the tests of the test suites, only once
+	for _, src := range v.TestSuites {
+		tests, err := LoadTestSuite(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		for i := range tests {
+			v.ValidationTestData = append(v.ValidationTestData, &LulaValidationTestData{
+				Test: &tests[i],
+			})
+		}
+	}
+	v.TestSuites = nil
+
+	// Tests without a fixture or generators use the domain resources
 	if v.DomainResources == nil {
-		return nil, fmt.Errorf("domain resources are nil, tests cannot be run")
+		for _, d := range v.ValidationTestData {
+			if d.Test != nil && d.Test.Fixture == "" && len(d.Test.Generators) == 0 {
+				return nil, fmt.Errorf("domain resources are nil, tests cannot be run")
+			}
+		}
 	}
 
 	// For each test, apply the transforms to the domain resources and run validate using those resources
@@ -189,7 +216,10 @@ func (v *LulaValidation) RunTests(ctx context.Context, saveResources bool) (*Lul
 			// Only execute test if it has not been executed yet
 			if d.Test != nil && d.Result == nil {
 				// Create a fresh copy of the resources and validation to run each test on
-				testResources := deepCopyMap(*v.DomainResources)
+				var testResources map[string]interface{}
+				if v.DomainResources != nil {
+					testResources = deepCopyMap(*v.DomainResources)
+				}
 				testValidation := &LulaValidation{
 					Provider: v.Provider,
 				}
@@ -246,6 +276,38 @@ type Result struct {
 	Failing      int               `json:"failing" yaml:"failing"`
 	State        string            `json:"state" yaml:"state"`
 	Observations map[string]string `json:"observations" yaml:"observations"`
+	Violations   []Violation       `json:"violations,omitempty" yaml:"violations,omitempty"`
+}
+
+// Violation is a single failed check against an individual resource
+type Violation struct {
+	// Resource identifies the resource that failed the check, e.g. namespace/name
+	Resource string `json:"resource" yaml:"resource"`
+	// Rule is the name of the check that failed
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// Message describes why the resource failed the check
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Severity is the severity of the violation, e.g. low, medium, high or critical
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+}
+
+// String returns the violation on a single line, e.g. "default/nginx [high] require-labels: missing label foo"
+func (v Violation) String() string {
+	var s strings.Builder
+	s.WriteString(v.Resource)
+	if v.Severity != "" {
+		s.WriteString(fmt.Sprintf(" [%s]", v.Severity))
+	}
+	if v.Rule != "" {
+		s.WriteString(" " + v.Rule)
+		if v.Message != "" {
+			s.WriteString(":")
+		}
+	}
+	if v.Message != "" {
+		s.WriteString(" " + v.Message)
+	}
+	return strings.TrimSpace(s.String())
 }
 
 func deepCopyMap(input map[string]interface{}) map[string]interface{} {
@@ -3,8 +3,12 @@ package types_test
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -321,3 +325,177 @@ func TestRunTests(t *testing.T) {
 		})
 	}
 }
+
+func TestRunTestsFixtures(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, tmpDir)
+
+	fixture := "pods:\n  - metadata:\n      name: pod-0\n"
+	// The fixtures of a test suite are relative to the suite
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "suites"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "suites", "pods.yaml"), []byte(fixture), 0600))
+	suite := "tests:\n  - name: suite-test\n    fixture: pods.yaml\n    expected-result: satisfied\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "suites", "suite.yaml"), []byte(suite), 0600))
+
+	opaProvider, err := opa.CreateOpaProvider(context.Background(), &opa.OpaSpec{
+		Rego: "package validate\n\nvalidate {count(input.pods) == 1}",
+	})
+	require.NoError(t, err)
+
+	validation := types.LulaValidation{
+		Name:     "test-validation",
+		Provider: &opaProvider,
+		ValidationTestData: []*types.LulaValidationTestData{
+			{
+				Test: &types.LulaValidationTest{
+					Name: "test-generators",
+					Generators: []types.LulaValidationTestGenerator{
+						{
+							Name:  "pods",
+							Count: 2,
+							Template: map[string]interface{}{
+								"metadata": map[string]interface{}{
+									"name": "generated-pod-${index}",
+								},
+							},
+						},
+					},
+					ExpectedResult: "not-satisfied",
+				},
+			},
+		},
+		TestSuites: []string{"suites/suite.yaml"},
+	}
+
+	testReport, err := validation.RunTests(ctx, true)
+	require.NoError(t, err)
+	require.Equal(t, &types.LulaValidationTestReport{
+		Name: "test-validation",
+		TestResults: []*types.LulaValidationTestResult{
+			{
+				TestName:          "test-generators",
+				Pass:              true,
+				Result:            "not-satisfied",
+				Remarks:           map[string]string{},
+				TestResourcesPath: filepath.Join(tmpDir, "test-generators.json"),
+			},
+			{
+				TestName:          "suite-test",
+				Pass:              true,
+				Result:            "satisfied",
+				Remarks:           map[string]string{},
+				TestResourcesPath: filepath.Join(tmpDir, "suite-test.json"),
+			},
+		},
+	}, testReport)
+
+	// The generated resources replace the index placeholder
+	data, err := os.ReadFile(filepath.Join(tmpDir, "test-generators.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "generated-pod-1")
+
+	// Tests without a fixture need domain resources
+	validation.ValidationTestData = append(validation.ValidationTestData, &types.LulaValidationTestData{
+		Test: &types.LulaValidationTest{Name: "test-no-fixture", ExpectedResult: "satisfied"},
+	})
+	_, err = validation.RunTests(ctx, false)
+	require.ErrorContains(t, err, "tests cannot be run")
+}
+
+func TestLoadTestSuite(t *testing.T) {
+	t.Parallel()
+
+	suite := `tests:
+  - name: relative
+    fixture: fixtures/pods.yaml
+    expected-result: satisfied
+    golden-file: golden/relative.yaml
+  - name: absolute
+    fixture: /fixtures/pods.yaml
+    expected-result: satisfied
+  - name: remote
+    fixture: https://example.com/pods.yaml
+    expected-result: satisfied
+`
+
+	t.Run("local suite", func(t *testing.T) {
+		t.Parallel()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "suites"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "suites", "suite.yaml"), []byte(suite), 0600))
+		ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, tmpDir)
+
+		tests, err := types.LoadTestSuite(ctx, "suites/suite.yaml")
+		require.NoError(t, err)
+		require.Len(t, tests, 3)
+		require.Equal(t, filepath.Join("suites", "fixtures", "pods.yaml"), tests[0].Fixture)
+		require.Equal(t, filepath.Join("suites", "golden", "relative.yaml"), tests[0].GoldenFile)
+		require.Equal(t, "/fixtures/pods.yaml", tests[1].Fixture)
+		require.Equal(t, "https://example.com/pods.yaml", tests[2].Fixture)
+	})
+
+	t.Run("remote suite", func(t *testing.T) {
+		t.Parallel()
+		withoutGolden := strings.ReplaceAll(suite, "    golden-file: golden/relative.yaml\n", "")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("golden") != "" {
+				_, _ = w.Write([]byte(suite))
+				return
+			}
+			_, _ = w.Write([]byte(withoutGolden))
+		}))
+		defer server.Close()
+
+		tests, err := types.LoadTestSuite(context.Background(), server.URL+"/suites/suite.yaml")
+		require.NoError(t, err)
+		require.Len(t, tests, 3)
+		require.Equal(t, server.URL+"/suites/fixtures/pods.yaml", tests[0].Fixture)
+		require.Equal(t, "/fixtures/pods.yaml", tests[1].Fixture)
+		require.Equal(t, "https://example.com/pods.yaml", tests[2].Fixture)
+
+		// Golden files cannot be written to a remote test suite
+		_, err = types.LoadTestSuite(context.Background(), server.URL+"/suites/suite.yaml?golden=true")
+		require.ErrorContains(t, err, "golden files are not supported in remote test suites")
+	})
+}
+
+func TestViolationString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		violation types.Violation
+		want      string
+	}{
+		{
+			name:      "all fields",
+			violation: types.Violation{Resource: "default/nginx", Rule: "require-labels", Message: "missing label foo", Severity: "high"},
+			want:      "default/nginx [high] require-labels: missing label foo",
+		},
+		{
+			name:      "resource and message",
+			violation: types.Violation{Resource: "default/nginx", Message: "missing label foo"},
+			want:      "default/nginx missing label foo",
+		},
+		{
+			name:      "message only",
+			violation: types.Violation{Message: "missing label foo"},
+			want:      "missing label foo",
+		},
+		{
+			name:      "rule only",
+			violation: types.Violation{Rule: "require-labels"},
+			want:      "require-labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.violation.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/defenseunicorns/lula/src/pkg/common/network"
+)
+
+// GeneratorIndexPlaceholder is replaced in the string values of a generator template with the index
+// of the generated resource
+const GeneratorIndexPlaceholder = "${index}"
+
+// LulaValidationTestGenerator generates a number of resources from a template, appending them to
+// a list in the test resources
+type LulaValidationTestGenerator struct {
+	// Name is the top-level key of the resources the generated resources are appended to
+	Name string `json:"name" yaml:"name"`
+	// Count is the number of resources to generate
+	Count int `json:"count" yaml:"count"`
+	// Template is the generated resource, where ${index} in string values is replaced with the
+	// index of each generated resource
+	Template map[string]interface{} `json:"template" yaml:"template"`
+}
+
+// validateData validates the data in the LulaValidationTestGenerator struct
+func (g *LulaValidationTestGenerator) validateData() error {
+	if g.Name == "" {
+		return fmt.Errorf("generator name is empty")
+	}
+	if g.Count < 1 {
+		return fmt.Errorf("generator %s count must be at least 1", g.Name)
+	}
+	if g.Template == nil {
+		return fmt.Errorf("generator %s template is empty", g.Name)
+	}
+	return nil
+}
+
+// generate appends the generated resources to the list at the generator's key in the resources
+func (g *LulaValidationTestGenerator) generate(resources map[string]interface{}) error {
+	var list []interface{}
+	switch existing := resources[g.Name].(type) {
+	case nil:
+		list = make([]interface{}, 0, g.Count)
+	case []interface{}:
+		list = existing
+	default:
+		return fmt.Errorf("generator %s: resources %s is not a list", g.Name, g.Name)
+	}
+
+	for i := 0; i < g.Count; i++ {
+		list = append(list, replaceIndex(deepCopyMap(g.Template), strconv.Itoa(i)))
+	}
+	resources[g.Name] = list
+	return nil
+}
+
+// replaceIndex replaces the index placeholder in the string values of a template
+func replaceIndex(value interface{}, index string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, GeneratorIndexPlaceholder, index)
+	case map[string]interface{}:
+		for key, element := range v {
+			v[key] = replaceIndex(element, index)
+		}
+		return v
+	case []interface{}:
+		for i, element := range v {
+			v[i] = replaceIndex(element, index)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// LoadFixture reads the resources of a fixture, a JSON or YAML file given as a local path, relative
+// to the working directory in the context, or a URL
+func LoadFixture(ctx context.Context, src string) (DomainResources, error) {
+	content, err := readTestFile(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %v", src, err)
+	}
+
+	// YAML is a superset of JSON, and converting to JSON keeps the numbers of JSON fixtures
+	// consistent with those of domain resources
+	jsonContent, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %v", src, err)
+	}
+	var resources DomainResources
+	if err := json.Unmarshal(jsonContent, &resources); err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: resources must be a map: %v", src, err)
+	}
+	if resources == nil {
+		resources = make(DomainResources)
+	}
+	return resources, nil
+}
+
+// LulaValidationTestSuite is a file of tests shared across validations
+type LulaValidationTestSuite struct {
+	Tests []LulaValidationTest `json:"tests" yaml:"tests"`
+}
+
+// LoadTestSuite reads the tests of a test suite, a YAML file given as a local path, relative to the
+// working directory in the context, or a URL. The relative fixtures and golden files of the tests
+// are resolved relative to the test suite.
+func LoadTestSuite(ctx context.Context, src string) ([]LulaValidationTest, error) {
+	content, err := readTestFile(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("error reading test suite %s: %v", src, err)
+	}
+
+	var suite LulaValidationTestSuite
+	if err := yaml.UnmarshalStrict(content, &suite); err != nil {
+		return nil, fmt.Errorf("error reading test suite %s: %v", src, err)
+	}
+	for i := range suite.Tests {
+		test := &suite.Tests[i]
+		if err := test.ValidateData(); err != nil {
+			return nil, fmt.Errorf("invalid test %s in test suite %s: %v", test.Name, src, err)
+		}
+		if test.GoldenFile != "" && !network.IsFileLocal(src) {
+			return nil, fmt.Errorf("invalid test %s in test suite %s: golden files are not supported in remote test suites", test.Name, src)
+		}
+		test.Fixture = resolveSuitePath(src, test.Fixture)
+		test.GoldenFile = resolveSuitePath(src, test.GoldenFile)
+	}
+	return suite.Tests, nil
+}
+
+// resolveSuitePath resolves a relative path in a test suite against the location of the suite,
+// leaving absolute paths and URLs unchanged
+func resolveSuitePath(suite, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	if pathURL, err := url.Parse(path); err != nil || pathURL.Scheme != "" {
+		return path
+	}
+
+	suiteURL, err := url.Parse(suite)
+	if err != nil {
+		return path
+	}
+	switch suiteURL.Scheme {
+	case "":
+		return filepath.Join(filepath.Dir(suite), path)
+	case "file":
+		return path
+	default:
+		return suiteURL.ResolveReference(&url.URL{Path: path}).String()
+	}
+}
+
+// readTestFile downloads a local or remote file used by tests and returns its content
+func readTestFile(ctx context.Context, src string) ([]byte, error) {
+	workDir, ok := ctx.Value(LulaValidationWorkDir).(string)
+	if !ok {
+		workDir = "."
+	}
+
+	dst, err := os.MkdirTemp("", "lula-tests-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dst)
+
+	tmp, err := network.DownloadFile(ctx, filepath.Join(dst, filepath.Base(src)), src, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Clean(tmp))
+}
@@ -0,0 +1,234 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// TestReportFormat is the file format of the test reports of validations
+type TestReportFormat string
+
+const (
+	TestReportFormatYaml  TestReportFormat = "yaml"
+	TestReportFormatJson  TestReportFormat = "json"
+	TestReportFormatJUnit TestReportFormat = "junit"
+	TestReportFormatTAP   TestReportFormat = "tap"
+
+	DefaultTestReportFormat = TestReportFormatYaml
+)
+
+// ValidateTestReportFormat checks that the format is a supported test report format
+func ValidateTestReportFormat(format string) error {
+	switch TestReportFormat(format) {
+	case TestReportFormatYaml, TestReportFormatJson, TestReportFormatJUnit, TestReportFormatTAP:
+		return nil
+	default:
+		return fmt.Errorf("invalid test report format %q, must be one of junit, tap, yaml or json", format)
+	}
+}
+
+// Extension returns the file extension of the test report format
+func (f TestReportFormat) Extension() string {
+	switch f {
+	case TestReportFormatJson:
+		return ".json"
+	case TestReportFormatJUnit:
+		return ".xml"
+	case TestReportFormatTAP:
+		return ".tap"
+	default:
+		return ".yaml"
+	}
+}
+
+// MarshalTestReports marshals the test reports, keyed by validation UUID, to the format. In the JUnit
+// and TAP formats, the reports are ordered by UUID, each report is a test suite and each test result
+// is a test case.
+func MarshalTestReports(testReportMap map[string]LulaValidationTestReport, format TestReportFormat) ([]byte, error) {
+	switch format {
+	case TestReportFormatYaml:
+		return goyaml.Marshal(testReportMap)
+	case TestReportFormatJson:
+		return json.MarshalIndent(testReportMap, "", "  ")
+	case TestReportFormatJUnit:
+		return marshalJUnit(testReportMap)
+	case TestReportFormatTAP:
+		return marshalTAP(testReportMap), nil
+	default:
+		return nil, ValidateTestReportFormat(string(format))
+	}
+}
+
+// sortedReportIds returns the UUIDs of the test reports in order
+func sortedReportIds(testReportMap map[string]LulaValidationTestReport) []string {
+	ids := make([]string, 0, len(testReportMap))
+	for id := range testReportMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// failureMessage describes why a test failed, and whether it failed to run rather than producing
+// an unexpected result
+func (r *LulaValidationTestResult) failureMessage() (message string, isError bool) {
+	if r.Result == "" {
+		return "test did not produce a result", true
+	}
+	return fmt.Sprintf("test failed with result %s", r.Result), false
+}
+
+// sortedRemarks returns the remarks of the test result as sorted "key: value" lines
+func (r *LulaValidationTestResult) sortedRemarks() []string {
+	remarks := make([]string, 0, len(r.Remarks))
+	for key, value := range r.Remarks {
+		remarks = append(remarks, fmt.Sprintf("%s: %s", key, value))
+	}
+	sort.Strings(remarks)
+	return remarks
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Id        string          `xml:"id,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// marshalJUnit marshals the test reports to JUnit XML
+func marshalJUnit(testReportMap map[string]LulaValidationTestReport) ([]byte, error) {
+	suites := junitTestSuites{
+		Name:       "lula",
+		TestSuites: make([]junitTestSuite, 0, len(testReportMap)),
+	}
+
+	for _, id := range sortedReportIds(testReportMap) {
+		report := testReportMap[id]
+		suite := junitTestSuite{
+			Name:      report.Name,
+			Id:        id,
+			Tests:     len(report.TestResults),
+			TestCases: make([]junitTestCase, 0, len(report.TestResults)),
+		}
+
+		for _, result := range report.TestResults {
+			testCase := junitTestCase{
+				Name:      result.TestName,
+				ClassName: report.Name,
+			}
+			remarks := strings.Join(result.sortedRemarks(), "\n")
+			if result.Pass {
+				testCase.SystemOut = remarks
+			} else {
+				message, isError := result.failureMessage()
+				failure := &junitFailure{Message: message, Text: remarks}
+				if isError {
+					failure.Type = "error"
+					testCase.Error = failure
+					suite.Errors++
+				} else {
+					failure.Type = "failure"
+					testCase.Failure = failure
+					suite.Failures++
+				}
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.TestSuites = append(suites.TestSuites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// marshalTAP marshals the test reports to TAP version 13, where a validation without tests is a
+// skipped test point
+func marshalTAP(testReportMap map[string]LulaValidationTestReport) []byte {
+	var points []string
+
+	for _, id := range sortedReportIds(testReportMap) {
+		report := testReportMap[id]
+		if len(report.TestResults) == 0 {
+			points = append(points, fmt.Sprintf("ok %d - %s # SKIP no tests", len(points)+1, report.Name))
+			continue
+		}
+
+		for _, result := range report.TestResults {
+			status := "ok"
+			if !result.Pass {
+				status = "not ok"
+			}
+			var point strings.Builder
+			point.WriteString(fmt.Sprintf("%s %d - %s: %s", status, len(points)+1, report.Name, result.TestName))
+
+			// Add a YAML diagnostic block to failing tests
+			if !result.Pass {
+				message, _ := result.failureMessage()
+				point.WriteString("\n  ---\n")
+				point.WriteString(fmt.Sprintf("  message: %s\n", tapScalar(message)))
+				if result.Result != "" {
+					point.WriteString(fmt.Sprintf("  result: %s\n", result.Result))
+				}
+				if remarks := result.sortedRemarks(); len(remarks) > 0 {
+					point.WriteString("  remarks:\n")
+					for _, remark := range remarks {
+						point.WriteString(fmt.Sprintf("    - %s\n", tapScalar(remark)))
+					}
+				}
+				point.WriteString("  ...")
+			}
+			points = append(points, point.String())
+		}
+	}
+
+	var tap strings.Builder
+	tap.WriteString("TAP version 13\n")
+	tap.WriteString(fmt.Sprintf("1..%d\n", len(points)))
+	for _, point := range points {
+		tap.WriteString(point + "\n")
+	}
+	return []byte(tap.String())
+}
+
+// tapScalar quotes a string as a YAML scalar of a TAP diagnostic block
+func tapScalar(s string) string {
+	return strconv.Quote(s)
+}
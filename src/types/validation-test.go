@@ -2,8 +2,14 @@ package types
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+
+	"sigs.k8s.io/yaml"
 
 	"github.com/defenseunicorns/lula/src/internal/transform"
 	"github.com/defenseunicorns/lula/src/pkg/message"
@@ -20,9 +26,27 @@ type LulaValidationTestData struct {
 // The 'test' is an evaluation of the Provider, comparing the actual result against expected,
 // when provided with a changed input of Domain Resources
 type LulaValidationTest struct {
-	Name           string                     `json:"name" yaml:"name"`
-	Changes        []LulaValidationTestChange `json:"changes,omitempty" yaml:"changes,omitempty"`
-	ExpectedResult string                     `json:"expected-result" yaml:"expected-result"`
+	Name string `json:"name" yaml:"name"`
+	// Optional: Fixture is a JSON or YAML file of resources, given as a local path or URL, used as
+	// the base resources of the test instead of the domain resources. Local paths are relative to
+	// the validation, or to the test suite of the test.
+	Fixture string `json:"fixture,omitempty" yaml:"fixture,omitempty"`
+	// Optional: Generators generate resources that are added to the base resources
+	Generators     []LulaValidationTestGenerator `json:"generators,omitempty" yaml:"generators,omitempty"`
+	Changes        []LulaValidationTestChange    `json:"changes,omitempty" yaml:"changes,omitempty"`
+	ExpectedResult string                        `json:"expected-result" yaml:"expected-result"`
+	// Optional: ExpectedObservations are assertions on individual observations of the result
+	ExpectedObservations []ExpectedObservation `json:"expected-observations,omitempty" yaml:"expected-observations,omitempty"`
+	// Optional: GoldenFile is the path, relative to the validation or to the test suite of the test,
+	// of a file with the expected observations of the result, rewritten when golden files are updated
+	GoldenFile string `json:"golden-file,omitempty" yaml:"golden-file,omitempty"`
+}
+
+// ExpectedObservation asserts that an observation equals a value or matches a regular expression
+type ExpectedObservation struct {
+	Key     string `json:"key" yaml:"key"`
+	Equals  string `json:"equals,omitempty" yaml:"equals,omitempty"`
+	Matches string `json:"matches,omitempty" yaml:"matches,omitempty"`
 }
 
 // ValidateData validates the data in the LulaValidationTest struct
@@ -35,31 +59,180 @@ func (l *LulaValidationTest) ValidateData() error {
 		return fmt.Errorf("expected-result must be satisfied or not-satisfied")
 	}
 
+	for _, generator := range l.Generators {
+		if err := generator.validateData(); err != nil {
+			return err
+		}
+	}
+
 	for _, change := range l.Changes {
 		if err := change.validateData(); err != nil {
 			return err
 		}
 	}
 
+	for _, observation := range l.ExpectedObservations {
+		if err := observation.validateData(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// validateData validates the data in the ExpectedObservation struct
+func (o *ExpectedObservation) validateData() error {
+	if o.Key == "" {
+		return fmt.Errorf("expected observation key is empty")
+	}
+	if (o.Equals == "") == (o.Matches == "") {
+		return fmt.Errorf("expected observation %s must specify one of equals or matches", o.Key)
+	}
+	if o.Matches != "" {
+		if _, err := regexp.Compile(o.Matches); err != nil {
+			return fmt.Errorf("expected observation %s has an invalid regular expression: %v", o.Key, err)
+		}
+	}
+	return nil
+}
+
+// check returns a description of the mismatch between the observation and the expectation, if any
+func (o *ExpectedObservation) check(observations map[string]string) string {
+	actual, ok := observations[o.Key]
+	if !ok {
+		return "observation not found"
+	}
+	if o.Matches != "" {
+		if !regexp.MustCompile(o.Matches).MatchString(actual) {
+			return fmt.Sprintf("%q does not match %q", actual, o.Matches)
+		}
+		return ""
+	}
+	if actual != o.Equals {
+		return fmt.Sprintf("expected %q, got %q", o.Equals, actual)
+	}
+	return ""
+}
+
+// checkObservations asserts on the observations of the test result with the expected observations
+// and golden file of the test, returning remarks describing each mismatch. If golden files are
+// being updated, the golden file is rewritten with the observations instead.
+func (l *LulaValidationTest) checkObservations(ctx context.Context, observations map[string]string) (map[string]string, error) {
+	failures := make(map[string]string)
+
+	for _, expected := range l.ExpectedObservations {
+		if mismatch := expected.check(observations); mismatch != "" {
+			failures[fmt.Sprintf("expected observation %s", expected.Key)] = mismatch
+		}
+	}
+
+	if l.GoldenFile == "" {
+		return failures, nil
+	}
+
+	workDir, ok := ctx.Value(LulaValidationWorkDir).(string)
+	if !ok {
+		workDir = "."
+	}
+	goldenPath := l.GoldenFile
+	if !filepath.IsAbs(goldenPath) {
+		goldenPath = filepath.Join(workDir, goldenPath)
+	}
+
+	if update, _ := ctx.Value(LulaUpdateGolden).(bool); update {
+		if observations == nil {
+			observations = make(map[string]string)
+		}
+		data, err := yaml.Marshal(observations)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(goldenPath, data, 0600); err != nil {
+			return nil, fmt.Errorf("error writing golden file: %v", err)
+		}
+		message.Debugf("Updated golden file %s", goldenPath)
+		return failures, nil
+	}
+
+	data, err := os.ReadFile(goldenPath)
+	if errors.Is(err, os.ErrNotExist) {
+		failures["golden file"] = fmt.Sprintf("%s not found, run with --update-golden to create it", goldenPath)
+		return failures, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading golden file: %v", err)
+	}
+	var golden map[string]string
+	if err := yaml.Unmarshal(data, &golden); err != nil {
+		return nil, fmt.Errorf("error reading golden file %s: %v", goldenPath, err)
+	}
+
+	keys := make([]string, 0, len(golden)+len(observations))
+	for key := range golden {
+		keys = append(keys, key)
+	}
+	for key := range observations {
+		if _, ok := golden[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		expected, inGolden := golden[key]
+		actual, inObservations := observations[key]
+		switch {
+		case !inObservations:
+			failures[fmt.Sprintf("golden observation %s", key)] = "observation not found"
+		case !inGolden:
+			failures[fmt.Sprintf("golden observation %s", key)] = fmt.Sprintf("unexpected observation %q", actual)
+		case expected != actual:
+			failures[fmt.Sprintf("golden observation %s", key)] = fmt.Sprintf("expected %q, got %q", expected, actual)
+		}
+	}
+
+	return failures, nil
+}
+
 // LulaValidationTestChange is a struct that contains the details of the changes that are to be made to the resources
 // for a LulaValidationTest
 type LulaValidationTestChange struct {
-	Path     string                 `json:"path" yaml:"path"`
+	Path     string                 `json:"path,omitempty" yaml:"path,omitempty"`
 	Type     transform.ChangeType   `json:"type" yaml:"type"`
 	Value    string                 `json:"value,omitempty" yaml:"value,omitempty"`
 	ValueMap map[string]interface{} `json:"value-map,omitempty" yaml:"value-map,omitempty"`
+	// Patch is the list of operations of a json-patch change
+	Patch []transform.JSONPatchOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
 }
 
 // ValidateData validates the data in the LulaValidationTestChange struct
 func (c *LulaValidationTestChange) validateData() error {
-	if c.Path == "" {
-		return fmt.Errorf("path is empty")
-	}
 	switch c.Type {
 	case transform.ChangeTypeAdd, transform.ChangeTypeUpdate, transform.ChangeTypeDelete:
+		if c.Path == "" {
+			return fmt.Errorf("path is empty")
+		}
+	case transform.ChangeTypeJSONPatch:
+		if c.Path != "" {
+			return fmt.Errorf("path is not supported for json-patch, use the path of each operation")
+		}
+		if len(c.Patch) == 0 {
+			return fmt.Errorf("patch is empty")
+		}
+		for _, op := range c.Patch {
+			if err := op.Validate(); err != nil {
+				return err
+			}
+		}
+	case transform.ChangeTypeMergePatch:
+		if c.Path != "" {
+			return fmt.Errorf("path is not supported for merge-patch")
+		}
+		if c.ValueMap == nil {
+			return fmt.Errorf("value-map is empty")
+		}
 	default:
 		return fmt.Errorf("invalid type")
 	}
@@ -67,6 +240,18 @@ func (c *LulaValidationTestChange) validateData() error {
 	return nil
 }
 
+// apply applies the change to the transform target and returns the changed resources
+func (c *LulaValidationTestChange) apply(tt *transform.TransformTarget) (map[string]interface{}, error) {
+	switch c.Type {
+	case transform.ChangeTypeJSONPatch:
+		return tt.ExecuteJSONPatch(c.Patch)
+	case transform.ChangeTypeMergePatch:
+		return tt.ExecuteMergePatch(c.ValueMap)
+	default:
+		return tt.ExecuteTransform(c.Path, c.Type, c.Value, c.ValueMap)
+	}
+}
+
 // ExecuteTest executes a single LulaValidationTest
 func (d *LulaValidationTestData) ExecuteTest(ctx context.Context, validation *LulaValidation, resources map[string]interface{}, saveResources bool) (*LulaValidationTestResult, error) {
 	if d.Test == nil {
@@ -77,6 +262,32 @@ func (d *LulaValidationTestData) ExecuteTest(ctx context.Context, validation *Lu
 		TestName: d.Test.Name,
 	}
 
+	// Use the resources of the fixture as the base resources, if specified
+	if d.Test.Fixture != "" {
+		fixture, err := LoadFixture(ctx, d.Test.Fixture)
+		if err != nil {
+			d.Result.Pass = false
+			d.Result.Remarks = map[string]string{
+				"error loading fixture": err.Error(),
+			}
+			return d.Result, nil
+		}
+		resources = fixture
+	}
+	if resources == nil {
+		resources = make(map[string]interface{})
+	}
+
+	for _, g := range d.Test.Generators {
+		if err := g.generate(resources); err != nil {
+			d.Result.Pass = false
+			d.Result.Remarks = map[string]string{
+				"error generating resources": err.Error(),
+			}
+			return d.Result, nil
+		}
+	}
+
 	tt, err := transform.CreateTransformTarget(resources)
 	if err != nil {
 		d.Result.Pass = false
@@ -87,7 +298,7 @@ func (d *LulaValidationTestData) ExecuteTest(ctx context.Context, validation *Lu
 	}
 
 	for _, c := range d.Test.Changes {
-		resources, err = tt.ExecuteTransform(c.Path, c.Type, c.Value, c.ValueMap)
+		resources, err = c.apply(tt)
 		if err != nil {
 			d.Result.Pass = false
 			d.Result.Remarks = map[string]string{
@@ -132,6 +343,27 @@ func (d *LulaValidationTestData) ExecuteTest(ctx context.Context, validation *Lu
 	d.Result.Pass = d.Test.ExpectedResult == result
 	d.Result.Remarks = validation.Result.Observations
 
+	// Assert on the observations, adding a remark for each mismatch
+	failures, err := d.Test.checkObservations(ctx, validation.Result.Observations)
+	if err != nil {
+		d.Result.Pass = false
+		d.Result.Remarks = map[string]string{
+			"error checking observations": err.Error(),
+		}
+		return d.Result, nil
+	}
+	if len(failures) > 0 {
+		d.Result.Pass = false
+		remarks := make(map[string]string, len(validation.Result.Observations)+len(failures))
+		for k, v := range validation.Result.Observations {
+			remarks[k] = v
+		}
+		for k, v := range failures {
+			remarks[k] = v
+		}
+		d.Result.Remarks = remarks
+	}
+
 	return d.Result, nil
 }
 
@@ -89,6 +89,52 @@ func TestExecuteTest(t *testing.T) {
 		require.Equal(t, "satisfied", validationTestData.Result.Result)
 	})
 
+	t.Run("Execute test - json patch and merge patch", func(t *testing.T) {
+		resources := map[string]interface{}{
+			"test": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "test-resource",
+				},
+			},
+		}
+
+		lulaValidation := types.LulaValidation{Provider: &opaProvider}
+
+		validationTestData := &types.LulaValidationTestData{
+			Test: &types.LulaValidationTest{
+				Name: "test-patch-name",
+				Changes: []types.LulaValidationTestChange{
+					{
+						Type: transform.ChangeTypeJSONPatch,
+						Patch: []transform.JSONPatchOperation{
+							{Op: "test", Path: "/test/metadata/name", Value: "test-resource"},
+							{Op: "replace", Path: "/test/metadata/name", Value: "another-resource"},
+						},
+					},
+					{
+						Type: transform.ChangeTypeMergePatch,
+						ValueMap: map[string]interface{}{
+							"test": map[string]interface{}{
+								"metadata": map[string]interface{}{
+									"name": "test-resource",
+								},
+							},
+						},
+					},
+				},
+				ExpectedResult: "satisfied",
+			},
+		}
+		require.NoError(t, validationTestData.Test.ValidateData())
+
+		_, err := validationTestData.ExecuteTest(context.Background(), &lulaValidation, resources, false)
+		require.NoError(t, err)
+
+		require.NotNil(t, validationTestData.Result)
+		require.Equal(t, true, validationTestData.Result.Pass)
+		require.Equal(t, "satisfied", validationTestData.Result.Result)
+	})
+
 	t.Run("Execute test - print resources", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, tmpDir)
@@ -142,4 +188,224 @@ func TestExecuteTest(t *testing.T) {
 
 		require.Equal(t, expectedData, data)
 	})
+
+	t.Run("Execute test - observations", func(t *testing.T) {
+		observationProvider, err := opa.CreateOpaProvider(context.Background(), &opa.OpaSpec{
+			Rego: "package validate\n\nvalidate {input.test.metadata.name == \"test-resource\"}\n\nmsg := sprintf(\"name is %s\", [input.test.metadata.name])",
+			Output: &opa.OpaOutput{
+				Observations: []string{"validate.msg"},
+			},
+		})
+		require.NoError(t, err)
+
+		tests := []struct {
+			name        string
+			expected    []types.ExpectedObservation
+			wantPass    bool
+			wantRemarks []string
+		}{
+			{
+				name:     "equals",
+				expected: []types.ExpectedObservation{{Key: "validate.msg", Equals: "name is another-resource"}},
+				wantPass: true,
+			},
+			{
+				name:     "matches",
+				expected: []types.ExpectedObservation{{Key: "validate.msg", Matches: "^name is another-"}},
+				wantPass: true,
+			},
+			{
+				name:        "equals mismatch",
+				expected:    []types.ExpectedObservation{{Key: "validate.msg", Equals: "name is test-resource"}},
+				wantRemarks: []string{"expected observation validate.msg"},
+			},
+			{
+				name:        "missing observation",
+				expected:    []types.ExpectedObservation{{Key: "validate.other", Matches: ".*"}},
+				wantRemarks: []string{"expected observation validate.other"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resources := map[string]interface{}{
+					"test": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"name": "test-resource",
+						},
+					},
+				}
+
+				lulaValidation := types.LulaValidation{Provider: &observationProvider}
+
+				validationTestData := &types.LulaValidationTestData{
+					Test: &types.LulaValidationTest{
+						Name: "test-modify-name",
+						Changes: []types.LulaValidationTestChange{
+							{
+								Path:  "test.metadata.name",
+								Type:  transform.ChangeTypeUpdate,
+								Value: "another-resource",
+							},
+						},
+						ExpectedResult:       "not-satisfied",
+						ExpectedObservations: tt.expected,
+					},
+				}
+
+				result, err := validationTestData.ExecuteTest(context.Background(), &lulaValidation, resources, false)
+				require.NoError(t, err)
+
+				require.Equal(t, tt.wantPass, result.Pass)
+				require.Equal(t, "not-satisfied", result.Result)
+				for _, remark := range tt.wantRemarks {
+					require.Contains(t, result.Remarks, remark)
+				}
+			})
+		}
+	})
+
+	t.Run("Execute test - golden file", func(t *testing.T) {
+		observationProvider, err := opa.CreateOpaProvider(context.Background(), &opa.OpaSpec{
+			Rego: "package validate\n\nvalidate {input.test.metadata.name == \"test-resource\"}\n\nmsg := sprintf(\"name is %s\", [input.test.metadata.name])",
+			Output: &opa.OpaOutput{
+				Observations: []string{"validate.msg"},
+			},
+		})
+		require.NoError(t, err)
+
+		tmpDir := t.TempDir()
+		ctx := context.WithValue(context.Background(), types.LulaValidationWorkDir, tmpDir)
+
+		execute := func(ctx context.Context, value string) *types.LulaValidationTestResult {
+			resources := map[string]interface{}{
+				"test": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name": "test-resource",
+					},
+				},
+			}
+			lulaValidation := types.LulaValidation{Provider: &observationProvider}
+			validationTestData := &types.LulaValidationTestData{
+				Test: &types.LulaValidationTest{
+					Name: "test-modify-name",
+					Changes: []types.LulaValidationTestChange{
+						{
+							Path:  "test.metadata.name",
+							Type:  transform.ChangeTypeUpdate,
+							Value: value,
+						},
+					},
+					ExpectedResult: "not-satisfied",
+					GoldenFile:     "golden/test-modify-name.yaml",
+				},
+			}
+			result, err := validationTestData.ExecuteTest(ctx, &lulaValidation, resources, false)
+			require.NoError(t, err)
+			return result
+		}
+
+		// Fails before the golden file is created
+		result := execute(ctx, "another-resource")
+		require.False(t, result.Pass)
+		require.Contains(t, result.Remarks, "golden file")
+
+		// Updating the golden file passes and writes the observations
+		result = execute(context.WithValue(ctx, types.LulaUpdateGolden, true), "another-resource")
+		require.True(t, result.Pass)
+
+		data, err := os.ReadFile(filepath.Join(tmpDir, "golden", "test-modify-name.yaml"))
+		require.NoError(t, err)
+		require.Equal(t, "validate.msg: name is another-resource\n", string(data))
+
+		// Matches the golden file
+		result = execute(ctx, "another-resource")
+		require.True(t, result.Pass)
+
+		// A changed observation no longer matches
+		result = execute(ctx, "yet-another-resource")
+		require.False(t, result.Pass)
+		require.Equal(t, `expected "name is another-resource", got "name is yet-another-resource"`, result.Remarks["golden observation validate.msg"])
+	})
+}
+
+// TestMarshalTestReports tests marshalling test reports to each format
+func TestMarshalTestReports(t *testing.T) {
+	testReportMap := map[string]types.LulaValidationTestReport{
+		"b-uuid": {
+			Name: "validation-with-tests",
+			TestResults: []*types.LulaValidationTestResult{
+				{TestName: "passing-test", Pass: true, Result: "satisfied"},
+				{TestName: "failing-test", Pass: false, Result: "not-satisfied", Remarks: map[string]string{"validate.msg": "label foo is <missing>"}},
+				{TestName: "erroring-test", Pass: false, Remarks: map[string]string{"error loading fixture": "not found"}},
+			},
+		},
+		"a-uuid": {
+			Name:        "validation-without-tests",
+			TestResults: []*types.LulaValidationTestResult{},
+		},
+	}
+
+	t.Run("junit", func(t *testing.T) {
+		data, err := types.MarshalTestReports(testReportMap, types.TestReportFormatJUnit)
+		require.NoError(t, err)
+
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="lula" tests="3" failures="1" errors="1">
+  <testsuite name="validation-without-tests" id="a-uuid" tests="0" failures="0" errors="0"></testsuite>
+  <testsuite name="validation-with-tests" id="b-uuid" tests="3" failures="1" errors="1">
+    <testcase name="passing-test" classname="validation-with-tests"></testcase>
+    <testcase name="failing-test" classname="validation-with-tests">
+      <failure message="test failed with result not-satisfied" type="failure">validate.msg: label foo is &lt;missing&gt;</failure>
+    </testcase>
+    <testcase name="erroring-test" classname="validation-with-tests">
+      <error message="test did not produce a result" type="error">error loading fixture: not found</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+		require.Equal(t, expected, string(data))
+	})
+
+	t.Run("tap", func(t *testing.T) {
+		data, err := types.MarshalTestReports(testReportMap, types.TestReportFormatTAP)
+		require.NoError(t, err)
+
+		expected := `TAP version 13
+1..4
+ok 1 - validation-without-tests # SKIP no tests
+ok 2 - validation-with-tests: passing-test
+not ok 3 - validation-with-tests: failing-test
+  ---
+  message: "test failed with result not-satisfied"
+  result: not-satisfied
+  remarks:
+    - "validate.msg: label foo is <missing>"
+  ...
+not ok 4 - validation-with-tests: erroring-test
+  ---
+  message: "test did not produce a result"
+  remarks:
+    - "error loading fixture: not found"
+  ...
+`
+		require.Equal(t, expected, string(data))
+	})
+
+	t.Run("json and yaml", func(t *testing.T) {
+		data, err := types.MarshalTestReports(testReportMap, types.TestReportFormatJson)
+		require.NoError(t, err)
+		var fromJson map[string]types.LulaValidationTestReport
+		require.NoError(t, json.Unmarshal(data, &fromJson))
+		require.Equal(t, testReportMap, fromJson)
+
+		data, err = types.MarshalTestReports(testReportMap, types.TestReportFormatYaml)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "test-name: failing-test")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := types.MarshalTestReports(testReportMap, "html")
+		require.Error(t, err)
+	})
 }